@@ -0,0 +1,276 @@
+// Package decimal implements an arbitrary-precision decimal number type,
+// suitable for exact calculations with money.
+//
+// A decimal number [D] is an integer (of any size), the "unscaled value",
+// and a scale, the number of digits after the decimal point. For example, the
+// number 12.340 has an unscaled value of 12340 and a scale of 3.
+//
+// Addition, subtraction, and multiplication are exact. Division, and
+// reducing the scale of a number, round the result according to an explicit
+// [Rounding] mode.
+package decimal
+
+import (
+    "math/big"
+    "strings"
+)
+
+// D is an arbitrary-precision decimal number. The zero value is the number
+// zero, with a scale of zero.
+//
+// A D is an immutable value: its methods return a new D and never modify
+// their receiver or arguments. It is therefore safe to copy and to share
+// between goroutines.
+type D struct {
+    unscaled *big.Int // nil means zero; never modified once set
+    scale    int      // always >= 0
+}
+
+// Rounding is a mode for rounding a decimal number that cannot be
+// represented exactly with a given scale.
+type Rounding int
+
+const (
+    // HalfEven rounds to the nearest number, or, if exactly halfway between
+    // two numbers, to the one with an even last digit. This is also known as
+    // "banker's rounding", and does not bias totals upwards.
+    HalfEven Rounding = iota
+
+    // HalfUp rounds to the nearest number, or, if exactly halfway between two
+    // numbers, away from zero. For example, 2.5 rounds to 3, and -2.5 rounds to
+    // -3.
+    HalfUp
+
+    // Floor rounds towards negative infinity.
+    Floor
+
+    // Ceiling rounds towards positive infinity.
+    Ceiling
+
+    // Down rounds towards zero i.e. truncates.
+    Down
+
+    // Up rounds away from zero.
+    Up
+)
+
+var (
+    bigZero = big.NewInt(0)
+    bigOne  = big.NewInt(1)
+    bigTen  = big.NewInt(10)
+)
+
+// pow10 returns a new big.Int equal to 10 to the power of n, for n >= 0.
+func pow10(n int) *big.Int {
+    return new(big.Int).Exp(bigTen, big.NewInt(int64(n)), nil)
+}
+
+// New returns a new decimal number with the given unscaled value and scale.
+// For example, New(1234, 2) is 12.34. It panics if scale is negative.
+func New(unscaled int64, scale int) D {
+    return NewFromBigInt(big.NewInt(unscaled), scale)
+}
+
+// NewFromBigInt is like [New], but for an unscaled value of any size. The
+// argument is copied, so may be modified afterwards.
+func NewFromBigInt(unscaled *big.Int, scale int) D {
+    if scale < 0 { panic("decimal: negative scale") }
+    return D{unscaled: new(big.Int).Set(unscaled), scale: scale}
+}
+
+// int returns the unscaled value, which must not be modified.
+func (d D) int() *big.Int {
+    if d.unscaled == nil { return bigZero }
+    return d.unscaled
+}
+
+// Unscaled returns a copy of the unscaled value of d.
+func (d D) Unscaled() *big.Int {
+    return new(big.Int).Set(d.int())
+}
+
+// Scale returns the number of digits after the decimal point.
+func (d D) Scale() int {
+    return d.scale
+}
+
+// Sign returns -1 if d < 0, 0 if d == 0, or +1 if d > 0.
+func (d D) Sign() int {
+    return d.int().Sign()
+}
+
+// IsZero returns true iff d is zero, with any scale.
+func (d D) IsZero() bool {
+    return d.Sign() == 0
+}
+
+// Neg returns -d.
+func (d D) Neg() D {
+    return D{unscaled: new(big.Int).Neg(d.int()), scale: d.scale}
+}
+
+// Abs returns the absolute value of d.
+func (d D) Abs() D {
+    return D{unscaled: new(big.Int).Abs(d.int()), scale: d.scale}
+}
+
+// rescaled returns the unscaled value of d at a scale greater than or equal
+// to the scale of d.
+func (d D) rescaled(scale int) *big.Int {
+    if scale == d.scale { return d.int() }
+    return new(big.Int).Mul(d.int(), pow10(scale - d.scale))
+}
+
+func maxScale(a D, b D) int {
+    if a.scale > b.scale { return a.scale }
+    return b.scale
+}
+
+// Add returns d + e, exactly. The scale of the result is the greater of the
+// scales of d and e.
+func (d D) Add(e D) D {
+    scale := maxScale(d, e)
+    return D{unscaled: new(big.Int).Add(d.rescaled(scale), e.rescaled(scale)), scale: scale}
+}
+
+// Sub returns d - e, exactly. The scale of the result is the greater of the
+// scales of d and e.
+func (d D) Sub(e D) D {
+    scale := maxScale(d, e)
+    return D{unscaled: new(big.Int).Sub(d.rescaled(scale), e.rescaled(scale)), scale: scale}
+}
+
+// Mul returns d * e, exactly. The scale of the result is the sum of the
+// scales of d and e. Use [D.Round] to reduce the scale.
+func (d D) Mul(e D) D {
+    return D{unscaled: new(big.Int).Mul(d.int(), e.int()), scale: d.scale + e.scale}
+}
+
+// Div returns d / e, rounded to the given scale with the given rounding mode.
+// It panics if e is zero, or if scale is negative.
+//
+// For example, to divide an amount of money into three parts, rounded to the
+// nearest penny: amount.Div(decimal.New(3, 0), 2, decimal.HalfEven).
+func (d D) Div(e D, scale int, mode Rounding) D {
+    if e.IsZero() { panic("decimal: division by zero") }
+    if scale < 0 { panic("decimal: negative scale") }
+
+    // d / e = (d.int / 10^d.scale) / (e.int / 10^e.scale)
+    // so the unscaled result is d.int * 10^(scale + e.scale - d.scale) / e.int
+    n, m := new(big.Int).Set(d.int()), new(big.Int).Set(e.int())
+    if exp := scale + e.scale - d.scale; exp >= 0 {
+        n.Mul(n, pow10(exp))
+    } else {
+        m.Mul(m, pow10(-exp))
+    }
+
+    return D{unscaled: quo(n, m, mode), scale: scale}
+}
+
+// Round returns d with the given scale. If the scale is greater than the
+// scale of d, the result is exact, with trailing zeros. Otherwise, the result
+// is rounded with the given rounding mode. It panics if scale is negative.
+//
+// For example, Round(2, HalfUp) on 1.005 is 1.01, and Round(3, HalfUp) on
+// 1.5 is 1.500.
+func (d D) Round(scale int, mode Rounding) D {
+    if scale < 0 { panic("decimal: negative scale") }
+    if scale >= d.scale { return D{unscaled: d.rescaled(scale), scale: scale} }
+    n := new(big.Int).Set(d.int())
+    return D{unscaled: quo(n, pow10(d.scale - scale), mode), scale: scale}
+}
+
+// Normalize returns d with the smallest scale that represents it exactly,
+// i.e. without trailing zeros after the decimal point.
+func (d D) Normalize() D {
+    if d.IsZero() { return D{} }
+    n := new(big.Int).Set(d.int())
+    scale := d.scale
+    q, r := new(big.Int), new(big.Int)
+    for scale > 0 {
+        q.QuoRem(n, bigTen, r)
+        if r.Sign() != 0 { break }
+        n, q = q, n
+        scale--
+    }
+    return D{unscaled: n, scale: scale}
+}
+
+// quo returns n / m, rounded with the given rounding mode, where m is not
+// zero. It may modify n and m.
+func quo(n *big.Int, m *big.Int, mode Rounding) *big.Int {
+    if m.Sign() < 0 {
+        n.Neg(n)
+        m.Neg(m)
+    }
+
+    q, r := new(big.Int).QuoRem(n, m, new(big.Int))
+    if r.Sign() == 0 { return q }
+
+    // q is truncated towards zero, so the exact result lies between q and
+    // q + sign in the direction away from zero.
+    sign := n.Sign()
+    half := r.Abs(r).Lsh(r, 1).Cmp(m) // compare the remainder with m/2
+
+    away := false
+    switch mode {
+        case HalfEven: away = (half > 0) || ((half == 0) && (q.Bit(0) == 1))
+        case HalfUp:   away = (half >= 0)
+        case Floor:    away = (sign < 0)
+        case Ceiling:  away = (sign > 0)
+        case Down:     away = false
+        case Up:       away = true
+        default:       panic("decimal: invalid rounding mode")
+    }
+
+    if away {
+        if sign < 0 { q.Sub(q, bigOne) } else { q.Add(q, bigOne) }
+    }
+    return q
+}
+
+// Cmp compares d and e numerically, regardless of scale, and returns -1 if
+// d < e, 0 if d == e, or +1 if d > e.
+func (d D) Cmp(e D) int {
+    scale := maxScale(d, e)
+    return d.rescaled(scale).Cmp(e.rescaled(scale))
+}
+
+// Equal returns true iff d and e are numerically equal, regardless of scale.
+// For example, 1.5 is equal to 1.50.
+func (d D) Equal(e D) bool {
+    return d.Cmp(e) == 0
+}
+
+// String returns d as a string of decimal digits, with a leading minus sign
+// if negative, and with a decimal point followed by exactly Scale digits if
+// the scale is greater than zero. For example, "-12.340".
+func (d D) String() string {
+    digits := new(big.Int).Abs(d.int()).String()
+
+    var sb strings.Builder
+    if d.Sign() < 0 { sb.WriteByte('-') }
+
+    if d.scale == 0 {
+        sb.WriteString(digits)
+        return sb.String()
+    }
+
+    if len(digits) <= d.scale {
+        digits = strings.Repeat("0", d.scale - len(digits) + 1) + digits
+    }
+
+    point := len(digits) - d.scale
+    sb.WriteString(digits[:point])
+    sb.WriteByte('.')
+    sb.WriteString(digits[point:])
+    return sb.String()
+}
+
+// Float64 returns the nearest float64 value to d, and true iff the result
+// represents d exactly. This is intended only for display or approximate
+// calculations.
+func (d D) Float64() (float64, bool) {
+    r := new(big.Rat).SetFrac(d.int(), pow10(d.scale))
+    return r.Float64()
+}
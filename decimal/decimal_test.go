@@ -0,0 +1,183 @@
+package decimal_test
+
+import (
+    "encoding/json"
+    "strings"
+    "testing"
+
+    "github.com/stretchr/testify/assert"
+    "github.com/tawesoft/golib/v2/decimal"
+    "github.com/tawesoft/golib/v2/text/number/symbols"
+)
+
+func TestParse(t *testing.T) {
+    type row struct {
+        input    string
+        expected string
+        scale    int
+    }
+
+    rows := []row{
+        {"0", "0", 0},
+        {"-0", "0", 0},
+        {"12.340", "12.340", 3},
+        {"-012.340", "-12.340", 3},
+        {"+1", "1", 0},
+        {".5", "0.5", 1},
+        {"5.", "5", 0},
+        {"-0.001", "-0.001", 3},
+        {"1.5e2", "150", 0},
+        {"1.5E-2", "0.015", 3},
+        {"15e-1", "1.5", 1},
+        {"1e00002", "100", 0},
+        {"1e-9999", "0."+strings.Repeat("0", 9998)+"1", 9999},
+        {"123456789012345678901234567890.123456789", "123456789012345678901234567890.123456789", 9},
+    }
+
+    for _, r := range rows {
+        d, err := decimal.Parse(r.input)
+        if !assert.NoError(t, err, r.input) { continue }
+        assert.Equal(t, r.expected, d.String(), r.input)
+        assert.Equal(t, r.scale, d.Scale(), r.input)
+    }
+
+    for _, input := range []string{"", "-", ".", "1.2.3", "1,5", "e5", "1e", "1e+", "1e99999", "1e10000", "0x10", " 1", "1_000"} {
+        _, err := decimal.Parse(input)
+        assert.Error(t, err, input)
+    }
+}
+
+func TestParseLocale(t *testing.T) {
+    type row struct {
+        input    string
+        locale   symbols.Symbols
+        expected string
+    }
+
+    en := symbols.Get_("en", "", "", "", "")
+    de := symbols.Get_("de", "", "", "", "")
+    fr := symbols.Get_("fr", "", "", "", "")
+    ar := symbols.Get_("ar", "", "eg", "", "arab")
+
+    rows := []row{
+        {"-1,234.5", en, "-1234.5"},
+        {"-1.234,5", de, "-1234.5"},
+        {"1 234,56", fr, "1234.56"},
+        {"1 234,56", fr, "1234.56"},
+        {"١٢٣٫٤٥", ar, "123.45"},
+        {ar.MinusSign + "٣", ar, "-3"},
+    }
+
+    for _, r := range rows {
+        d, err := decimal.ParseLocale(r.input, r.locale)
+        if !assert.NoError(t, err, r.input) { continue }
+        assert.Equal(t, r.expected, d.String(), r.input)
+    }
+
+    _, err := decimal.ParseLocale("1.5e3", en)
+    assert.Error(t, err)
+    _, err = decimal.ParseLocale("1,5x", de)
+    assert.Error(t, err)
+}
+
+func TestArithmetic(t *testing.T) {
+    m := decimal.MustParse
+
+    assert.Equal(t, "3.30", m("1.1").Add(m("2.20")).String())
+    assert.Equal(t, "-1.10", m("1.1").Sub(m("2.20")).String())
+    assert.Equal(t, "0.30", m("0.1").Add(m("0.2")).Mul(m("1.0")).String())
+    assert.Equal(t, "2.4200", m("1.10").Mul(m("2.20")).String())
+    assert.Equal(t, "1.5", m("-1.5").Abs().String())
+    assert.Equal(t, "-1.5", m("1.5").Neg().String())
+    assert.Equal(t, "0.00", decimal.D{}.Round(2, decimal.HalfEven).String())
+    assert.Equal(t, "1.2", m("1.2000").Normalize().String())
+    assert.Equal(t, "0", m("0.000").Normalize().String())
+
+    assert.Equal(t, 0, m("1.5").Cmp(m("1.50")))
+    assert.Equal(t, -1, m("-2").Cmp(m("1.50")))
+    assert.Equal(t, 1, m("0.01").Cmp(decimal.D{}))
+    assert.True(t, m("1.5").Equal(m("1.500")))
+}
+
+func TestRounding(t *testing.T) {
+    type row struct {
+        input    string
+        mode     decimal.Rounding
+        expected string
+    }
+
+    rows := []row{
+        {"2.5",   decimal.HalfEven, "2"},
+        {"3.5",   decimal.HalfEven, "4"},
+        {"-2.5",  decimal.HalfEven, "-2"},
+        {"2.51",  decimal.HalfEven, "3"},
+        {"2.5",   decimal.HalfUp,   "3"},
+        {"-2.5",  decimal.HalfUp,   "-3"},
+        {"2.49",  decimal.HalfUp,   "2"},
+        {"2.1",   decimal.Floor,    "2"},
+        {"-2.1",  decimal.Floor,    "-3"},
+        {"2.1",   decimal.Ceiling,  "3"},
+        {"-2.1",  decimal.Ceiling,  "-2"},
+        {"-2.9",  decimal.Down,     "-2"},
+        {"2.1",   decimal.Up,       "3"},
+        {"-2.1",  decimal.Up,       "-3"},
+        {"2.000", decimal.Up,       "2"},
+    }
+
+    for _, r := range rows {
+        got := decimal.MustParse(r.input).Round(0, r.mode).String()
+        assert.Equal(t, r.expected, got, "%s mode %d", r.input, r.mode)
+    }
+
+    assert.Equal(t, "1.01", decimal.MustParse("1.005").Round(2, decimal.HalfUp).String())
+    assert.Equal(t, "1.00", decimal.MustParse("1.005").Round(2, decimal.HalfEven).String())
+    assert.Equal(t, "1.500", decimal.MustParse("1.5").Round(3, decimal.HalfUp).String())
+}
+
+func TestDiv(t *testing.T) {
+    m := decimal.MustParse
+
+    assert.Equal(t, "3.33", m("10").Div(m("3"), 2, decimal.HalfEven).String())
+    assert.Equal(t, "6.67", m("20").Div(m("3"), 2, decimal.HalfEven).String())
+    assert.Equal(t, "-6.66", m("20").Div(m("-3"), 2, decimal.Down).String())
+    assert.Equal(t, "-6.67", m("-20").Div(m("3"), 2, decimal.Floor).String())
+    assert.Equal(t, "400", m("1.2").Div(m("0.003"), 0, decimal.HalfEven).String())
+    assert.Equal(t, "0.04", m("0.125").Div(m("3.125"), 2, decimal.HalfEven).String())
+    assert.Equal(t, "0.2", m("0.25").Div(m("1"), 1, decimal.HalfEven).String())
+    assert.Equal(t, "0.3", m("0.25").Div(m("1"), 1, decimal.HalfUp).String())
+
+    assert.Panics(t, func() { m("1").Div(m("0.00"), 2, decimal.HalfEven) })
+}
+
+func TestEncoding(t *testing.T) {
+    type invoice struct {
+        Total decimal.D `json:"total"`
+    }
+
+    data, err := json.Marshal(invoice{decimal.MustParse("-12.340")})
+    assert.NoError(t, err)
+    assert.Equal(t, `{"total":"-12.340"}`, string(data))
+
+    var i invoice
+    assert.NoError(t, json.Unmarshal([]byte(`{"total":"0.10"}`), &i))
+    assert.Equal(t, "0.10", i.Total.String())
+    assert.NoError(t, json.Unmarshal([]byte(`{"total": 1.25e1}`), &i))
+    assert.Equal(t, "12.5", i.Total.String())
+    assert.Error(t, json.Unmarshal([]byte(`{"total":"x"}`), &i))
+    assert.Equal(t, "12.5", i.Total.String())
+    assert.NoError(t, json.Unmarshal([]byte(`{"total":null}`), &i))
+    assert.Equal(t, "12.5", i.Total.String())
+
+    var d decimal.D
+    assert.NoError(t, d.Scan([]byte("1.50")))
+    assert.Equal(t, "1.50", d.String())
+    assert.NoError(t, d.Scan(int64(-7)))
+    assert.Equal(t, "-7", d.String())
+    assert.NoError(t, d.Scan(0.1))
+    assert.Equal(t, "0.1", d.String())
+    assert.Error(t, d.Scan(nil))
+
+    v, err := decimal.MustParse("9.99").Value()
+    assert.NoError(t, err)
+    assert.Equal(t, "9.99", v)
+}
@@ -0,0 +1,78 @@
+package decimal
+
+import (
+    "bytes"
+    "database/sql/driver"
+    "encoding/json"
+    "fmt"
+    "strconv"
+)
+
+// MarshalJSON implements the [json.Marshaler] interface. A D is encoded as a
+// JSON string, such as "12.34", because many JSON decoders would otherwise
+// lose precision by decoding the number as a floating point value.
+func (d D) MarshalJSON() ([]byte, error) {
+    return json.Marshal(d.String())
+}
+
+// UnmarshalJSON implements the [json.Unmarshaler] interface. Either a JSON
+// string or a JSON number is accepted, in the format accepted by [Parse]. If
+// decoding fails, the D is left unchanged and the error is returned.
+//
+// By convention, a JSON null leaves the D unchanged, without error.
+func (d *D) UnmarshalJSON(data []byte) error {
+    data = bytes.TrimSpace(data)
+    if string(data) == "null" { return nil }
+    if (len(data) > 0) && (data[0] == '"') {
+        var s string
+        if err := json.Unmarshal(data, &s); err != nil { return err }
+        data = []byte(s)
+    }
+    return d.UnmarshalText(data)
+}
+
+// MarshalText implements the [encoding.TextMarshaler] interface, in the
+// format returned by [D.String].
+func (d D) MarshalText() ([]byte, error) {
+    return []byte(d.String()), nil
+}
+
+// UnmarshalText implements the [encoding.TextUnmarshaler] interface, in the
+// format accepted by [Parse]. If decoding fails, the D is left unchanged and
+// the error is returned.
+func (d *D) UnmarshalText(text []byte) error {
+    x, err := Parse(string(text))
+    if err != nil { return err }
+    *d = x
+    return nil
+}
+
+// Value implements the [database/sql/driver.Valuer] interface. A D is stored
+// as a string, such as "12.34", which databases convert exactly to a
+// DECIMAL or NUMERIC column.
+func (d D) Value() (driver.Value, error) {
+    return d.String(), nil
+}
+
+// Scan implements the [database/sql.Scanner] interface. A database value
+// that is a string or a byte slice is parsed with [Parse], and an integer is
+// converted exactly. A floating point value is converted from its shortest
+// decimal representation e.g. 0.1 scans as 0.1. If conversion fails, the D
+// is left unchanged and the error is returned.
+//
+// To scan a nullable column, use maybe.M[decimal.D].
+func (d *D) Scan(src any) error {
+    switch s := src.(type) {
+        case string:
+            return d.UnmarshalText([]byte(s))
+        case []byte:
+            return d.UnmarshalText(s)
+        case int64:
+            *d = New(s, 0)
+            return nil
+        case float64:
+            return d.UnmarshalText(strconv.AppendFloat(nil, s, 'g', -1, 64))
+        default:
+            return fmt.Errorf("decimal: unsupported Scan, storing driver.Value type %T into type decimal.D", src)
+    }
+}
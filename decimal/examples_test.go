@@ -0,0 +1,39 @@
+package decimal_test
+
+import (
+    "fmt"
+
+    "github.com/tawesoft/golib/v2/decimal"
+    "github.com/tawesoft/golib/v2/text/number/symbols"
+)
+
+func Example() {
+    price := decimal.MustParse("19.99")
+    quantity := decimal.New(3, 0)
+    taxRate := decimal.MustParse("0.175")
+
+    subtotal := price.Mul(quantity)
+    tax := subtotal.Mul(taxRate).Round(2, decimal.HalfUp)
+    total := subtotal.Add(tax)
+
+    fmt.Printf("Subtotal: %s\n", subtotal)
+    fmt.Printf("Tax: %s\n", tax)
+    fmt.Printf("Total: %s\n", total)
+    fmt.Printf("Each of 7 parts: %s\n", total.Div(decimal.New(7, 0), 2, decimal.HalfEven))
+
+    // Output:
+    // Subtotal: 59.97
+    // Tax: 10.49
+    // Total: 70.46
+    // Each of 7 parts: 10.07
+}
+
+func ExampleParseLocale() {
+    de := symbols.Get_("de", "", "", "", "")
+
+    d, err := decimal.ParseLocale("-1.234,56", de)
+    if err != nil { panic(err) }
+    fmt.Println(d)
+
+    // Output: -1234.56
+}
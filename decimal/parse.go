@@ -0,0 +1,179 @@
+package decimal
+
+import (
+    "fmt"
+    "math/big"
+    "strconv"
+    "strings"
+    "unicode/utf8"
+
+    "github.com/tawesoft/golib/v2/text/np"
+    "github.com/tawesoft/golib/v2/text/number/symbols"
+)
+
+// maxExponent limits the exponent of a number in scientific notation, so
+// that parsing a short string cannot allocate an enormous number.
+const maxExponent = 9999
+
+// Parse parses a decimal number from a string of decimal digits, with an
+// optional leading "+" or "-" sign, and an optional "." decimal point. The
+// scale of the result is the number of digits after the decimal point. For
+// example, "-012.340" parses as -12.340 with a scale of 3.
+//
+// An exponent, written "e" or "E" followed by an optionally signed integer
+// from -9999 to 9999 inclusive, is also accepted, such that "1.5e2" parses
+// as 150 and "15e-1" parses as 1.5.
+func Parse(s string) (D, error) {
+    d, err := parse(s)
+    if err != nil { return D{}, fmt.Errorf("decimal: cannot parse %q: %w", s, err) }
+    return d, nil
+}
+
+// MustParse is like [Parse], but panics on error. It is intended for
+// constants in source code.
+func MustParse(s string) D {
+    d, err := Parse(s)
+    if err != nil { panic(err) }
+    return d
+}
+
+var (
+    errSyntax   = fmt.Errorf("invalid syntax")
+    errExponent = fmt.Errorf("exponent out of range")
+)
+
+func parse(s string) (D, error) {
+    mantissa, exponent, hasExponent := s, "", false
+    if i := strings.IndexAny(s, "eE"); i >= 0 {
+        mantissa, exponent, hasExponent = s[:i], s[i+1:], true
+    }
+
+    sign := ""
+    if (len(mantissa) > 0) && ((mantissa[0] == '+') || (mantissa[0] == '-')) {
+        sign, mantissa = mantissa[:1], mantissa[1:]
+    }
+
+    whole, frac, _ := strings.Cut(mantissa, ".")
+    if (whole == "") && (frac == "") { return D{}, errSyntax }
+    if !isDigits(whole) || !isDigits(frac) { return D{}, errSyntax }
+
+    n, ok := new(big.Int).SetString(sign + whole + frac, 10)
+    if !ok { return D{}, errSyntax }
+    scale := len(frac)
+
+    if hasExponent {
+        digits := strings.TrimLeft(exponent, "+-")
+        if (len(exponent) - len(digits) > 1) || (digits == "") || !isDigits(digits) {
+            return D{}, errSyntax
+        }
+
+        exp, err := strconv.Atoi(exponent)
+        if (err != nil) || (exp < -maxExponent) || (exp > maxExponent) {
+            return D{}, errExponent
+        }
+
+        scale -= exp
+        if scale < 0 {
+            n.Mul(n, pow10(-scale))
+            scale = 0
+        }
+    }
+
+    return D{unscaled: n, scale: scale}, nil
+}
+
+func isDigits(s string) bool {
+    for i := 0; i < len(s); i++ {
+        if (s[i] < '0') || (s[i] > '9') { return false }
+    }
+    return true
+}
+
+// bidi returns s without any Unicode bidirectional formatting marks, which
+// CLDR uses in some locales around the plus and minus signs.
+func bidi(s string) string {
+    return strings.Map(func(r rune) rune {
+        switch r {
+            case '\u200e', '\u200f', '\u061c': return -1
+            default: return r
+        }
+    }, s)
+}
+
+// ParseLocale is like [Parse], but parses a number formatted according to
+// the given locale's number symbols. For example, with the symbols for
+// German, "-1.234,5" parses as -1234.5.
+//
+// The locale's group separators (e.g. thousands separators) are ignored.
+// Digits may be decimal digits from any script, such as Arabic-Indic digits.
+// Scientific notation is not accepted.
+//
+// For example:
+//
+//     d, err := decimal.ParseLocale("1 234,56", symbols.Get_("fr", "", "", "", ""))
+func ParseLocale(s string, sym symbols.Symbols) (D, error) {
+    d, err := parseLocale(s, sym)
+    if err != nil { return D{}, fmt.Errorf("decimal: cannot parse %q: %w", s, err) }
+    return d, nil
+}
+
+func parseLocale(s string, sym symbols.Symbols) (D, error) {
+    decimal := sym.Decimal
+    if decimal == "" { decimal = "." }
+    minus, plus := bidi(sym.MinusSign), bidi(sym.PlusSign)
+    if minus == "" { minus = "-" }
+    if plus == "" { plus = "+" }
+
+    var groups []string
+    if sym.Group != "" {
+        groups = append(groups, sym.Group)
+        switch sym.Group {
+            // no-break spaces are commonly typed as spaces
+            case "\u00a0", "\u202f": groups = append(groups, " ", "\u00a0", "\u202f")
+        }
+    }
+
+    s = strings.TrimSpace(bidi(s))
+
+    var sb strings.Builder
+    for len(s) > 0 {
+        switch {
+            case strings.HasPrefix(s, decimal):
+                sb.WriteByte('.')
+                s = s[len(decimal):]
+                continue
+            case strings.HasPrefix(s, minus):
+                sb.WriteByte('-')
+                s = s[len(minus):]
+                continue
+            case strings.HasPrefix(s, plus):
+                sb.WriteByte('+')
+                s = s[len(plus):]
+                continue
+        }
+
+        if g := hasAnyPrefix(s, groups); g != "" {
+            s = s[len(g):]
+            continue
+        }
+
+        r, size := utf8.DecodeRuneInString(s)
+        t, v := np.Get(r)
+        if (t != np.Decimal) || (v.Denominator != 1) || (v.Numerator < 0) || (v.Numerator > 9) {
+            return D{}, errSyntax
+        }
+        sb.WriteByte('0' + byte(v.Numerator))
+        s = s[size:]
+    }
+
+    str := sb.String()
+    if strings.ContainsAny(str, "eE") { return D{}, errSyntax }
+    return parse(str)
+}
+
+func hasAnyPrefix(s string, prefixes []string) string {
+    for _, p := range prefixes {
+        if strings.HasPrefix(s, p) { return p }
+    }
+    return ""
+}
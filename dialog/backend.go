@@ -0,0 +1,267 @@
+package dialog
+
+import (
+    "fmt"
+    "image/color"
+    "os"
+    "sync"
+    "time"
+
+    "github.com/tawesoft/golib/v2/operator"
+)
+
+// EnvBackend is the name of an environment variable that, if set to the name
+// of a registered [Backend], forces every dialog to use that backend. For
+// example, "GOLIB_DIALOG_BACKEND=none" disables every dialog, which may be
+// useful in headless environments.
+//
+// A backend forced with [Use] takes priority over this environment variable.
+const EnvBackend = "GOLIB_DIALOG_BACKEND"
+
+// Backend implements each type of dialog.
+//
+// Each method receives a dialog after defaults (such as a default title)
+// have been applied. Messages are received already formatted with their
+// arguments, and the Format and Args fields cleared (and likewise the body of
+// a notification). Where a Backend does not
+// support a dialog, as reported by its Supported method, that method must
+// return immediately without blocking, in the manner documented by the
+// corresponding dialog method, e.g. [Message.Ask].
+//
+// Most users will not need to implement a Backend, except for testing (see
+// [Script]) or to integrate with an unusual environment.
+type Backend interface {
+    Supported() Support
+    MessageRaise(m Message, message string) error
+    MessageAsk(m Message, message string) (bool, error)
+    QuestionAsk(m Question, message string) (int, bool, error)
+    FileOpen(m FilePicker) (string, bool, error)
+    FileOpenMultiple(m FilePicker) ([]string, bool, error)
+    FileSave(m FilePicker) (string, bool, error)
+    ColorPick(m ColorPicker) (color.Color, bool, error)
+    DatePick(m DatePicker) (time.Time, bool, error)
+    TextAsk(m TextInput) (string, bool, error)
+    PasswordAsk(m PasswordInput) (string, bool, error)
+    ChoicePick(m Choice) (int, bool, error)
+    ChoicePickMultiple(m Choice) ([]int, bool, error)
+    ProgressStart(m Progress) (ProgressUpdater, error)
+    Notify(n Notification, body string) (<-chan int, error)
+}
+
+// initErr is any error encountered by the platform-specific initialisation
+// of backends, returned by [Supported].
+var initErr error
+
+var backends = struct {
+    mu     sync.RWMutex
+    named  map[string]Backend
+    forced Backend
+}{
+    named: map[string]Backend{
+        "none": funs{},
+    },
+}
+
+// Register makes a Backend available by name, for example so that it may be
+// selected with the [EnvBackend] environment variable. Registering a name
+// again replaces the previous Backend with that name.
+//
+// This package registers the backend "none", which supports nothing, the
+// backend "auto", which is the default and picks the best available
+// implementation of each type of dialog, and a backend for each
+// implementation available on the current platform, such as "zenity" or
+// "windows".
+func Register(name string, b Backend) {
+    backends.mu.Lock()
+    defer backends.mu.Unlock()
+    backends.named[name] = b
+}
+
+// Lookup returns a registered Backend by name.
+func Lookup(name string) (Backend, bool) {
+    backends.mu.RLock()
+    defer backends.mu.RUnlock()
+    b, ok := backends.named[name]
+    return b, ok
+}
+
+// Use forces every dialog to use the given Backend, and returns the Backend
+// previously forced, if any, or nil. If b is nil, removes any forced Backend.
+//
+// For example, in a test:
+//
+//     script := &dialog.Script{}
+//     defer dialog.Use(dialog.Use(script))
+func Use(b Backend) Backend {
+    backends.mu.Lock()
+    defer backends.mu.Unlock()
+    previous := backends.forced
+    backends.forced = b
+    return previous
+}
+
+// Current returns the Backend used by dialogs: one forced by [Use], or else
+// one selected by the [EnvBackend] environment variable, or else the "auto"
+// backend.
+//
+// An error is returned if the environment variable names an unregistered
+// Backend, in which case every dialog also returns this error.
+func Current() (Backend, error) {
+    backends.mu.RLock()
+    forced := backends.forced
+    backends.mu.RUnlock()
+    if forced != nil { return forced, nil }
+
+    name := os.Getenv(EnvBackend)
+    if name == "" { name = "auto" }
+
+    if b, ok := Lookup(name); ok {
+        return b, nil
+    }
+    return funs{}, fmt.Errorf("dialog: unknown backend %q named by environment variable %s", name, EnvBackend)
+}
+
+// funs implements a Backend where each type of dialog is implemented by an
+// optional function. A nil function means the dialog is not supported.
+type funs struct {
+    // names of the implementations used, in order of priority
+    names []string
+
+    messageRaise         func(m Message, message string) error
+    messageAsk           func(m Message, message string) (bool, error)
+    questionAsk          func(m Question, message string) (int, bool, error)
+    filePickOpen         func(m FilePicker)  (string, bool, error)
+    filePickOpenMultiple func(m FilePicker)  ([]string, bool, error)
+    filePickSave         func(m FilePicker)  (string, bool, error)
+    colorPick            func(m ColorPicker) (color.Color, bool, error)
+    datePick             func(m DatePicker)  (time.Time, bool, error)
+    textAsk              func(m TextInput)     (string, bool, error)
+    passwordAsk          func(m PasswordInput) (string, bool, error)
+    choicePick           func(m Choice)        (int, bool, error)
+    choicePickMultiple   func(m Choice)        ([]int, bool, error)
+    progressStart        func(m Progress)      (ProgressUpdater, error)
+    notify               func(n Notification, body string) (<-chan int, error)
+}
+
+// merge returns a copy of f, where any unsupported dialog is implemented by
+// g instead, if supported by g.
+func (f funs) merge(g funs) funs {
+    used := false
+    fill := func(dest, src bool) bool {
+        if dest || !src { return false }
+        used = true
+        return true
+    }
+
+    if fill(f.messageRaise         != nil, g.messageRaise         != nil) { f.messageRaise         = g.messageRaise }
+    if fill(f.messageAsk           != nil, g.messageAsk           != nil) { f.messageAsk           = g.messageAsk }
+    if fill(f.questionAsk          != nil, g.questionAsk          != nil) { f.questionAsk          = g.questionAsk }
+    if fill(f.filePickOpen         != nil, g.filePickOpen         != nil) { f.filePickOpen         = g.filePickOpen }
+    if fill(f.filePickOpenMultiple != nil, g.filePickOpenMultiple != nil) { f.filePickOpenMultiple = g.filePickOpenMultiple }
+    if fill(f.filePickSave         != nil, g.filePickSave         != nil) { f.filePickSave         = g.filePickSave }
+    if fill(f.colorPick            != nil, g.colorPick            != nil) { f.colorPick            = g.colorPick }
+    if fill(f.datePick             != nil, g.datePick             != nil) { f.datePick             = g.datePick }
+    if fill(f.textAsk              != nil, g.textAsk              != nil) { f.textAsk              = g.textAsk }
+    if fill(f.passwordAsk          != nil, g.passwordAsk          != nil) { f.passwordAsk          = g.passwordAsk }
+    if fill(f.choicePick           != nil, g.choicePick           != nil) { f.choicePick           = g.choicePick }
+    if fill(f.choicePickMultiple   != nil, g.choicePickMultiple   != nil) { f.choicePickMultiple   = g.choicePickMultiple }
+    if fill(f.progressStart        != nil, g.progressStart        != nil) { f.progressStart        = g.progressStart }
+    if fill(f.notify               != nil, g.notify               != nil) { f.notify               = g.notify }
+
+    if used {
+        f.names = append(append([]string(nil), f.names...), g.names...)
+    }
+    return f
+}
+
+func (f funs) Supported() Support {
+    return Support{
+        MessageRaise:    f.messageRaise         != nil,
+        MessageAsk:      f.messageAsk           != nil,
+        Question:        f.questionAsk          != nil,
+        FilePicker:      f.filePickOpen         != nil,
+        MultiFilePicker: f.filePickOpenMultiple != nil,
+        ColorPicker:     f.colorPick            != nil,
+        DatePicker:      f.datePick             != nil,
+        TextInput:       f.textAsk              != nil,
+        PasswordInput:   f.passwordAsk          != nil,
+        Choice:          f.choicePick           != nil,
+        MultiChoice:     f.choicePickMultiple   != nil,
+        Progress:        f.progressStart        != nil,
+        Notification:    f.notify               != nil,
+    }
+}
+
+func (f funs) backends() []string {
+    return append([]string(nil), f.names...)
+}
+
+func (f funs) MessageRaise(m Message, message string) error {
+    if f.messageRaise == nil { return nil }
+    return f.messageRaise(m, message)
+}
+
+func (f funs) MessageAsk(m Message, message string) (bool, error) {
+    if f.messageAsk == nil { return true, nil }
+    return f.messageAsk(m, message)
+}
+
+func (f funs) QuestionAsk(m Question, message string) (int, bool, error) {
+    if f.questionAsk == nil { return m.Default, false, nil }
+    return f.questionAsk(m, message)
+}
+
+func (f funs) FileOpen(m FilePicker) (string, bool, error) {
+    if f.filePickOpen == nil { return "", false, nil }
+    return f.filePickOpen(m)
+}
+
+func (f funs) FileOpenMultiple(m FilePicker) ([]string, bool, error) {
+    if f.filePickOpenMultiple == nil { return []string{}, false, nil }
+    return f.filePickOpenMultiple(m)
+}
+
+func (f funs) FileSave(m FilePicker) (string, bool, error) {
+    if f.filePickSave == nil { return "", false, nil }
+    return f.filePickSave(m)
+}
+
+func (f funs) ColorPick(m ColorPicker) (color.Color, bool, error) {
+    if f.colorPick == nil { return operator.Zero[color.Color](), false, nil }
+    return f.colorPick(m)
+}
+
+func (f funs) DatePick(m DatePicker) (time.Time, bool, error) {
+    if f.datePick == nil { return operator.Zero[time.Time](), false, nil }
+    return f.datePick(m)
+}
+
+func (f funs) TextAsk(m TextInput) (string, bool, error) {
+    if f.textAsk == nil { return "", false, nil }
+    return f.textAsk(m)
+}
+
+func (f funs) PasswordAsk(m PasswordInput) (string, bool, error) {
+    if f.passwordAsk == nil { return "", false, nil }
+    return f.passwordAsk(m)
+}
+
+func (f funs) ChoicePick(m Choice) (int, bool, error) {
+    if f.choicePick == nil { return 0, false, nil }
+    return f.choicePick(m)
+}
+
+func (f funs) ChoicePickMultiple(m Choice) ([]int, bool, error) {
+    if f.choicePickMultiple == nil { return []int{}, false, nil }
+    return f.choicePickMultiple(m)
+}
+
+func (f funs) ProgressStart(m Progress) (ProgressUpdater, error) {
+    if f.progressStart == nil { return noProgress{}, nil }
+    return f.progressStart(m)
+}
+
+func (f funs) Notify(n Notification, body string) (<-chan int, error) {
+    if f.notify == nil { return closedAction(), nil }
+    return f.notify(n, body)
+}
@@ -4,39 +4,27 @@ package dialog
 
 import (
     "fmt"
-    "image/color"
+    "os"
     "os/exec"
     "strings"
-    "time"
 
     "github.com/alessio/shellescape"
-    "github.com/tawesoft/golib/v2/operator"
+    "github.com/tawesoft/golib/v2/internal/dbus"
     "golang.org/x/sys/execabs"
 )
 
 // Compile-time constants to enable/disable implementations, even when they're
 // found at runtime.
 const (
+    enableKDialog  = true
+    enableNotify   = true
+    enablePortal   = true
     enableWhiptail = true
     enableXMessage = true
+    enableYad      = true
     enableZenity   = true
 )
 
-var (
-    gfuns  funs  // global init of functions to implementations
-    giniterr error
-)
-
-type funs struct {
-    messageRaise         func(m Message, message string) error
-    messageAsk           func(m Message, message string) (bool, error)
-    filePickOpen         func(m FilePicker)  (string, bool, error)
-    filePickOpenMultiple func(m FilePicker)  ([]string, bool, error)
-    filePickSave         func(m FilePicker)  (string, bool, error)
-    colorPick            func(m ColorPicker) (color.Color, bool, error)
-    datePick             func(m DatePicker)  (time.Time, bool, error)
-}
-
 func clean(x string) string {
     return shellescape.Quote(x)
 }
@@ -48,9 +36,12 @@ func osInit() error {
 func init() {
     type paths struct {
         shell    string
+        kdialog  string
+        notify   string
         whiptail string
         xmessage string
         xterm    string
+        yad      string
         zenity   string
     }
 
@@ -63,44 +54,79 @@ func init() {
     var err error
     p := &paths{}
     if err == nil { err = stash(&p.shell,    "sh") }
+    if err == nil { err = stash(&p.kdialog,  "kdialog") }
+    if err == nil { err = stash(&p.notify,   "notify-send") }
     if err == nil { err = stash(&p.whiptail, "whiptail") }
     if err == nil { err = stash(&p.xmessage, "xmessage") }
     if err == nil { err = stash(&p.xterm,    "xterm") }
+    if err == nil { err = stash(&p.yad,      "yad") }
     if err == nil { err = stash(&p.zenity,   "zenity"  ) }
-    giniterr = err
+    initErr = err
+
+    found := make(map[string]funs)
 
     if (p.zenity != "") && enableZenity {
         z := zenity{p.zenity}
-        if gfuns.messageAsk == nil {
-            gfuns.messageAsk = z.ask
-        }
-        if gfuns.messageRaise == nil {
-            gfuns.messageRaise = z.raise
+        found["zenity"] = funs{
+            messageAsk:           z.ask,
+            messageRaise:         z.raise,
+            questionAsk:          z.question,
+            filePickOpen:         z.open,
+            filePickOpenMultiple: z.openMultiple,
+            filePickSave:         z.save,
+            colorPick:            z.color,
+            datePick:             z.date,
+            textAsk:              z.text,
+            passwordAsk:          z.password,
+            choicePick:           z.choice,
+            choicePickMultiple:   z.choiceMultiple,
+            progressStart:        z.progress,
         }
-        if gfuns.filePickOpen == nil {
-            gfuns.filePickOpen = z.open
-        }
-        if gfuns.filePickOpenMultiple == nil {
-            gfuns.filePickOpenMultiple = z.openMultiple
-        }
-        if gfuns.filePickSave == nil {
-            gfuns.filePickSave = z.save
-        }
-        if gfuns.colorPick == nil {
-            gfuns.colorPick = z.color
+    }
+
+    if (p.kdialog != "") && enableKDialog {
+        k := kdialog{p.kdialog}
+        found["kdialog"] = funs{
+            messageAsk:           k.ask,
+            messageRaise:         k.raise,
+            questionAsk:          k.question,
+            filePickOpen:         k.open,
+            filePickOpenMultiple: k.openMultiple,
+            filePickSave:         k.save,
+            colorPick:            k.color,
+            datePick:             k.date,
+            textAsk:              k.text,
+            passwordAsk:          k.password,
+            choicePick:           k.choice,
+            choicePickMultiple:   k.choiceMultiple,
         }
-        if gfuns.datePick == nil {
-            gfuns.datePick = z.date
+    }
+
+    if (p.yad != "") && enableYad {
+        y := yad{p.yad}
+        found["yad"] = funs{
+            messageAsk:           y.ask,
+            messageRaise:         y.raise,
+            questionAsk:          y.question,
+            filePickOpen:         y.open,
+            filePickOpenMultiple: y.openMultiple,
+            filePickSave:         y.save,
+            colorPick:            y.color,
+            datePick:             y.date,
+            textAsk:              y.text,
+            passwordAsk:          y.password,
+            choicePick:           y.choice,
+            choicePickMultiple:   y.choiceMultiple,
+            progressStart:        y.progress,
         }
     }
 
     if (p.xmessage != "") && enableXMessage {
         x := xmessage{p.xmessage}
-        if gfuns.messageAsk == nil {
-            gfuns.messageAsk = x.ask
-        }
-        if gfuns.messageRaise == nil {
-            gfuns.messageRaise = x.raise
+        found["xmessage"] = funs{
+            messageAsk:   x.ask,
+            messageRaise: x.raise,
+            questionAsk:  x.question,
         }
     }
 
@@ -110,30 +136,107 @@ func init() {
             xterm:    p.xterm,
             whiptail: p.whiptail,
         }
-        if gfuns.filePickOpen == nil {
-            gfuns.filePickOpen = w.open
+        found["whiptail"] = funs{
+            questionAsk:        w.question,
+            filePickOpen:       w.open,
+            filePickSave:       w.save,
+            colorPick:          w.color,
+            datePick:           w.date,
+            textAsk:            w.text,
+            passwordAsk:        w.password,
+            choicePick:         w.choice,
+            choicePickMultiple: w.choiceMultiple,
+            progressStart:      w.progress,
+        }
+    }
+
+    var auto funs
+    for _, name := range priority(os.Getenv("XDG_CURRENT_DESKTOP")) {
+        f, ok := found[name]
+        if !ok { continue }
+        f.names = []string{name}
+        Register(name, f)
+        auto = auto.merge(f)
+    }
+
+    // The desktop portal is preferred for file pickers, because it also
+    // works inside a sandbox, but it falls back to the implementations above
+    // at runtime if there turns out to be no portal on the bus.
+    if address := dbus.SessionBusAddress(); (address != "") && enablePortal {
+        x := portal{address: address, fallback: auto}
+        f := funs{
+            names:                []string{"portal"},
+            filePickOpen:         x.open,
+            filePickOpenMultiple: x.openMultiple,
+            filePickSave:         x.save,
         }
-        if gfuns.filePickSave == nil {
-            gfuns.filePickSave = w.save
+        Register("portal", f)
+        auto = f.merge(auto)
+    }
+
+    // Without a display server, none of the above can show anything, so
+    // draw on the controlling terminal instead e.g. over SSH.
+    if tty, err := os.OpenFile("/dev/tty", os.O_RDWR, 0); err == nil {
+        tty.Close()
+        x := tui{path: "/dev/tty"}
+        f := funs{
+            names:                []string{"tui"},
+            messageAsk:           x.ask,
+            messageRaise:         x.raise,
+            questionAsk:          x.question,
+            filePickOpen:         x.open,
+            filePickOpenMultiple: x.openMultiple,
+            filePickSave:         x.save,
+            colorPick:            x.color,
+            datePick:             x.date,
+            textAsk:              x.text,
+            passwordAsk:          x.password,
+            choicePick:           x.choice,
+            choicePickMultiple:   x.choiceMultiple,
+            progressStart:        x.progress,
         }
-        if gfuns.colorPick == nil {
-            gfuns.colorPick = w.color
+        Register("tui", f)
+
+        if (os.Getenv("DISPLAY") == "") && (os.Getenv("WAYLAND_DISPLAY") == "") {
+            auto = f.merge(auto)
         }
-        if gfuns.datePick == nil {
-            gfuns.datePick = w.date
+    }
+
+    // Notifications use the notification server over D-Bus where possible,
+    // because it supports actions, or else notify-send.
+    if enableNotify {
+        x := notifier{address: dbus.SessionBusAddress(), notifySend: p.notify}
+        name := "notifications"
+        if x.address == "" { name = "notify-send" }
+        if (x.address != "") || (x.notifySend != "") {
+            f := funs{
+                names:  []string{name},
+                notify: x.notify,
+            }
+            Register(name, f)
+            auto = auto.merge(f)
         }
     }
+
+    Register("auto", auto)
 }
 
-func supported() (Support, error) {
-    return Support{
-        MessageRaise:    gfuns.messageRaise != nil,
-        MessageAsk:      gfuns.messageAsk   != nil,
-        FilePicker:      gfuns.filePickOpen != nil,
-        MultiFilePicker: gfuns.filePickOpenMultiple != nil,
-        DatePicker:      gfuns.datePick     != nil,
-        ColorPicker:     gfuns.colorPick    != nil,
-    }, giniterr
+// priority returns the names of implementations in order of priority, such
+// that the native implementation for a desktop environment is preferred. The
+// desktop argument is a colon-separated list of desktop environment names,
+// as in the XDG_CURRENT_DESKTOP environment variable e.g. "ubuntu:GNOME".
+func priority(desktop string) []string {
+    for _, d := range strings.Split(desktop, ":") {
+        switch strings.ToUpper(strings.TrimSpace(d)) {
+            case "KDE", "LXQT", "TRINITY":
+                return []string{"kdialog", "zenity", "yad", "xmessage", "whiptail"}
+            case "GNOME", "UNITY", "CINNAMON", "MATE", "PANTHEON", "BUDGIE":
+                return []string{"zenity", "yad", "kdialog", "xmessage", "whiptail"}
+            case "XFCE", "LXDE":
+                return []string{"yad", "zenity", "kdialog", "xmessage", "whiptail"}
+        }
+    }
+    return []string{"zenity", "yad", "kdialog", "xmessage", "whiptail"}
 }
 
 func find(bin string) (string, error) {
@@ -163,38 +266,3 @@ func find(bin string) (string, error) {
 
     return strings.TrimSpace(buf.String()), nil
 }
-
-func (m ColorPicker) pick() (color.Color, bool, error) {
-    if gfuns.colorPick == nil { return operator.Zero[color.Color](), false, nil }
-    return gfuns.colorPick(m)
-}
-
-func (m DatePicker) pick() (time.Time, bool, error) {
-    if gfuns.datePick == nil { return operator.Zero[time.Time](), false, nil }
-    return gfuns.datePick(m)
-}
-
-func (m FilePicker) open() (string, bool, error) {
-    if gfuns.filePickOpen == nil { return "", false, nil }
-    return gfuns.filePickOpen(m)
-}
-
-func (m FilePicker) openMultiple() ([]string, bool, error) {
-    if gfuns.filePickSave == nil { return []string{}, false, nil }
-    return gfuns.filePickOpenMultiple(m)
-}
-
-func (m FilePicker) save() (string, bool, error) {
-    if gfuns.filePickSave == nil { return "", false, nil }
-    return gfuns.filePickSave(m)
-}
-
-func (m Message) ask(message string) (bool, error) {
-    if gfuns.messageAsk == nil { return false, nil }
-    return gfuns.messageAsk(m, message)
-}
-
-func (m Message) raise(message string) error {
-    if gfuns.messageRaise == nil { return nil }
-    return gfuns.messageRaise(m, message)
-}
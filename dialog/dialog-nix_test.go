@@ -0,0 +1,128 @@
+//go:build (linux || unix)
+
+package dialog
+
+import (
+    "fmt"
+    "os"
+    "os/exec"
+    "strings"
+    "testing"
+
+    "github.com/stretchr/testify/assert"
+)
+
+func TestPriority(t *testing.T) {
+    assert.Equal(t, "kdialog", priority("KDE")[0])
+    assert.Equal(t, "zenity",  priority("ubuntu:GNOME")[0])
+    assert.Equal(t, "yad",     priority("XFCE")[0])
+    assert.Equal(t, "zenity",  priority("")[0])
+}
+
+func TestFunsMerge(t *testing.T) {
+    x := xmessage{}
+    k := kdialog{}
+
+    a := funs{names: []string{"xmessage"}, messageRaise: x.raise, messageAsk: x.ask}
+    b := funs{names: []string{"kdialog"},  messageRaise: k.raise, filePickOpen: k.open}
+    c := funs{names: []string{"unused"},   messageRaise: k.raise}
+
+    f := a.merge(b).merge(c)
+    s := f.Supported()
+    assert.True(t, s.MessageRaise)
+    assert.True(t, s.FilePicker)
+    assert.False(t, s.ColorPicker)
+    assert.Equal(t, []string{"xmessage", "kdialog"}, f.backends())
+}
+
+func TestFilePicker_location(t *testing.T) {
+    dir := t.TempDir()
+    cwd, err := os.Getwd()
+    if !assert.Nil(t, err) { return }
+
+    tests := []struct {
+        m    FilePicker
+        dir  string
+        name string
+    }{
+        {FilePicker{}, cwd, ""},
+        {FilePicker{Path: "/srv/www/"}, "/srv/www", ""},
+        {FilePicker{Path: "/srv/www/index.html"}, "/srv/www", "index.html"},
+        {FilePicker{Path: dir}, dir, ""},
+        {FilePicker{Path: "/srv/www/index.html", Dir: "/tmp"}, "/tmp", "index.html"},
+        {FilePicker{Path: "/srv/www/index.html", FileName: "a/b.txt"}, "/srv/www", "b.txt"},
+        {FilePicker{Dir: "/tmp", FileName: "b.txt"}, "/tmp", "b.txt"},
+    }
+
+    for i, tt := range tests {
+        dir, name, err := tt.m.location()
+        assert.Nil(t, err, "test %d", i)
+        assert.Equal(t, tt.dir, dir, "test %d", i)
+        assert.Equal(t, tt.name, name, "test %d", i)
+    }
+}
+
+func TestGlobs(t *testing.T) {
+    assert.Equal(t, []string{"*.txt", "*.md"}, globs("*.txt  *.md"))
+    assert.Equal(t, []string{"*"}, globs("*.*"))
+    assert.Equal(t, []string{}, globs(""))
+}
+
+func TestPipeProgress(t *testing.T) {
+    var out strings.Builder
+    cmd := exec.Command("cat")
+    cmd.Stdout = &out
+    w, err := cmd.StdinPipe()
+    assert.Nil(t, err)
+
+    p := &pipeProgress{
+        name: "cat",
+        cmd:  cmd,
+        w:    w,
+        formatPercent: func(percent int, _ string) string {
+            return fmt.Sprintf("%d\n", percent)
+        },
+        formatText: func(percent int, text string) string {
+            return fmt.Sprintf("%d %s\n", percent, text)
+        },
+    }
+    assert.Nil(t, p.start())
+    assert.Nil(t, p.SetPercent(10))
+    assert.Nil(t, p.SetText("hello\nworld"))
+    assert.Nil(t, p.Close())
+    assert.Nil(t, p.SetPercent(20)) // ignored
+
+    select {
+        case <-p.Cancelled(): t.Errorf("unexpected cancel")
+        default:
+    }
+
+    // a program that exits with an error was cancelled by the user
+    w, err = os.Open(os.DevNull)
+    assert.Nil(t, err)
+    p = &pipeProgress{name: "false", cmd: exec.Command("false"), w: w}
+    assert.Nil(t, p.start())
+    <-p.Cancelled()
+    assert.Nil(t, p.Close())
+
+    // a program that exits successfully by itself was not cancelled
+    w, err = os.Open(os.DevNull)
+    assert.Nil(t, err)
+    p = &pipeProgress{name: "true", cmd: exec.Command("true"), w: w}
+    assert.Nil(t, p.start())
+    <-p.exited
+    select {
+        case <-p.Cancelled(): t.Errorf("unexpected cancel")
+        default:
+    }
+    assert.Nil(t, p.Close())
+
+    // writing to a program that has exited, before that is noticed, isn't
+    // an error
+    r, w, err := os.Pipe()
+    if !assert.Nil(t, err) { return }
+    r.Close()
+    defer w.Close()
+    p = &pipeProgress{name: "exited", w: w, exited: make(chan struct{})}
+    assert.Nil(t, p.write("100\n"))
+}
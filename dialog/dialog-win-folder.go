@@ -0,0 +1,89 @@
+//go:build windows
+
+package dialog
+
+import (
+    "fmt"
+    "runtime"
+    "unsafe"
+
+    "golang.org/x/sys/windows"
+)
+
+// The common file dialogs can't select a directory, so directories are
+// selected with the shell's folder browser instead. This can only select a
+// single directory.
+
+var (
+    procSHBrowseForFolder   = dllShell32.NewProc("SHBrowseForFolderW")
+    procSHGetPathFromIDList = dllShell32.NewProc("SHGetPathFromIDListW")
+    procSendMessage         = dllUser32.NewProc("SendMessageW")
+    procCoTaskMemFree       = dllOle32.NewProc("CoTaskMemFree")
+
+    // selects the initial directory, given as the lParam of browseInfoW
+    winBrowseCallback = windows.NewCallback(func(hwnd uintptr, msg uintptr, lparam uintptr, data uintptr) uintptr {
+        const BFFM_INITIALIZED = 1
+        const BFFM_SETSELECTIONW = winWM_USER + 103
+        if (msg == BFFM_INITIALIZED) && (data != 0) {
+            procSendMessage.Call(hwnd, BFFM_SETSELECTIONW, 1, data)
+        }
+        return 0
+    })
+)
+
+// https://learn.microsoft.com/en-us/windows/win32/api/shlobj_core/ns-shlobj_core-browseinfow
+type browseInfoW struct {
+    hwndOwner      uintptr
+    pidlRoot       uintptr
+    pszDisplayName *uint16
+    lpszTitle      *uint16
+    ulFlags        uint32
+    lpfn           uintptr
+    lParam         uintptr
+    iImage         int32
+}
+
+func (m FilePicker) pickFolder(initialDir string) ([]string, bool, error) {
+    if err := procSHBrowseForFolder.Find(); err != nil {
+        err = fmt.Errorf("missing required shell32.dll procedure SHBrowseForFolderW: %w", err)
+        return nil, false, errSys(err)
+    }
+
+    // the new dialog style requires COM on this thread
+    runtime.LockOSThread()
+    defer runtime.UnlockOSThread()
+
+    const COINIT_APARTMENTTHREADED = 0x2
+    const S_FALSE = windows.Errno(1) // already initialised
+    if err := windows.CoInitializeEx(0, COINIT_APARTMENTTHREADED); (err != nil) && (err != S_FALSE) {
+        return nil, false, errSys(fmt.Errorf("CoInitializeEx error: %w", err))
+    }
+    defer windows.CoUninitialize()
+
+    const (
+        BIF_RETURNONLYFSDIRS = 0x0001
+        BIF_EDITBOX          = 0x0010
+        BIF_NEWDIALOGSTYLE   = 0x0040
+    )
+
+    name := make([]uint16, windows.MAX_PATH)
+    info := browseInfoW{
+        pszDisplayName: &name[0],
+        lpszTitle:      wide(m.Title),
+        ulFlags:        BIF_RETURNONLYFSDIRS | BIF_EDITBOX | BIF_NEWDIALOGSTYLE,
+        lpfn:           winBrowseCallback,
+        lParam:         pwide(initialDir),
+    }
+
+    pidl, _, _ := procSHBrowseForFolder.Call(uintptr(unsafe.Pointer(&info)))
+    if pidl == 0 { return nil, false, nil } // closed / cancelled
+    defer procCoTaskMemFree.Call(pidl)
+
+    buf := make([]uint16, windows.MAX_PATH)
+    if ret, _, err := procSHGetPathFromIDList.Call(pidl, uintptr(unsafe.Pointer(&buf[0]))); ret == 0 {
+        // e.g. a virtual folder, which isn't a directory
+        return nil, false, errSys(fmt.Errorf("SHGetPathFromIDListW error: %w", err))
+    }
+
+    return []string{windows.UTF16ToString(buf)}, true, nil
+}
@@ -0,0 +1,381 @@
+//go:build windows
+
+package dialog
+
+import (
+    "fmt"
+    "runtime"
+    "strings"
+    "sync"
+    "unicode/utf16"
+    "unsafe"
+
+    "golang.org/x/sys/windows"
+)
+
+// Windows has no stock dialog for text input, lists, or custom buttons, so
+// these are built from an in-memory dialog template (DLGTEMPLATE), with the
+// standard edit, list box and button controls.
+
+var (
+    dllUser32 = windows.NewLazySystemDLL("user32.dll")
+
+    procDialogBoxIndirectParam = dllUser32.NewProc("DialogBoxIndirectParamW")
+    procEndDialog              = dllUser32.NewProc("EndDialog")
+    procSendDlgItemMessage     = dllUser32.NewProc("SendDlgItemMessageW")
+    procGetDlgItemText         = dllUser32.NewProc("GetDlgItemTextW")
+    procGetDlgItem             = dllUser32.NewProc("GetDlgItem")
+    procSetFocus               = dllUser32.NewProc("SetFocus")
+)
+
+const (
+    winIDOK       = 1
+    winIDCancel   = 2
+    winIDLabel    = 100
+    winIDControl  = 101
+    winIDButton   = 200 // first of several custom buttons
+
+    winWM_INITDIALOG     = 0x0110
+    winWM_COMMAND        = 0x0111
+    winWM_GETTEXTLENGTH  = 0x000E
+    winLB_ADDSTRING      = 0x0180
+    winLB_SETSEL         = 0x0185
+    winLB_SETCURSEL      = 0x0186
+    winLB_GETCURSEL      = 0x0188
+    winLB_GETSELCOUNT    = 0x0190
+    winLB_GETSELITEMS    = 0x0191
+    winBM_GETCHECK       = 0x00F0
+    winBST_CHECKED       = 1
+
+    winWS_POPUP          = 0x80000000
+    winWS_CHILD          = 0x40000000
+    winWS_VISIBLE        = 0x10000000
+    winWS_CAPTION        = 0x00C00000
+    winWS_BORDER         = 0x00800000
+    winWS_SYSMENU        = 0x00080000
+    winWS_VSCROLL        = 0x00200000
+    winWS_TABSTOP        = 0x00010000
+    winDS_SETFONT        = 0x00000040
+    winDS_MODALFRAME     = 0x00000080
+    winDS_SETFOREGROUND  = 0x00000200
+    winDS_CENTER         = 0x00000800
+    winES_PASSWORD       = 0x00000020
+    winES_AUTOHSCROLL    = 0x00000080
+    winBS_DEFPUSHBUTTON  = 0x00000001
+    winBS_AUTOCHECKBOX   = 0x00000003
+    winLBS_NOTIFY        = 0x00000001
+    winLBS_NOINTEGRALHEIGHT = 0x00000100
+    winLBS_EXTENDEDSEL   = 0x00000800
+
+    winClassButton  = 0x0080
+    winClassEdit    = 0x0081
+    winClassStatic  = 0x0082
+    winClassListBox = 0x0083
+)
+
+// winTemplate builds a DLGTEMPLATE in memory.
+type winTemplate struct {
+    buf   []uint16
+    count int // offset of the cdit field
+}
+
+func (t *winTemplate) word(x uint16) {
+    t.buf = append(t.buf, x)
+}
+
+func (t *winTemplate) dword(x uint32) {
+    t.buf = append(t.buf, uint16(x & 0xFFFF), uint16(x >> 16))
+}
+
+func (t *winTemplate) str(s string) {
+    t.buf = append(t.buf, utf16.Encode([]rune(s))...)
+    t.buf = append(t.buf, 0)
+}
+
+func (t *winTemplate) align() {
+    if len(t.buf) % 2 != 0 { t.word(0) }
+}
+
+func newWinTemplate(title string, cx, cy int16) *winTemplate {
+    t := &winTemplate{}
+    t.dword(winWS_POPUP | winWS_CAPTION | winWS_SYSMENU | winDS_MODALFRAME |
+        winDS_SETFONT | winDS_SETFOREGROUND | winDS_CENTER)
+    t.dword(0) // extended style
+    t.count = len(t.buf)
+    t.word(0) // number of items, incremented by item
+    t.word(0); t.word(0) // x, y
+    t.word(uint16(cx)); t.word(uint16(cy))
+    t.word(0) // no menu
+    t.word(0) // default class
+    t.str(title)
+    t.word(8) // font size
+    t.str("MS Shell Dlg")
+    return t
+}
+
+// height sets the height of the dialog, once its items are known.
+func (t *winTemplate) height(cy int16) {
+    t.buf[t.count + 4] = uint16(cy)
+}
+
+func (t *winTemplate) item(class uint16, id uint16, style uint32, text string, x, y, cx, cy int16) {
+    t.align()
+    t.buf[t.count]++
+    t.dword(winWS_CHILD | winWS_VISIBLE | style)
+    t.dword(0) // extended style
+    t.word(uint16(x)); t.word(uint16(y))
+    t.word(uint16(cx)); t.word(uint16(cy))
+    t.word(id)
+    t.word(0xFFFF); t.word(class)
+    t.str(text)
+    t.word(0) // no creation data
+}
+
+// bytes returns the template in DWORD-aligned memory.
+func (t *winTemplate) bytes() []uint32 {
+    mem := make([]uint32, (len(t.buf) + 1) / 2)
+    copy(unsafe.Slice((*uint16)(unsafe.Pointer(&mem[0])), len(t.buf)), t.buf)
+    return mem
+}
+
+// winInput is the state of the dialog currently shown. Dialogs are modal, so
+// only one is shown at a time, and the dialog procedure (a callback, which
+// can only be created a limited number of times) is shared.
+var winInput struct {
+    mu       sync.Mutex
+    once     sync.Once
+    proc     uintptr
+
+    options  []string
+    defaults []int
+    list     bool
+    multiple bool
+    buttons  bool // options are button labels
+    focus    int
+
+    button   int
+    checked  bool
+
+    text     string
+    indexes  []int
+}
+
+func winDialogProc(hwnd uintptr, msg uintptr, wparam uintptr, lparam uintptr) uintptr {
+    s := &winInput
+
+    switch msg {
+        case winWM_INITDIALOG:
+            if s.buttons {
+                button, _, _ := procGetDlgItem.Call(hwnd, uintptr(winIDButton + s.focus))
+                procSetFocus.Call(button)
+                return 0 // focus was set
+            }
+            if !s.list { return 1 }
+            for _, option := range s.options {
+                procSendDlgItemMessage.Call(hwnd, winIDControl, winLB_ADDSTRING, 0, pwide(option))
+            }
+            for _, i := range s.defaults {
+                if s.multiple {
+                    procSendDlgItemMessage.Call(hwnd, winIDControl, winLB_SETSEL, 1, uintptr(i))
+                } else {
+                    procSendDlgItemMessage.Call(hwnd, winIDControl, winLB_SETCURSEL, uintptr(i), 0)
+                    break
+                }
+            }
+            return 1
+
+        case winWM_COMMAND:
+            id := int(wparam & 0xFFFF)
+            if s.buttons && (id >= winIDButton) && (id < winIDButton + len(s.options)) {
+                state, _, _ := procSendDlgItemMessage.Call(hwnd, winIDControl, winBM_GETCHECK, 0, 0)
+                s.button = id - winIDButton
+                s.checked = (state == winBST_CHECKED)
+                procEndDialog.Call(hwnd, 1)
+                return 1
+            }
+
+            switch id {
+                case winIDOK:
+                    if !s.list {
+                        n, _, _ := procSendDlgItemMessage.Call(hwnd, winIDControl, winWM_GETTEXTLENGTH, 0, 0)
+                        buf := make([]uint16, n + 1)
+                        procGetDlgItemText.Call(hwnd, winIDControl, uintptr(unsafe.Pointer(&buf[0])), uintptr(len(buf)))
+                        s.text = windows.UTF16ToString(buf)
+                    } else if s.multiple {
+                        n, _, _ := procSendDlgItemMessage.Call(hwnd, winIDControl, winLB_GETSELCOUNT, 0, 0)
+                        if int(n) > 0 {
+                            buf := make([]int32, n)
+                            procSendDlgItemMessage.Call(hwnd, winIDControl, winLB_GETSELITEMS, n, uintptr(unsafe.Pointer(&buf[0])))
+                            for _, i := range buf {
+                                s.indexes = append(s.indexes, int(i))
+                            }
+                        }
+                    } else {
+                        i, _, _ := procSendDlgItemMessage.Call(hwnd, winIDControl, winLB_GETCURSEL, 0, 0)
+                        if int32(i) >= 0 { s.indexes = []int{int(int32(i))} }
+                    }
+                    procEndDialog.Call(hwnd, 1)
+                    return 1
+                case winIDCancel:
+                    procEndDialog.Call(hwnd, 0)
+                    return 1
+            }
+    }
+    return 0
+}
+
+// winShow displays a dialog from a template, returning true if OK was picked.
+func winShow(t *winTemplate) (bool, error) {
+    if err := procDialogBoxIndirectParam.Find(); err != nil {
+        err = fmt.Errorf("missing required user32.dll procedure DialogBoxIndirectParamW: %w", err)
+        return false, errSys(err)
+    }
+
+    winInput.once.Do(func() {
+        winInput.proc = windows.NewCallback(winDialogProc)
+    })
+
+    // the dialog's message loop runs on this thread
+    runtime.LockOSThread()
+    defer runtime.UnlockOSThread()
+
+    mem := t.bytes()
+    ret, _, err := procDialogBoxIndirectParam.Call(
+        0,
+        uintptr(unsafe.Pointer(&mem[0])),
+        0,
+        winInput.proc,
+        0,
+    )
+    runtime.KeepAlive(mem)
+
+    if int(ret) == -1 {
+        return false, errSys(fmt.Errorf("error in user32.dll procedure DialogBoxIndirectParamW: %w", err))
+    }
+    return ret == 1, nil
+}
+
+// inputTemplate returns a template with a label, a control of the given
+// height, and OK and Cancel buttons.
+func inputTemplate(title string, label string, class uint16, style uint32, text string, height int16) *winTemplate {
+    const width = 220
+    labelHeight := int16(8 * (1 + (len([]rune(label)) / 48)))
+    if labelHeight > 48 { labelHeight = 48 }
+
+    y := int16(7) + labelHeight + 4
+    buttons := y + height + 7
+
+    t := newWinTemplate(title, width, buttons + 14 + 7)
+    t.item(winClassStatic, winIDLabel, 0, label, 7, 7, width - 14, labelHeight)
+    t.item(class, winIDControl, style | winWS_BORDER | winWS_TABSTOP, text, 7, y, width - 14, height)
+    t.item(winClassButton, winIDOK, winBS_DEFPUSHBUTTON | winWS_TABSTOP, locale().OK, width - 114, buttons, 50, 14)
+    t.item(winClassButton, winIDCancel, winWS_TABSTOP, locale().Cancel, width - 57, buttons, 50, 14)
+    return t
+}
+
+func winText(title string, label string, initial string, password bool) (string, bool, error) {
+    winInput.mu.Lock()
+    defer winInput.mu.Unlock()
+    winInput.list = false
+    winInput.buttons = false
+    winInput.text = ""
+
+    style := uint32(winES_AUTOHSCROLL)
+    if password { style |= winES_PASSWORD }
+
+    ok, err := winShow(inputTemplate(title, label, winClassEdit, style, initial, 14))
+    if (err != nil) || !ok { return "", false, err }
+    return winInput.text, true, nil
+}
+
+func (m TextInput) ask() (string, bool, error) {
+    return winText(m.Title, m.Label, m.Initial, false)
+}
+
+func (m PasswordInput) ask() (string, bool, error) {
+    return winText(m.Title, m.Label, "", true)
+}
+
+func (m Choice) pick(multiple bool) ([]int, bool, error) {
+    winInput.mu.Lock()
+    defer winInput.mu.Unlock()
+    winInput.list = true
+    winInput.buttons = false
+    winInput.multiple = multiple
+    winInput.options = m.Options
+    winInput.defaults = m.Default
+    winInput.indexes = nil
+
+    style := uint32(winWS_VSCROLL | winLBS_NOTIFY | winLBS_NOINTEGRALHEIGHT)
+    if multiple { style |= winLBS_EXTENDEDSEL }
+
+    ok, err := winShow(inputTemplate(m.Title, m.Label, winClassListBox, style, "", 100))
+    if (err != nil) || !ok { return nil, false, err }
+    return winInput.indexes, len(winInput.indexes) > 0, nil
+}
+
+func (m Choice) choice() (int, bool, error) {
+    xs, ok, err := m.pick(false)
+    if (err != nil) || !ok { return 0, false, err }
+    return xs[0], true, nil
+}
+
+func (m Choice) choiceMultiple() ([]int, bool, error) {
+    xs, ok, err := m.pick(true)
+    if (err != nil) || !ok { return []int{}, false, err }
+    return xs, true, nil
+}
+
+// questionTemplate returns a template with a message, an optional checkbox,
+// and a row of buttons of equal width.
+func questionTemplate(m Question, message string) *winTemplate {
+    buttonWidth := int16(50)
+    for _, label := range m.Buttons {
+        if w := int16(4 * len([]rune(label)) + 16); w > buttonWidth { buttonWidth = w }
+    }
+
+    width := int16(len(m.Buttons)) * (buttonWidth + 7) + 7
+    if width < 220 { width = 220 }
+
+    // roughly four dialog units per character
+    lines := 0
+    for _, line := range strings.Split(message, "\n") {
+        lines += 1 + (len([]rune(line)) / int((width - 14) / 4))
+    }
+    labelHeight := int16(8 * lines)
+    if labelHeight > 200 { labelHeight = 200 }
+
+    y := int16(7) + labelHeight + 7
+    t := newWinTemplate(m.Title, width, 0)
+    t.item(winClassStatic, winIDLabel, 0, message, 7, 7, width - 14, labelHeight)
+    if m.Checkbox != "" {
+        t.item(winClassButton, winIDControl, winBS_AUTOCHECKBOX | winWS_TABSTOP, m.Checkbox, 7, y, width - 14, 10)
+        y += 10 + 7
+    }
+
+    x := width - (int16(len(m.Buttons)) * (buttonWidth + 7))
+    for i, label := range m.Buttons {
+        style := uint32(winWS_TABSTOP)
+        if i == m.Default { style |= winBS_DEFPUSHBUTTON }
+        t.item(winClassButton, uint16(winIDButton + i), style, label, x, y, buttonWidth, 14)
+        x += buttonWidth + 7
+    }
+
+    t.height(y + 14 + 7)
+    return t
+}
+
+func (m Question) ask(message string) (int, bool, error) {
+    winInput.mu.Lock()
+    defer winInput.mu.Unlock()
+    winInput.list = false
+    winInput.buttons = true
+    winInput.options = m.Buttons
+    winInput.focus = m.Default
+    winInput.button = -1
+    winInput.checked = false
+
+    ok, err := winShow(questionTemplate(m, message))
+    if (err != nil) || !ok { return -1, false, err }
+    return winInput.button, winInput.checked, nil
+}
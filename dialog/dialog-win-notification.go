@@ -0,0 +1,271 @@
+//go:build windows
+
+package dialog
+
+import (
+    "fmt"
+    "runtime"
+    "sync"
+    "time"
+    "unsafe"
+
+    "golang.org/x/sys/windows"
+)
+
+// Notifications are shown as a balloon from a temporary notification area
+// ("system tray") icon, which newer versions of Windows show as a toast. The
+// icon belongs to a hidden message-only window, with its own message loop on
+// a goroutine locked to an OS thread.
+
+var (
+    dllShell32 = windows.NewLazySystemDLL("shell32.dll")
+
+    procShellNotifyIcon  = dllShell32.NewProc("Shell_NotifyIconW")
+    procRegisterClassEx  = dllUser32.NewProc("RegisterClassExW")
+    procCreateWindowEx   = dllUser32.NewProc("CreateWindowExW")
+    procDestroyWindow    = dllUser32.NewProc("DestroyWindow")
+    procDefWindowProc    = dllUser32.NewProc("DefWindowProcW")
+    procGetMessage       = dllUser32.NewProc("GetMessageW")
+    procTranslateMessage = dllUser32.NewProc("TranslateMessage")
+    procDispatchMessage  = dllUser32.NewProc("DispatchMessageW")
+    procPostQuitMessage  = dllUser32.NewProc("PostQuitMessage")
+    procLoadIcon         = dllUser32.NewProc("LoadIconW")
+    procSetTimer         = dllUser32.NewProc("SetTimer")
+)
+
+const (
+    winNIM_ADD        = 0x0
+    winNIM_DELETE     = 0x2
+    winNIM_SETVERSION = 0x4
+
+    winNIF_MESSAGE = 0x01
+    winNIF_ICON    = 0x02
+    winNIF_TIP     = 0x04
+    winNIF_INFO    = 0x10
+
+    winNIIF_INFO    = 0x01
+    winNIIF_WARNING = 0x02
+    winNIIF_ERROR   = 0x03
+    winNIIF_NOSOUND = 0x10
+
+    winNOTIFYICON_VERSION_4 = 4
+
+    winWM_TIMER            = 0x0113
+    winWM_USER             = 0x0400
+    winWM_NOTIFYICON       = winWM_USER + 1 // our callback message
+    winNIN_BALLOONHIDE     = winWM_USER + 3
+    winNIN_BALLOONTIMEOUT  = winWM_USER + 4
+    winNIN_BALLOONUSERCLICK = winWM_USER + 5
+
+    winHWND_MESSAGE = ^uintptr(2) // (HWND)-3
+
+    winIDI_ERROR       = 32513
+    winIDI_WARNING     = 32515
+    winIDI_INFORMATION = 32516
+
+    // how long to wait for the user before removing the icon regardless
+    winNotificationLifetime = 60 * time.Second
+)
+
+// https://learn.microsoft.com/en-us/windows/win32/api/shellapi/ns-shellapi-notifyicondataw
+type notifyIconDataW struct {
+    cbSize           uint32
+    hWnd             uintptr
+    uID              uint32
+    uFlags           uint32
+    uCallbackMessage uint32
+    hIcon            uintptr
+    szTip            [128]uint16
+    dwState          uint32
+    dwStateMask      uint32
+    szInfo           [256]uint16
+    uVersion         uint32 // union with uTimeout
+    szInfoTitle      [64]uint16
+    dwInfoFlags      uint32
+    guidItem         windows.GUID
+    hBalloonIcon     uintptr
+}
+
+type wndClassExW struct {
+    cbSize        uint32
+    style         uint32
+    lpfnWndProc   uintptr
+    cbClsExtra    int32
+    cbWndExtra    int32
+    hInstance     uintptr
+    hIcon         uintptr
+    hCursor       uintptr
+    hbrBackground uintptr
+    lpszMenuName  *uint16
+    lpszClassName *uint16
+    hIconSm       uintptr
+}
+
+type winMsg struct {
+    hwnd    uintptr
+    message uint32
+    wParam  uintptr
+    lParam  uintptr
+    time    uint32
+    pt      struct{ x, y int32 }
+}
+
+// winNotifications maps each hidden window to the channel for the action
+// chosen in its notification.
+var winNotifications struct {
+    mu      sync.Mutex
+    once    sync.Once
+    err     error
+    class   *uint16
+    windows map[uintptr]chan<- int
+}
+
+// copyWide copies a string into a fixed-size, nul-terminated UTF-16 buffer,
+// truncating it if necessary.
+func copyWide(dest []uint16, s string) {
+    src, err := windows.UTF16FromString(s)
+    if err != nil { return }
+    if len(src) > len(dest) {
+        src = src[:len(dest)]
+        src[len(src) - 1] = 0
+    }
+    copy(dest, src)
+}
+
+func winNotificationProc(hwnd uintptr, msg uintptr, wparam uintptr, lparam uintptr) uintptr {
+    finish := func(action int) {
+        winNotifications.mu.Lock()
+        c, ok := winNotifications.windows[hwnd]
+        delete(winNotifications.windows, hwnd)
+        winNotifications.mu.Unlock()
+        if !ok { return }
+
+        if action >= 0 { c <- action }
+        close(c)
+        procDestroyWindow.Call(hwnd)
+        procPostQuitMessage.Call(0)
+    }
+
+    switch msg {
+        case winWM_NOTIFYICON:
+            switch lparam & 0xFFFF {
+                case winNIN_BALLOONUSERCLICK:
+                    finish(0)
+                    return 0
+                case winNIN_BALLOONHIDE, winNIN_BALLOONTIMEOUT:
+                    finish(-1)
+                    return 0
+            }
+        case winWM_TIMER:
+            finish(-1)
+            return 0
+    }
+
+    ret, _, _ := procDefWindowProc.Call(hwnd, msg, wparam, lparam)
+    return ret
+}
+
+func winRegisterNotificationClass() error {
+    var instance windows.Handle
+    if err := windows.GetModuleHandleEx(0, nil, &instance); err != nil {
+        return errSys(fmt.Errorf("GetModuleHandleEx error: %w", err))
+    }
+
+    class := wide("golib-dialog-notification")
+    wc := wndClassExW{
+        lpfnWndProc:   windows.NewCallback(winNotificationProc),
+        hInstance:     uintptr(instance),
+        lpszClassName: class,
+    }
+    wc.cbSize = uint32(unsafe.Sizeof(wc))
+
+    if ret, _, err := procRegisterClassEx.Call(uintptr(unsafe.Pointer(&wc))); ret == 0 {
+        return errSys(fmt.Errorf("RegisterClassExW error: %w", err))
+    }
+
+    winNotifications.class = class
+    winNotifications.windows = make(map[uintptr]chan<- int)
+    return nil
+}
+
+func (n Notification) send(body string) (<-chan int, error) {
+    winNotifications.once.Do(func() {
+        winNotifications.err = winRegisterNotificationClass()
+    })
+    if winNotifications.err != nil { return closedAction(), winNotifications.err }
+
+    result := make(chan int, 1)
+    started := make(chan error)
+    go n.run(body, result, started)
+
+    if err := <-started; err != nil { return closedAction(), err }
+    if len(n.Actions) == 0 { return closedAction(), nil }
+    return result, nil
+}
+
+func (n Notification) run(body string, result chan int, started chan<- error) {
+    runtime.LockOSThread()
+    defer runtime.UnlockOSThread()
+
+    hwnd, _, err := procCreateWindowEx.Call(
+        0,
+        uintptr(unsafe.Pointer(winNotifications.class)),
+        0,
+        0,
+        0, 0, 0, 0,
+        winHWND_MESSAGE,
+        0, 0, 0,
+    )
+    if hwnd == 0 {
+        started <- errSys(fmt.Errorf("CreateWindowExW error: %w", err))
+        return
+    }
+
+    winNotifications.mu.Lock()
+    winNotifications.windows[hwnd] = result
+    winNotifications.mu.Unlock()
+
+    icon, infoFlags := uintptr(winIDI_INFORMATION), uint32(winNIIF_INFO)
+    switch n.Icon {
+        case IconWarning: icon, infoFlags = winIDI_WARNING, winNIIF_WARNING
+        case IconError:   icon, infoFlags = winIDI_ERROR,   winNIIF_ERROR
+    }
+    if n.Urgency == UrgencyLow { infoFlags |= winNIIF_NOSOUND }
+    hIcon, _, _ := procLoadIcon.Call(0, icon)
+
+    data := notifyIconDataW{
+        hWnd:             hwnd,
+        uID:              1,
+        uFlags:           winNIF_MESSAGE | winNIF_ICON | winNIF_TIP | winNIF_INFO,
+        uCallbackMessage: winWM_NOTIFYICON,
+        hIcon:            hIcon,
+        uVersion:         winNOTIFYICON_VERSION_4,
+        dwInfoFlags:      infoFlags,
+    }
+    data.cbSize = uint32(unsafe.Sizeof(data))
+    copyWide(data.szTip[:], n.AppName)
+    copyWide(data.szInfoTitle[:], n.Title)
+    copyWide(data.szInfo[:], body)
+
+    if ret, _, err := procShellNotifyIcon.Call(winNIM_ADD, uintptr(unsafe.Pointer(&data))); ret == 0 {
+        winNotifications.mu.Lock()
+        delete(winNotifications.windows, hwnd)
+        winNotifications.mu.Unlock()
+        procDestroyWindow.Call(hwnd)
+        started <- errSys(fmt.Errorf("Shell_NotifyIconW error: %w", err))
+        return
+    }
+    defer procShellNotifyIcon.Call(winNIM_DELETE, uintptr(unsafe.Pointer(&data)))
+    procShellNotifyIcon.Call(winNIM_SETVERSION, uintptr(unsafe.Pointer(&data)))
+
+    procSetTimer.Call(hwnd, 1, uintptr(winNotificationLifetime / time.Millisecond), 0)
+    started <- nil
+
+    var msg winMsg
+    for {
+        ret, _, _ := procGetMessage.Call(uintptr(unsafe.Pointer(&msg)), 0, 0, 0)
+        if (ret == 0) || (int32(ret) == -1) { break } // WM_QUIT, or error
+        procTranslateMessage.Call(uintptr(unsafe.Pointer(&msg)))
+        procDispatchMessage.Call(uintptr(unsafe.Pointer(&msg)))
+    }
+}
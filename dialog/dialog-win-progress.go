@@ -0,0 +1,194 @@
+//go:build windows
+
+package dialog
+
+import (
+    "fmt"
+    "runtime"
+    "sync"
+    "syscall"
+    "time"
+    "unsafe"
+
+    "golang.org/x/sys/windows"
+)
+
+// The shell's progress dialog (IProgressDialog) is a COM object that runs its
+// own window on its own thread. Every call to it is made from a single
+// goroutine locked to an OS thread, as required by the COM apartment.
+
+var (
+    dllOle32 = windows.NewLazySystemDLL("ole32.dll")
+
+    procCoCreateInstance = dllOle32.NewProc("CoCreateInstance")
+
+    clsidProgressDialog = windows.GUID{
+        Data1: 0xF8383852,
+        Data2: 0xFCD3,
+        Data3: 0x11d1,
+        Data4: [8]byte{0xA6, 0xB9, 0x00, 0x60, 0x97, 0xDF, 0x5B, 0xD4},
+    }
+    iidIProgressDialog = windows.GUID{
+        Data1: 0xEBBC7C04,
+        Data2: 0x315E,
+        Data3: 0x11d2,
+        Data4: [8]byte{0xB6, 0x2F, 0x00, 0x60, 0x97, 0xDF, 0x5B, 0xD4},
+    }
+)
+
+// IProgressDialog vtable indexes
+const (
+    winProgressRelease             = 2
+    winProgressStartProgressDialog = 3
+    winProgressStopProgressDialog  = 4
+    winProgressSetTitle            = 5
+    winProgressHasUserCancelled    = 7
+    winProgressSetProgress         = 8
+    winProgressSetLine             = 10
+)
+
+// IProgressDialog flags
+const (
+    winPROGDLG_NORMAL           = 0x00000000
+    winPROGDLG_NOMINIMIZE       = 0x00000008
+    winPROGDLG_MARQUEEPROGRESS  = 0x00000020
+    winPROGDLG_NOCANCEL         = 0x00000040
+)
+
+// winComObject is the memory layout of a COM object: a pointer to a table of
+// methods.
+type winComObject struct {
+    vtable *[16]uintptr
+}
+
+type winProgress struct {
+    updates   chan func(obj *winComObject) // always received until closed
+    cancelled chan struct{}
+    closed    chan struct{}
+
+    mu        sync.Mutex
+    isClosed  bool
+}
+
+// winCall calls a method of a COM object by its vtable index.
+func winCall(obj *winComObject, index int, args ... uintptr) uintptr {
+    self := uintptr(unsafe.Pointer(obj))
+    ret, _, _ := syscall.SyscallN(obj.vtable[index], append([]uintptr{self}, args...)...)
+    return ret
+}
+
+func (m Progress) start() (ProgressUpdater, error) {
+    p := &winProgress{
+        updates:   make(chan func(obj *winComObject)),
+        cancelled: make(chan struct{}),
+        closed:    make(chan struct{}),
+    }
+    started := make(chan error)
+    go p.run(m, started)
+
+    if err := <-started; err != nil { return nil, err }
+    return p, nil
+}
+
+func (p *winProgress) run(m Progress, started chan<- error) {
+    defer close(p.closed)
+
+    runtime.LockOSThread()
+    defer runtime.UnlockOSThread()
+
+    const COINIT_APARTMENTTHREADED = 0x2
+    if err := windows.CoInitializeEx(0, COINIT_APARTMENTTHREADED); err != nil {
+        started <- errSys(fmt.Errorf("CoInitializeEx error: %w", err))
+        return
+    }
+    defer windows.CoUninitialize()
+
+    if err := procCoCreateInstance.Find(); err != nil {
+        started <- errSys(fmt.Errorf("missing required ole32.dll procedure CoCreateInstance: %w", err))
+        return
+    }
+
+    const CLSCTX_INPROC_SERVER = 0x1
+    var obj *winComObject
+    hr, _, _ := procCoCreateInstance.Call(
+        uintptr(unsafe.Pointer(&clsidProgressDialog)),
+        0,
+        CLSCTX_INPROC_SERVER,
+        uintptr(unsafe.Pointer(&iidIProgressDialog)),
+        uintptr(unsafe.Pointer(&obj)),
+    )
+    if int32(hr) < 0 {
+        started <- errSys(fmt.Errorf("CoCreateInstance(CLSID_ProgressDialog) returned 0x%x", uint32(hr)))
+        return
+    }
+    defer winCall(obj, winProgressRelease)
+
+    flags := uintptr(winPROGDLG_NORMAL | winPROGDLG_NOMINIMIZE)
+    if m.Pulsate  { flags |= winPROGDLG_MARQUEEPROGRESS }
+    if m.NoCancel { flags |= winPROGDLG_NOCANCEL }
+
+    winCall(obj, winProgressSetTitle, pwide(m.Title))
+    winCall(obj, winProgressSetLine, 1, pwide(m.Text), 0, 0)
+    winCall(obj, winProgressSetProgress, 0, 100)
+    if hr := winCall(obj, winProgressStartProgressDialog, 0, 0, flags, 0); int32(hr) < 0 {
+        started <- errSys(fmt.Errorf("IProgressDialog.StartProgressDialog returned 0x%x", uint32(hr)))
+        return
+    }
+    defer winCall(obj, winProgressStopProgressDialog)
+    started <- nil
+
+    ticker := time.NewTicker(100 * time.Millisecond)
+    defer ticker.Stop()
+
+    for {
+        select {
+            case f, ok := <-p.updates:
+                if !ok { return }
+                f(obj)
+            case <-ticker.C:
+                if winCall(obj, winProgressHasUserCancelled) != 0 {
+                    close(p.cancelled)
+                    // wait to be closed, ignoring further updates
+                    for range p.updates {}
+                    return
+                }
+        }
+    }
+}
+
+func (p *winProgress) update(f func(obj *winComObject)) {
+    p.mu.Lock()
+    defer p.mu.Unlock()
+    if p.isClosed { return }
+    p.updates <- f
+}
+
+func (p *winProgress) SetPercent(percent int) error {
+    p.update(func(obj *winComObject) {
+        winCall(obj, winProgressSetProgress, uintptr(percent), 100)
+    })
+    return nil
+}
+
+func (p *winProgress) SetText(text string) error {
+    p.update(func(obj *winComObject) {
+        winCall(obj, winProgressSetLine, 1, pwide(text), 0, 0)
+    })
+    return nil
+}
+
+func (p *winProgress) Close() error {
+    p.mu.Lock()
+    if !p.isClosed {
+        p.isClosed = true
+        close(p.updates)
+    }
+    p.mu.Unlock()
+
+    <-p.closed
+    return nil
+}
+
+func (p *winProgress) Cancelled() <-chan struct{} {
+    return p.cancelled
+}
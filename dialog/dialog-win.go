@@ -4,18 +4,13 @@ package dialog
 
 import (
     "fmt"
-    "image/color"
-    "os"
     "path/filepath"
     "strings"
-    "time"
     "unicode/utf16"
     "unsafe"
 
     "github.com/tawesoft/golib/v2/must"
-    "github.com/tawesoft/golib/v2/operator"
     "golang.org/x/sys/windows"
-    "golang.org/x/text/unicode/bidi"
 )
 
 func osInit() error {
@@ -162,21 +157,24 @@ func (i IconType) iconFlag() uint32 {
     return 0
 }
 
-func supported() (Support, error) {
-    return Support{
-        MessageRaise:    true,
-        MessageAsk:      true,
-        FilePicker:      true,
-        MultiFilePicker: true,
-    }, nil
-}
-
-func (m ColorPicker) pick() (color.Color, bool, error) {
-    return operator.Zero[color.Color](), false, nil
-}
-
-func (m DatePicker) pick() (time.Time, bool, error) {
-    return operator.Zero[time.Time](), false, nil
+func init() {
+    f := funs{
+        names:                []string{"windows"},
+        messageRaise:         Message.raise,
+        messageAsk:           Message.ask,
+        questionAsk:          Question.ask,
+        filePickOpen:         FilePicker.open,
+        filePickOpenMultiple: FilePicker.openMultiple,
+        filePickSave:         FilePicker.save,
+        textAsk:              TextInput.ask,
+        passwordAsk:          PasswordInput.ask,
+        choicePick:           Choice.choice,
+        choicePickMultiple:   Choice.choiceMultiple,
+        progressStart:        Progress.start,
+        notify:               Notification.send,
+    }
+    Register("windows", f)
+    Register("auto", f)
 }
 
 func (m FilePicker) pick(
@@ -225,6 +223,10 @@ func (m FilePicker) pick(
             0
     }
 
+    if (mode == 's') && m.ConfirmOverwrite {
+        flags |= 0x00000002 // OFN_OVERWRITEPROMPT
+    }
+
     if !m.AddToRecent {
         flags |= 0x02000000 // OFN_DONTADDTORECENT
     }
@@ -233,30 +235,27 @@ func (m FilePicker) pick(
         flags |= 0x10000000 // OFN_FORCESHOWHIDDEN
     }
 
+    initialDir, name, err := m.location()
+    if err != nil { return nil, false, err }
+
+    if m.Directory && (mode != 's') {
+        return m.pickFolder(initialDir)
+    }
+
     const bufSize = 16*1024 // UTF16 chars
     buf := make([]uint16, bufSize)
-    /*
-    base := filepath.Base(m.Path)
-    if base != "" {
-        if lpstrBase, err := windows.UTF16FromString(base); err == nil {
-            copy(buf, lpstrBase)
+    if name != "" {
+        if lpstrName, err := windows.UTF16FromString(name); err == nil {
+            copy(buf[:bufSize - 1], lpstrName)
         } else {
             return nil, false, fmt.Errorf("Unicode error: %w", err)
         }
-    }*/
-
-    initialDir := filepath.Dir(m.Path)
-    if initialDir == "" {
-        initialDir, err = os.Getwd()
-        if err != nil {
-            return nil, false, fmt.Errorf("error getting current working directory: %w", err)
-        }
     }
 
     var filters strings.Builder
     if len(m.FileTypes) == 0 { must.Never() }
     for _, f := range m.FileTypes {
-        name, patterns := f[0], strings.Split(f[1], " ")
+        name, patterns := f[0], strings.Fields(f[1])
         filters.WriteString(fmt.Sprintf("%s (%s)", name, strings.Join(patterns, ", ")))
         filters.WriteByte(0) // null terminate
         filters.WriteString(strings.Join(patterns, ";"))
@@ -384,21 +383,8 @@ func (m Message) flags(message string) uint32 {
         windows.MB_TOPMOST
 
     // right-to-left writing system?
-    for _, r := range message {
-        prop, _ := bidi.LookupRune(r)
-        cls := prop.Class()
-        var isRtl bool
-
-        switch cls {
-            case bidi.R:   isRtl = true // Strong R-to-L
-            case bidi.AL:  isRtl = true // Strong R-to-L
-            case bidi.RLO: isRtl = true // Explicit R-to-L
-        }
-        if isRtl {
-            rtlFlags := uint32(0) | windows.MB_RIGHT | windows.MB_RTLREADING
-            flags |= rtlFlags
-            continue
-        }
+    if isRTL(message) {
+        flags |= windows.MB_RIGHT | windows.MB_RTLREADING
     }
 
     return flags
@@ -413,8 +399,6 @@ func (m Message) ask(message string) (bool, error) {
         windows.MB_ICONWARNING   | // docs say don't use ICONQUESTION
         m.flags(message)
 
-    // TODO add MB_RIGHT | MB_RTLREADING if right-to-left runes detected
-
     q, _ := windows.MessageBox(0, wide(message), wide(m.Title), flags)
     return q == 6, nil // IDYES
 }
@@ -6,7 +6,14 @@
 //
 // All dialogs will default to using localised text for window titles, buttons,
 // etc. where possible, but may default to using English in places depending on
-// the implementation.
+// the implementation. Default text that this package supplies itself is in the
+// language given by [Language], which is detected from the environment or set
+// with [SetLanguage]. Use [RegisterLocale] to add a language.
+//
+// Messages written in a right-to-left writing system, such as Arabic or Hebrew,
+// are displayed right-to-left on Windows, by kdialog, and by the terminal
+// dialogs. GTK programs such as zenity and yad detect the direction
+// themselves.
 //
 // ## Alternatives
 //
@@ -46,26 +53,89 @@
 // On other systems (Linux, etc), this package uses (in order of priority) one
 // or more of:
 //
+//   - the XDG desktop portal, over D-Bus (file pickers only)
 //   - zenity
+//   - yad
+//   - kdialog
 //   - xmessage
 //   - whiptail in an xterm
+//   - the terminal itself, drawn directly on the controlling TTY
 //   - osascript (Apple script) (TODO)
 //
+// The order of priority depends on the desktop environment, as detected from
+// the XDG_CURRENT_DESKTOP environment variable, so that a native
+// implementation is preferred: for example, kdialog is preferred on KDE
+// Plasma, and yad is preferred on Xfce.
+//
+// The XDG desktop portal (org.freedesktop.portal.FileChooser) is used for
+// file pickers wherever a D-Bus session bus is available, because it also
+// works inside a sandbox such as Flatpak or Snap where the other programs
+// can't be run. If there is no portal on the bus, file pickers fall back to
+// the next implementation.
+//
+// With whiptail, which doesn't have a file picker, a path is typed into an
+// input box instead, and the FileTypes of a [FilePicker] are ignored.
+//
+// Desktop notifications (see [Notification]) use the notification server
+// (org.freedesktop.Notifications) over D-Bus, which supports actions, or
+// else the notify-send program, which doesn't. On Windows, notifications are
+// shown as a balloon from a notification area icon, which newer versions of
+// Windows show as a toast.
+//
+// If there is no display server (neither the DISPLAY nor WAYLAND_DISPLAY
+// environment variables are set), for example over SSH or in a plain
+// console, dialogs are instead drawn directly on the controlling terminal
+// (/dev/tty), with keyboard navigation, without needing any other programs.
+//
 // ## Feature support
 //
 //   Platform/software | Message.Raise | Message.Ask | FilePicker | ColorPicker | DatePicker
 //   ---------------------------------------------------------------------------------------
 //   Windows           | Yes           | Yes         | Yes        |  No         |  No
+//   XDG portal        |  No           |  No         | Yes        |  No         |  No
 //   zenity            | Yes           | Yes         | Yes        | Yes         | Yes
+//   yad               | Yes           | Yes         | Yes        | Yes         | Yes
+//   kdialog           | Yes           | Yes         | Yes        | Yes         | Yes
 //   xmessage          | Yes           | Yes         |  No        |  No         |  No
 //   whiptail + xterm  |  No           |  No         | Yes        | Yes         | Yes
+//   terminal (TUI)    | Yes           | Yes         | Yes        | Yes         | Yes
 //   osascript         | TODO          | TODO        | TODO       | TODO        | TODO
 //
+//   Platform/software | TextInput | PasswordInput | Choice | Progress | Question
+//   -----------------------------------------------------------------------------
+//   Windows           | Yes       | Yes           | Yes    | Yes      | Yes
+//   XDG portal        |  No       |  No           |  No    |  No      |  No
+//   zenity            | Yes       | Yes           | Yes    | Yes      | Yes
+//   yad               | Yes       | Yes           | Yes    | Yes      | Yes
+//   kdialog           | Yes       | Yes           | Yes    |  No      | Yes
+//   xmessage          |  No       |  No           |  No    |  No      | Yes
+//   whiptail + xterm  | Yes       | Yes           | Yes    | Yes      | Yes
+//   terminal (TUI)    | Yes       | Yes           | Yes    | Yes      | Yes
+//   osascript         | TODO      | TODO          | TODO   | TODO     | TODO
+//
+// The checkbox of a [Question], such as "Don't ask again", is only shown on
+// Windows, by yad, and on the terminal.
+//
+// See [Supported] for the features, and [Backends] for the implementations,
+// available at runtime.
+//
+// ## Backends
+//
+// Each implementation is a [Backend], registered by name e.g. "zenity" or
+// "windows". By default, the "auto" backend picks the best available
+// implementation for each type of dialog. A different backend may be forced
+// with [Use], or with the [EnvBackend] environment variable.
+//
+// To test code that uses dialogs without showing anything, use the [Script]
+// backend, which returns queued answers and records each dialog shown.
 package dialog
 
 import (
     "fmt"
     "image/color"
+    "os"
+    "path/filepath"
+    "strings"
     "time"
 )
 
@@ -80,17 +150,38 @@ func Init() error {
 type Support struct {
     MessageRaise    bool // Can use Message.Raise?
     MessageAsk      bool // Can use Message.Ask?
+    Question        bool // Can use Question.Ask?
     FilePicker      bool // Can use FilePicker.Open, FilePicker.Save?
     MultiFilePicker bool // Can use FilePicker.OpenMultiple?
     ColorPicker     bool // Can use ColorPicker.Pick?
     DatePicker      bool // Can use DatePicker.Pick?
+    TextInput       bool // Can use TextInput.Ask?
+    PasswordInput   bool // Can use PasswordInput.Ask?
+    Choice          bool // Can use Choice.Pick?
+    MultiChoice     bool // Can use Choice.PickMultiple?
+    Progress        bool // Can use Progress.Start?
+    Notification    bool // Can use Notification.Send?
 }
 
 // Supported returns a [Support] struct detailing what features are available
 // on the current system. Using a feature that isn't supported will silently
 // proceed as documented.
 func Supported() (Support, error) {
-    return supported()
+    b, err := Current()
+    if err != nil { return Support{}, err }
+    return b.Supported(), initErr
+}
+
+// Backends returns the names of the implementations used by the current
+// [Backend], in order of priority, e.g. ["kdialog", "xmessage"]. For a Backend
+// not provided by this package, returns nil.
+func Backends() ([]string, error) {
+    b, err := Current()
+    if err != nil { return nil, err }
+    if n, ok := b.(interface{ backends() []string }); ok {
+        return n.backends(), initErr
+    }
+    return nil, initErr
 }
 
 // Alert is like [Raise] and the other convenience methods, but doesn't return
@@ -138,7 +229,7 @@ func Info(message string, args...interface{}) error {
 // Warning is like [Raise], but uses a warning icon and title bar.
 func Warning(message string, args...interface{}) error {
     return Message{
-        Title:  "Warning", // may be localised
+        Title:  locale().Warning,
         Format: message,
         Args:   args,
         Icon:   IconWarning,
@@ -148,7 +239,7 @@ func Warning(message string, args...interface{}) error {
 // Error is like [Raise], but uses an error icon and title bar.
 func Error(message string, args...interface{}) error {
     return Message{
-        Title:  "Error", // may be localised
+        Title:  locale().Error,
         Format: message,
         Args:   args,
         Icon:   IconError,
@@ -171,14 +262,16 @@ func Color() (color.Color, bool, error) {
 // Where not supported, immediately returns (zero, false, nil) without blocking
 // (see [Supported]).
 func (m ColorPicker) Pick() (color.Color, bool, error) {
-    if m.Title == "" { m.Title = "Select color" }
-    return m.pick()
+    if m.Title == "" { m.Title = locale().SelectColor }
+    b, err := Current()
+    if err != nil { return nil, false, err }
+    return b.ColorPick(m)
 }
 
 // ColorPicker is a dialog to select a colour.
 type ColorPicker struct {
     // Title is the colour picker window title (may be empty) e.g. "Colour".
-    // If omitted, defaults to en-US "Color".
+    // If omitted, defaults to a localised "Select color" (see [Language]).
     Title string
 
     // Palette controls the color picker mode. If false, this is a simple
@@ -220,7 +313,9 @@ func (m DatePicker) Pick() (time.Time, bool, error) {
     // m.Title has sensible defaults on some implementations, so not set here
     if m.Location == nil { m.Location = time.Local }
     if m.Initial.IsZero() { m.Initial = time.Now() }
-    return m.pick()
+    b, err := Current()
+    if err != nil { return time.Time{}, false, err }
+    return b.DatePick(m)
 }
 
 // DatePicker is a dialog to select a date (year, month, day).
@@ -294,14 +389,39 @@ type FilePicker struct {
     // Path is the initial file selected (if empty, defaults to
     // current working directory). To open in a specific directory without
     // specifying a file name, use a trailing slash.
+    //
+    // Dir and FileName, if not empty, take precedence over the directory and
+    // the file name parts of Path, respectively.
     Path string
 
+    // Dir is the directory that the file picker starts in (may be empty).
+    Dir string
+
+    // FileName is the name of the file initially selected, or, for Save, the
+    // suggested name of the new file (may be empty). Any directory part is
+    // ignored.
+    FileName string
+
+    // Directory, if true, means that [FilePicker.Open] and
+    // [FilePicker.OpenMultiple] select existing directories instead of files.
+    // FileTypes are ignored.
+    Directory bool
+
+    // ConfirmOverwrite, if true, means that [FilePicker.Save] asks the user
+    // to confirm before selecting a file that already exists. Some file
+    // pickers always ask, regardless.
+    ConfirmOverwrite bool
+
     // FileTypes is hint describing known file types and file extensions. It
     // may be used to filter visible files. May be nil. This is a slice of
     // 2-tuples. The first item in the tuple is a human-readable label, the
-    // second item in the tuple is a list of patterns delimited by space. Can
-    // be left as nil as default. ("All Files", "*.*") is automatically added
-    // to the end if the last item doesn't have the exact filter "*.*".
+    // second item in the tuple is a list of glob patterns delimited by space.
+    // Can be left as nil as default. A localised ("All Files", "*.*") is
+    // automatically added to the end if the last item doesn't have the exact
+    // filter "*.*" (see [Language]).
+    //
+    // The pattern "*.*" matches every file, including files without an
+    // extension, on every platform.
     //
     // For example:
     //
@@ -309,7 +429,7 @@ type FilePicker struct {
     //       {"Text Document", "*.txt *.rtf"},
     //       {"Image",         "*.png"},
     //       ...
-    //       {"Pob Ffeil",      "*.*"}, // suppress English "All Files"
+    //       {"Everything",     "*.*"}, // instead of "All Files"
     //   }
     FileTypes [][2]string
 
@@ -335,16 +455,62 @@ func (m FilePicker) clear() FilePicker {
     fileTypes := make([][2]string, 0, len(m.FileTypes) + 1)
     fileTypes = append(fileTypes, m.FileTypes...)
 
-    if (len(fileTypes)) > 0 && (fileTypes[len(fileTypes) - 1][1] != "*.*") {
-        fileTypes = append(fileTypes, [2]string{"All Files", "*.*"})
-    } else if len(fileTypes) == 0 {
-        fileTypes = append(fileTypes, [2]string{"All Files", "*.*"})
+    if (len(fileTypes) == 0) || (fileTypes[len(fileTypes) - 1][1] != "*.*") {
+        fileTypes = append(fileTypes, [2]string{locale().AllFiles, "*.*"})
     }
 
     m.FileTypes = fileTypes
     return m
 }
 
+// location returns the absolute path of the directory the file picker starts
+// in, and the name of the file initially selected (may be empty).
+func (m FilePicker) location() (string, string, error) {
+    dir, name := m.Path, ""
+    if info, err := os.Stat(dir); (err == nil) && info.IsDir() {
+        // ok
+    } else if (dir != "") && !strings.HasSuffix(dir, string(filepath.Separator)) && !strings.HasSuffix(dir, "/") {
+        dir, name = filepath.Split(dir)
+    }
+    if m.Dir != "" { dir = m.Dir }
+    if m.FileName != "" { name = filepath.Base(m.FileName) }
+
+    if dir == "" {
+        cwd, err := os.Getwd()
+        if err != nil {
+            return "", "", fmt.Errorf("error getting working directory: %v", err)
+        }
+        dir = cwd
+    }
+    abs, err := filepath.Abs(dir)
+    if err != nil {
+        return "", "", fmt.Errorf("error getting absolute path of %q: %v", dir, err)
+    }
+    return abs, name, nil
+}
+
+// globs splits a space-delimited list of FileTypes patterns. The pattern
+// "*.*" becomes "*", so that it also matches files without an extension.
+func globs(patterns string) []string {
+    xs := strings.Fields(patterns)
+    for i := range xs {
+        if xs[i] == "*.*" { xs[i] = "*" }
+    }
+    return xs
+}
+
+// overwrite returns a question to ask before selecting path, if path exists
+// and the file picker should confirm an overwrite. This is for file pickers
+// that can't ask by themselves.
+func (m FilePicker) overwrite(path string) (Message, string, bool) {
+    if !m.ConfirmOverwrite { return Message{}, "", false }
+    if _, err := os.Stat(path); err != nil { return Message{}, "", false }
+    return Message{
+        Title: m.Title,
+        Icon:  IconWarning,
+    }, fmt.Sprintf(locale().Overwrite, filepath.Base(path)), true
+}
+
 // Open displays a file picker dialog to select a single file. It blocks until
 // an option is picked, then returns the selected path as an absolute path, and
 // true, or an empty string and false if no file was selected (i.e. the user
@@ -357,16 +523,20 @@ func (m FilePicker) clear() FilePicker {
 // but merely selects a path.
 func (m FilePicker) Open() (string, bool, error) {
     m = m.clear()
-    if m.Title == "" { m.Title = "Open File..." }
-    return m.open()
+    if m.Title == "" { m.Title = locale().OpenFile }
+    b, err := Current()
+    if err != nil { return "", false, err }
+    return b.FileOpen(m)
 }
 
 // OpenMultiple is like [FilePicker.Open], but allows multiple files to be
 // selected. Each returned path is still an absolute path.
 func (m FilePicker) OpenMultiple() ([]string, bool, error) {
     m = m.clear()
-    if m.Title == "" { m.Title = "Open Files..." }
-    return m.openMultiple()
+    if m.Title == "" { m.Title = locale().OpenFiles }
+    b, err := Current()
+    if err != nil { return []string{}, false, err }
+    return b.FileOpenMultiple(m)
 }
 
 // Save is like [FilePicker.Open], but for writing to a file. This may change
@@ -377,8 +547,10 @@ func (m FilePicker) OpenMultiple() ([]string, bool, error) {
 // but merely selects a path.
 func (m FilePicker) Save() (string, bool, error) {
     m = m.clear()
-    if m.Title == "" { m.Title = "Save As..." }
-    return m.save()
+    if m.Title == "" { m.Title = locale().SaveAs }
+    b, err := Current()
+    if err != nil { return "", false, err }
+    return b.FileSave(m)
 }
 
 type IconType int
@@ -434,8 +606,10 @@ func (m Message) clear() (Message, string) {
 // icon instead.
 func (m Message) Ask() (bool, error) {
     n, s := m.clear()
-    if n.Title == "" { n.Title = "Question" }
-    return n.ask(s)
+    if n.Title == "" { n.Title = locale().Question }
+    b, err := Current()
+    if err != nil { return false, err }
+    return b.MessageAsk(n, s)
 }
 
 // Raise displays a message. It blocks until the message is acknowledged
@@ -444,6 +618,8 @@ func (m Message) Ask() (bool, error) {
 // Where not supported, returns immediately without blocking.
 func (m Message) Raise() error {
     n, s := m.clear()
-    if n.Title == "" { n.Title = "Message" }
-    return n.raise(s)
+    if n.Title == "" { n.Title = locale().Message }
+    b, err := Current()
+    if err != nil { return err }
+    return b.MessageRaise(n, s)
 }
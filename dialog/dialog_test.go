@@ -0,0 +1,263 @@
+package dialog_test
+
+import (
+    "context"
+    "image/color"
+    "testing"
+
+    "github.com/stretchr/testify/assert"
+    "github.com/tawesoft/golib/v2/dialog"
+    "golang.org/x/text/language"
+)
+
+func TestScript(t *testing.T) {
+    t.Setenv("LC_ALL", "en_GB.UTF-8") // for default titles
+    script := &dialog.Script{}
+    defer dialog.Use(dialog.Use(script))
+
+    // no answers queued
+    _, err := dialog.Ask("Question?")
+    assert.Error(t, err)
+
+    // raise doesn't take an answer
+    assert.Nil(t, dialog.Warning("Careful %s", "now"))
+    assert.Equal(t, 0, script.Remaining())
+
+    // wrong type
+    script.Push(dialog.Answer{Ok: true, Value: 123})
+    _, _, err = dialog.Open("")
+    assert.Error(t, err)
+
+    // cancelled
+    script.Push(dialog.Answer{Ok: false})
+    _, ok, err := dialog.Color()
+    assert.Nil(t, err)
+    assert.False(t, ok)
+
+    script.Push(dialog.Answer{Ok: true, Value: color.Color(color.White)})
+    c, ok, err := dialog.Color()
+    assert.Nil(t, err)
+    assert.True(t, ok)
+    assert.Equal(t, color.White, c)
+
+    records := script.Records()
+    assert.Len(t, records, 5)
+    assert.Equal(t, "MessageRaise", records[1].Method)
+    assert.Equal(t, "Careful now", records[1].Text)
+    assert.Equal(t, "Warning", records[1].Dialog.(dialog.Message).Title)
+    assert.Equal(t, "Select color", records[4].Dialog.(dialog.ColorPicker).Title)
+}
+
+func TestScript_input(t *testing.T) {
+    t.Setenv("LC_ALL", "en_GB.UTF-8") // for default titles
+    script := &dialog.Script{}
+    defer dialog.Use(dialog.Use(script))
+
+    script.Push(
+        dialog.Answer{Ok: true, Value: "Alice"},
+        dialog.Answer{Ok: true, Value: "hunter2"},
+        dialog.Answer{Ok: true, Value: 1},
+        dialog.Answer{Ok: true, Value: []int{0, 2}},
+    )
+
+    name, ok, err := dialog.Input("Name:")
+    assert.Nil(t, err)
+    assert.True(t, ok)
+    assert.Equal(t, "Alice", name)
+
+    password, ok, err := dialog.Password("Password:")
+    assert.Nil(t, err)
+    assert.True(t, ok)
+    assert.Equal(t, "hunter2", password)
+
+    i, ok, err := dialog.Choose("Pick one:", "a", "b", "c")
+    assert.Nil(t, err)
+    assert.True(t, ok)
+    assert.Equal(t, 1, i)
+
+    xs, ok, err := dialog.Choice{
+        Options: []string{"a", "b", "c"},
+        Default: []int{2, 7, 0, 2},
+    }.PickMultiple()
+    assert.Nil(t, err)
+    assert.True(t, ok)
+    assert.Equal(t, []int{0, 2}, xs)
+
+    records := script.Records()
+    assert.Len(t, records, 4)
+    assert.Equal(t, "Input", records[0].Dialog.(dialog.TextInput).Title)
+    assert.Equal(t, "Password", records[1].Dialog.(dialog.PasswordInput).Title)
+    assert.Equal(t, []int{0, 2}, records[3].Dialog.(dialog.Choice).Default)
+
+    // a choice without options is cancelled without a dialog
+    _, ok, err = dialog.Choose("Pick one:")
+    assert.Nil(t, err)
+    assert.False(t, ok)
+    assert.Len(t, script.Records(), 4)
+}
+
+func TestScript_question(t *testing.T) {
+    t.Setenv("LC_ALL", "en_GB.UTF-8") // for default titles
+    script := &dialog.Script{}
+    defer dialog.Use(dialog.Use(script))
+
+    script.Push(
+        dialog.Answer{Value: 2},
+        dialog.Answer{Ok: true, Value: -1},
+        dialog.Answer{Value: 3},
+    )
+
+    i, err := dialog.AskButtons([]string{"Save", "Don't Save", "Cancel"}, "Save changes to %q?", "a.txt")
+    assert.Nil(t, err)
+    assert.Equal(t, 2, i)
+
+    i, checked, err := dialog.Question{
+        Default:  5,
+        Checkbox: "Don't ask again",
+    }.Ask()
+    assert.Nil(t, err)
+    assert.True(t, checked)
+    assert.Equal(t, -1, i)
+
+    _, _, err = dialog.Question{}.Ask()
+    assert.NotNil(t, err)
+
+    records := script.Records()
+    assert.Len(t, records, 3)
+    assert.Equal(t, `Save changes to "a.txt"?`, records[0].Text)
+    q := records[1].Dialog.(dialog.Question)
+    assert.Equal(t, "Question", q.Title)
+    assert.Equal(t, []string{"OK"}, q.Buttons)
+    assert.Equal(t, 0, q.Default)
+}
+
+func TestScript_progress(t *testing.T) {
+    script := &dialog.Script{}
+    defer dialog.Use(dialog.Use(script))
+
+    // the user cancels after two updates
+    script.Push(dialog.Answer{Ok: false, Value: 2})
+
+    p, err := dialog.Progress{Text: "Copying"}.Start(context.Background())
+    assert.Nil(t, err)
+
+    assert.Nil(t, p.SetPercent(50))
+    assert.Nil(t, p.SetText("Copying more"))
+
+    <-p.Context().Done()
+    assert.Equal(t, dialog.ErrCancelled, context.Cause(p.Context()))
+    assert.Nil(t, p.SetPercent(75)) // ignored
+    assert.Nil(t, p.Done())
+
+    var methods []string
+    for _, r := range script.Records() {
+        methods = append(methods, r.Method+":"+r.Text)
+    }
+    assert.Equal(t, []string{
+        "ProgressStart:Copying",
+        "ProgressSetPercent:50",
+        "ProgressSetText:Copying more",
+        "ProgressClose:",
+    }, methods)
+
+    // not cancelled by the user
+    script.Push(dialog.Answer{Ok: true})
+    p, err = dialog.Progress{}.Start(context.Background())
+    assert.Nil(t, err)
+    assert.Nil(t, p.SetPercent(200))
+    assert.Nil(t, p.Done())
+    assert.Equal(t, context.Canceled, context.Cause(p.Context()))
+    assert.Equal(t, "100", script.Records()[5].Text)
+}
+
+func TestScript_notify(t *testing.T) {
+    script := &dialog.Script{}
+    defer dialog.Use(dialog.Use(script))
+
+    // without actions, doesn't take an answer
+    assert.Nil(t, dialog.Notify("Done", "Synced %d files", 3))
+
+    script.Push(dialog.Answer{Ok: true, Value: 1})
+    c, err := dialog.Notification{
+        Title:   "Done",
+        Actions: []string{"Dismiss", "Open"},
+    }.Send()
+    assert.Nil(t, err)
+    assert.Equal(t, 1, <-c)
+
+    records := script.Records()
+    assert.Len(t, records, 2)
+    assert.Equal(t, "Synced 3 files", records[0].Text)
+    assert.Equal(t, "Done", records[0].Dialog.(dialog.Notification).Title)
+    assert.Equal(t, 0, script.Remaining())
+}
+
+func TestCurrent(t *testing.T) {
+    defer dialog.Use(dialog.Use(nil))
+
+    t.Setenv(dialog.EnvBackend, "none")
+    b, err := dialog.Current()
+    assert.Nil(t, err)
+    assert.True(t, b.Supported() == dialog.Support{}) // comparable
+
+    ok, err := dialog.Ask("Question?")
+    assert.Nil(t, err)
+    assert.True(t, ok)
+
+    t.Setenv(dialog.EnvBackend, "no-such-backend")
+    _, err = dialog.Current()
+    assert.Error(t, err)
+    assert.Error(t, dialog.Raise("Hello"))
+
+    script := &dialog.Script{}
+    dialog.Use(script)
+    b, err = dialog.Current()
+    assert.Nil(t, err)
+    assert.Equal(t, script, b)
+
+    names, err := dialog.Backends()
+    assert.Nil(t, err)
+    assert.Equal(t, []string{"script"}, names)
+}
+
+func TestLanguage(t *testing.T) {
+    t.Setenv("LC_ALL", "")
+    t.Setenv("LC_MESSAGES", "")
+    t.Setenv("LANG", "C")
+    assert.Equal(t, language.English, dialog.Language())
+
+    t.Setenv("LANG", "de_AT.UTF-8")
+    assert.Equal(t, language.MustParse("de-AT"), dialog.Language())
+
+    t.Setenv("LC_ALL", "cy_GB.UTF-8@euro")
+    assert.Equal(t, language.MustParse("cy-GB"), dialog.Language())
+
+    dialog.SetLanguage(language.French)
+    assert.Equal(t, language.French, dialog.Language())
+    dialog.SetLanguage(language.Und)
+    assert.Equal(t, language.MustParse("cy-GB"), dialog.Language())
+}
+
+func TestScript_locale(t *testing.T) {
+    script := &dialog.Script{}
+    defer dialog.Use(dialog.Use(script))
+
+    t.Setenv("LC_ALL", "cy_GB.UTF-8")
+    script.Push(dialog.Answer{Value: 0}, dialog.Answer{Value: 0})
+
+    _, _, err := dialog.Question{}.Ask()
+    assert.Nil(t, err)
+
+    dialog.SetLanguage(language.MustParse("pt-BR")) // not registered
+    defer dialog.SetLanguage(language.Und)
+    _, _, err = dialog.Question{}.Ask()
+    assert.Nil(t, err)
+
+    records := script.Records()
+    assert.Len(t, records, 2)
+    q := records[0].Dialog.(dialog.Question)
+    assert.Equal(t, "Cwestiwn", q.Title)
+    assert.Equal(t, []string{"Iawn"}, q.Buttons)
+    q = records[1].Dialog.(dialog.Question)
+    assert.Equal(t, "Question", q.Title)
+}
@@ -0,0 +1,30 @@
+package dialog_test
+
+import (
+    "fmt"
+
+    "github.com/tawesoft/golib/v2/dialog"
+)
+
+func ExampleScript() {
+    script := &dialog.Script{}
+    script.Push(
+        dialog.Answer{Ok: true},
+        dialog.Answer{Ok: true, Value: "/home/example/notes.txt"},
+    )
+    defer dialog.Use(dialog.Use(script))
+
+    if ok, _ := dialog.Ask("Open a file?"); ok {
+        path, ok, _ := dialog.Open("")
+        fmt.Println(path, ok)
+    }
+
+    for _, r := range script.Records() {
+        fmt.Printf("%s %q\n", r.Method, r.Text)
+    }
+
+    // Output:
+    // /home/example/notes.txt true
+    // MessageAsk "Open a file?"
+    // FileOpen ""
+}
@@ -0,0 +1,186 @@
+package dialog
+
+import (
+    "fmt"
+    "sort"
+    "strconv"
+    "strings"
+)
+
+// Input is a convenience function to display a dialog asking for a line of
+// text. Use the [TextInput.Ask] method on a configured [TextInput] for more
+// options. It blocks until an option is picked. Where not supported,
+// immediately returns ("", false, nil) without blocking.
+func Input(label string) (string, bool, error) {
+    return TextInput{
+        Label: label,
+    }.Ask()
+}
+
+// Password is a convenience function to display a dialog asking for a
+// password or other secret, which is hidden as it is typed. Use the
+// [PasswordInput.Ask] method on a configured [PasswordInput] for more
+// options. It blocks until an option is picked. Where not supported,
+// immediately returns ("", false, nil) without blocking.
+func Password(label string) (string, bool, error) {
+    return PasswordInput{
+        Label: label,
+    }.Ask()
+}
+
+// Choose is a convenience function to display a dialog to choose one of
+// several options. Use the [Choice.Pick] method on a configured [Choice] for
+// more options. It blocks until an option is picked, then returns the index
+// of the chosen option. Where not supported, immediately returns
+// (0, false, nil) without blocking.
+func Choose(label string, options ... string) (int, bool, error) {
+    return Choice{
+        Label:   label,
+        Options: options,
+    }.Pick()
+}
+
+// TextInput is a dialog to enter a single line of text.
+type TextInput struct {
+    // Title is the window title (may be empty). If omitted, defaults to
+    // a localised "Input" (see [Language]).
+    Title string
+
+    // Label is text shown above the input (may be empty) e.g. "Your name:".
+    Label string
+
+    // Initial is text entered into the input by default (may be empty).
+    Initial string
+}
+
+// Ask displays the dialog. It blocks until an option is picked, then returns
+// the entered text and true, or an empty string and false if the user
+// selected the cancel option.
+//
+// Where not supported, immediately returns ("", false, nil) without blocking
+// (see [Supported]).
+func (m TextInput) Ask() (string, bool, error) {
+    if m.Title == "" { m.Title = locale().Input }
+    b, err := Current()
+    if err != nil { return "", false, err }
+    return b.TextAsk(m)
+}
+
+// PasswordInput is a dialog to enter a password or other secret. The text is
+// hidden as it is typed.
+type PasswordInput struct {
+    // Title is the window title (may be empty). If omitted, defaults to
+    // a localised "Password" (see [Language]).
+    Title string
+
+    // Label is text shown above the input (may be empty) e.g.
+    // "Enter the password for example.org:".
+    Label string
+}
+
+// Ask displays the dialog. It blocks until an option is picked, then returns
+// the entered text and true, or an empty string and false if the user
+// selected the cancel option.
+//
+// Where not supported, immediately returns ("", false, nil) without blocking
+// (see [Supported]).
+func (m PasswordInput) Ask() (string, bool, error) {
+    if m.Title == "" { m.Title = locale().Password }
+    b, err := Current()
+    if err != nil { return "", false, err }
+    return b.PasswordAsk(m)
+}
+
+// Choice is a dialog to choose one, or several, of a list of options.
+type Choice struct {
+    // Title is the window title (may be empty). If omitted, defaults to
+    // a localised "Select" (see [Language]).
+    Title string
+
+    // Label is text shown above the options (may be empty) e.g.
+    // "Select a language:".
+    Label string
+
+    // Options are the labels of each option, in order.
+    Options []string
+
+    // Default lists the indexes of options initially selected (may be
+    // empty). For [Choice.Pick], only the first is used.
+    Default []int
+}
+
+// clear sets defaults on the choice (and makes its own copy of the default
+// slice, without out-of-range or duplicate indexes, sorted).
+func (m Choice) clear(title string) Choice {
+    if m.Title == "" { m.Title = title }
+
+    seen := make(map[int]bool)
+    defaults := make([]int, 0, len(m.Default))
+    for _, i := range m.Default {
+        if (i < 0) || (i >= len(m.Options)) || seen[i] { continue }
+        seen[i] = true
+        defaults = append(defaults, i)
+    }
+    sort.Ints(defaults)
+
+    m.Default = defaults
+    return m
+}
+
+// selected returns true if the option at index i is selected by default.
+func (m Choice) selected(i int, multiple bool) bool {
+    if !multiple {
+        return (len(m.Default) > 0) && (m.Default[0] == i)
+    }
+    for _, d := range m.Default {
+        if d == i { return true }
+    }
+    return false
+}
+
+// Pick displays the dialog to choose a single option. It blocks until an
+// option is picked, then returns the index of the chosen option and true, or
+// false if the user selected the cancel option.
+//
+// Where not supported, immediately returns (0, false, nil) without blocking
+// (see [Supported]).
+func (m Choice) Pick() (int, bool, error) {
+    m = m.clear(locale().Select)
+    if len(m.Options) == 0 { return 0, false, nil }
+    b, err := Current()
+    if err != nil { return 0, false, err }
+    return b.ChoicePick(m)
+}
+
+// PickMultiple is like [Choice.Pick], but allows any number of options to be
+// chosen. The indexes are returned in ascending order.
+//
+// Where not supported, immediately returns ([]int{}, false, nil) without
+// blocking (see [Supported]).
+func (m Choice) PickMultiple() ([]int, bool, error) {
+    m = m.clear(locale().Select)
+    if len(m.Options) == 0 { return []int{}, false, nil }
+    b, err := Current()
+    if err != nil { return []int{}, false, err }
+    return b.ChoicePickMultiple(m)
+}
+
+// parseIndexes parses the output of an implementation that prints the index
+// of each chosen option, delimited by any of the runes in sep (and
+// whitespace), checking that each index is in range. The result is sorted.
+func parseIndexes(s string, sep string, n int) ([]int, error) {
+    fields := strings.FieldsFunc(s, func(r rune) bool {
+        return strings.ContainsRune(sep, r) || (r == ' ') || (r == '\n') || (r == '\r') || (r == '\t')
+    })
+
+    result := make([]int, 0, len(fields))
+    for _, f := range fields {
+        i, err := strconv.Atoi(strings.Trim(f, `"`))
+        if (err != nil) || (i < 0) || (i >= n) {
+            return nil, fmt.Errorf("invalid choice %q", f)
+        }
+        result = append(result, i)
+    }
+    sort.Ints(result)
+    return result, nil
+}
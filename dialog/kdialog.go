@@ -0,0 +1,292 @@
+//go:build (linux || unix)
+
+package dialog
+
+import (
+    "fmt"
+    "image/color"
+    "os/exec"
+    "path/filepath"
+    "regexp"
+    "strconv"
+    "strings"
+    "time"
+)
+
+// kdialog is the native dialog program for KDE Plasma.
+type kdialog struct {
+    path string
+}
+
+func (x kdialog) run(args ... string) (string, bool, error) {
+    var sb strings.Builder
+    cmd := exec.Command(x.path, args...)
+    cmd.Stdout = &sb
+
+    if err := cmd.Run(); err != nil {
+        if ExitError, ok := err.(*exec.ExitError); ok && (ExitError.ExitCode() == 1) {
+            return "", false, nil // "no", or cancel
+        } else {
+            return "", false, fmt.Errorf("kdialog error: %w", err)
+        }
+    }
+
+    return strings.TrimRight(sb.String(), "\n"), true, nil
+}
+
+// direction returns the Qt arguments for laying out a message box
+// right-to-left, if the message is written right-to-left.
+func (x kdialog) direction(message string) []string {
+    if isRTL(message) { return []string{"-reverse"} }
+    return nil
+}
+
+func (x kdialog) ask(m Message, message string) (bool, error) {
+    args := append(x.direction(message), "--title", m.Title, "--yesno", message)
+    _, ok, err := x.run(args...)
+    return ok, err
+}
+
+// question uses a message box for up to three buttons, or else a menu.
+// kdialog can't show a checkbox, or set a default button.
+func (x kdialog) question(m Question, message string) (int, bool, error) {
+    args := append(x.direction(message), "--title", m.Title)
+    labels := []string{"--yes-label", "--no-label", "--cancel-label"}
+
+    switch len(m.Buttons) {
+        case 1: args = append(args, "--msgbox", message, "--ok-label", m.Buttons[0])
+        case 2: args = append(args, "--yesno", message)
+        case 3: args = append(args, "--yesnocancel", message)
+        default:
+            args = append(args, "--menu", message)
+            for i, label := range m.Buttons {
+                args = append(args, strconv.Itoa(i), label)
+            }
+            args = append(args, "--default", strconv.Itoa(m.Default))
+    }
+    if (len(m.Buttons) == 2) || (len(m.Buttons) == 3) {
+        for i, label := range m.Buttons {
+            args = append(args, labels[i], label)
+        }
+    }
+
+    var sb strings.Builder
+    cmd := exec.Command(x.path, args...)
+    cmd.Stdout = &sb
+
+    code := 0
+    if err := cmd.Run(); err != nil {
+        ExitError, ok := err.(*exec.ExitError)
+        if !ok { return -1, false, fmt.Errorf("kdialog error: %w", err) }
+        code = ExitError.ExitCode()
+    }
+
+    if len(m.Buttons) > 3 {
+        if code != 0 { return -1, false, nil }
+        i, err := strconv.Atoi(strings.TrimSpace(sb.String()))
+        if (err != nil) || (i < 0) || (i >= len(m.Buttons)) {
+            return -1, false, fmt.Errorf("kdialog menu parse error parsing %q", sb.String())
+        }
+        return i, false, nil
+    }
+
+    // exit code is 0 for yes (or OK), 1 for no, and 2 for cancel
+    if code >= len(m.Buttons) {
+        return -1, false, nil
+    }
+    return code, false, nil
+}
+
+func (x kdialog) raise(m Message, message string) error {
+    mode := "--msgbox"
+    switch m.Icon {
+        case IconWarning: mode = "--sorry"
+        case IconError:   mode = "--error"
+    }
+
+    args := append(x.direction(message), "--title", m.Title, mode, message)
+    _, _, err := x.run(args...)
+    return err
+}
+
+var kdialogColorPickerRE = regexp.MustCompile(`^#(?P<red>[[:xdigit:]]{2})(?P<green>[[:xdigit:]]{2})(?P<blue>[[:xdigit:]]{2})$`)
+
+func (x kdialog) color(m ColorPicker) (color.Color, bool, error) {
+    var zero color.Color
+
+    args := []string{
+        "--title", m.Title,
+        "--getcolor",
+    }
+
+    if m.Initial != zero {
+        r, g, b, _ := m.Initial.RGBA()
+        r /= 256; g /= 256; b /= 256;
+        args = append(args, "--default", fmt.Sprintf("#%02x%02x%02x", r, g, b))
+    }
+
+    result, ok, err := x.run(args...)
+    if (err != nil) || !ok { return zero, false, err }
+
+    result = strings.TrimSpace(result)
+    matches := kdialogColorPickerRE.FindStringSubmatch(result)
+    if len(matches) != 4 {
+        return zero, false, fmt.Errorf("kdialog --getcolor parse error parsing %q", result)
+    }
+    cr, errR := strconv.ParseUint(matches[1], 16, 8)
+    cg, errG := strconv.ParseUint(matches[2], 16, 8)
+    cb, errB := strconv.ParseUint(matches[3], 16, 8)
+    if (errR != nil) || (errG != nil) || (errB != nil) {
+        return zero, false, fmt.Errorf("kdialog --getcolor parse error parsing %q", result)
+    }
+
+    return color.RGBA{
+        R: uint8(cr),
+        G: uint8(cg),
+        B: uint8(cb),
+        A: 255,
+    }, true, nil
+}
+
+func (x kdialog) date(m DatePicker) (time.Time, bool, error) {
+    var zero time.Time
+
+    text := m.LongTitle
+    if text == "" { text = m.Title }
+
+    args := []string{
+        "--calendar",   text,
+        "--dateformat", "yyyyMMdd",
+        "--default",    m.Initial.Format("20060102"),
+    }
+
+    if len(m.Title) > 0 {
+        args = append(args, "--title", m.Title)
+    }
+
+    result, ok, err := x.run(args...)
+    if (err != nil) || !ok { return zero, false, err }
+
+    t, err := time.ParseInLocation("20060102", strings.TrimSpace(result), m.Location)
+    if err != nil {
+        return zero, false, fmt.Errorf("kdialog --calendar format error: %w", err)
+    }
+    return t, true, nil
+}
+
+func (x kdialog) pickFile(
+    m FilePicker,
+    mode rune, // (o)pen, (m)ultiple, (s)ave
+) ([]string, bool, error) {
+    dir, name, err := m.location()
+    if err != nil { return nil, false, err }
+
+    // a trailing slash opens a directory without selecting a file
+    path := strings.TrimSuffix(dir, "/") + "/"
+    if name != "" { path = filepath.Join(dir, name) }
+
+    // Qt-style filters, one per line e.g. "Text Document (*.txt *.rtf)"
+    filters := make([]string, 0, len(m.FileTypes))
+    for _, f := range m.FileTypes {
+        name, patterns := f[0], globs(f[1])
+        filters = append(filters, fmt.Sprintf("%s (%s)", name, strings.Join(patterns, " ")))
+    }
+
+    args := []string{"--title", m.Title}
+    filter := strings.Join(filters, "\n")
+
+    switch {
+        case m.Directory && (mode != 's'):
+            // kdialog can't select multiple directories
+            args = append(args, "--getexistingdirectory", dir)
+        case mode == 'o':
+            args = append(args, "--getopenfilename", path, filter)
+        case mode == 'm':
+            args = append(args, "--getopenfilename", path, filter, "--multiple", "--separate-output")
+        case mode == 's':
+            args = append(args, "--getsavefilename", path, filter)
+    }
+
+    result, ok, err := x.run(args...)
+    if (err != nil) || !ok { return nil, false, err }
+    if len(result) == 0 { return nil, false, nil }
+
+    if mode == 'm' {
+        return strings.Split(result, "\n"), true, nil
+    } else {
+        return []string{result}, true, nil
+    }
+}
+
+func (x kdialog) open(m FilePicker) (string, bool, error) {
+    if xs, ok, err := x.pickFile(m, 'o'); err != nil {
+        return "", false, fmt.Errorf("error opening file picker: %w", err)
+    } else if !ok || (len(xs) == 0) {
+        return "", false, nil
+    } else {
+        return xs[0], true, nil
+    }
+}
+
+func (x kdialog) openMultiple(m FilePicker) ([]string, bool, error) {
+    if xs, ok, err := x.pickFile(m, 'm'); err != nil {
+        return []string{}, false, fmt.Errorf("error opening file picker: %w", err)
+    } else if !ok || (len(xs) == 0) {
+        return []string{}, false, nil
+    } else {
+        return xs, true, nil
+    }
+}
+
+func (x kdialog) save(m FilePicker) (string, bool, error) {
+    if xs, ok, err := x.pickFile(m, 's'); err != nil {
+        return "", false, fmt.Errorf("error opening save file picker: %w", err)
+    } else if !ok || (len(xs) == 0) {
+        return "", false, nil
+    } else {
+        return xs[0], true, nil
+    }
+}
+
+func (x kdialog) text(m TextInput) (string, bool, error) {
+    return x.run("--title", m.Title, "--inputbox", m.Label, m.Initial)
+}
+
+func (x kdialog) password(m PasswordInput) (string, bool, error) {
+    return x.run("--title", m.Title, "--password", m.Label)
+}
+
+// pickChoice displays a list where the tag of each option is its index.
+func (x kdialog) pickChoice(m Choice, multiple bool) ([]int, bool, error) {
+    args := []string{"--title", m.Title}
+    if multiple {
+        args = append(args, "--separate-output", "--checklist", m.Label)
+    } else {
+        args = append(args, "--radiolist", m.Label)
+    }
+
+    for i, option := range m.Options {
+        selected := "off"
+        if m.selected(i, multiple) { selected = "on" }
+        args = append(args, strconv.Itoa(i), option, selected)
+    }
+
+    result, ok, err := x.run(args...)
+    if (err != nil) || !ok { return nil, false, err }
+
+    xs, err := parseIndexes(result, "", len(m.Options))
+    if err != nil { return nil, false, fmt.Errorf("kdialog list parse error: %w", err) }
+    return xs, len(xs) > 0, nil
+}
+
+func (x kdialog) choice(m Choice) (int, bool, error) {
+    xs, ok, err := x.pickChoice(m, false)
+    if (err != nil) || !ok { return 0, false, err }
+    return xs[0], true, nil
+}
+
+func (x kdialog) choiceMultiple(m Choice) ([]int, bool, error) {
+    xs, ok, err := x.pickChoice(m, true)
+    if (err != nil) || !ok { return []int{}, false, err }
+    return xs, true, nil
+}
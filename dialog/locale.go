@@ -0,0 +1,367 @@
+package dialog
+
+import (
+    "os"
+    "strings"
+    "sync"
+
+    "golang.org/x/text/language"
+    "golang.org/x/text/unicode/bidi"
+)
+
+// Locale is the text of default window titles and button labels, in one
+// language. Where a dialog is shown by a platform or program with its own
+// localised text, that text may be used instead.
+//
+// The key help shown at the bottom of a terminal dialog, and a few short
+// prompts of the terminal and whiptail dialogs, are always in English.
+type Locale struct {
+    // Default titles
+    Message      string // e.g. "Message"
+    Question     string // e.g. "Question"
+    Warning      string // e.g. "Warning"
+    Error        string // e.g. "Error"
+    Input        string // e.g. "Input"
+    Password     string // e.g. "Password"
+    Select       string // e.g. "Select"
+    Progress     string // e.g. "Progress"
+    Notification string // e.g. "Notification"
+    SelectColor  string // e.g. "Select color"
+    SelectDate   string // e.g. "Select date"
+    OpenFile     string // e.g. "Open File..."
+    OpenFiles    string // e.g. "Open Files..."
+    SaveAs       string // e.g. "Save As..."
+
+    // AllFiles is the label of the file type added to every [FilePicker].
+    AllFiles string // e.g. "All Files"
+
+    // Button labels. In a terminal dialog, the first letter of Yes and No
+    // is also a shortcut key.
+    OK     string
+    Cancel string
+    Yes    string
+    No     string
+
+    // Overwrite is a printf-style format string asking to replace an existing
+    // file, given its name, where a [FilePicker] confirms this itself.
+    Overwrite string // e.g. "A file named %q already exists. Do you want to replace it?"
+
+    // Months and Weekdays are the names of months, from January, and the
+    // short names of days of the week, from Monday, for a calendar. Weekday
+    // names are up to two characters long.
+    Months   [12]string // e.g. "January"
+    Weekdays [7]string  // e.g. "Mo"
+}
+
+// fill returns a copy of l, with any empty text replaced by the text from d.
+func (l Locale) fill(d Locale) Locale {
+    for _, x := range []struct{ dest *string; src string }{
+        {&l.Message,      d.Message},
+        {&l.Question,     d.Question},
+        {&l.Warning,      d.Warning},
+        {&l.Error,        d.Error},
+        {&l.Input,        d.Input},
+        {&l.Password,     d.Password},
+        {&l.Select,       d.Select},
+        {&l.Progress,     d.Progress},
+        {&l.Notification, d.Notification},
+        {&l.SelectColor,  d.SelectColor},
+        {&l.SelectDate,   d.SelectDate},
+        {&l.OpenFile,     d.OpenFile},
+        {&l.OpenFiles,    d.OpenFiles},
+        {&l.SaveAs,       d.SaveAs},
+        {&l.AllFiles,     d.AllFiles},
+        {&l.OK,           d.OK},
+        {&l.Cancel,       d.Cancel},
+        {&l.Yes,          d.Yes},
+        {&l.No,           d.No},
+        {&l.Overwrite,    d.Overwrite},
+    } {
+        if *x.dest == "" { *x.dest = x.src }
+    }
+    if l.Months   == [12]string{} { l.Months   = d.Months }
+    if l.Weekdays == [7]string{}  { l.Weekdays = d.Weekdays }
+    return l
+}
+
+var localeEnglish = Locale{
+    Message:      "Message",
+    Question:     "Question",
+    Warning:      "Warning",
+    Error:        "Error",
+    Input:        "Input",
+    Password:     "Password",
+    Select:       "Select",
+    Progress:     "Progress",
+    Notification: "Notification",
+    SelectColor:  "Select color",
+    SelectDate:   "Select date",
+    OpenFile:     "Open File...",
+    OpenFiles:    "Open Files...",
+    SaveAs:       "Save As...",
+    AllFiles:     "All Files",
+    OK:           "OK",
+    Cancel:       "Cancel",
+    Yes:          "Yes",
+    No:           "No",
+    Overwrite:    "A file named %q already exists. Do you want to replace it?",
+    Months:       [12]string{
+        "January", "February", "March", "April",
+        "May", "June", "July", "August",
+        "September", "October", "November", "December",
+    },
+    Weekdays:     [7]string{"Mo", "Tu", "We", "Th", "Fr", "Sa", "Su"},
+}
+
+var locales = struct {
+    mu      sync.RWMutex
+    tags    []language.Tag // in the same order as values
+    values  []Locale
+    matcher language.Matcher
+    forced  language.Tag
+}{
+    forced: language.Und,
+}
+
+func init() {
+    // English is first, as the fallback.
+    RegisterLocale(language.English, localeEnglish)
+
+    RegisterLocale(language.MustParse("cy"), Locale{
+        Message:      "Neges",
+        Question:     "Cwestiwn",
+        Warning:      "Rhybudd",
+        Error:        "Gwall",
+        Input:        "Mewnbwn",
+        Password:     "Cyfrinair",
+        Select:       "Dewis",
+        Progress:     "Cynnydd",
+        Notification: "Hysbysiad",
+        SelectColor:  "Dewis lliw",
+        SelectDate:   "Dewis dyddiad",
+        OpenFile:     "Agor Ffeil...",
+        OpenFiles:    "Agor Ffeiliau...",
+        SaveAs:       "Cadw Fel...",
+        AllFiles:     "Pob Ffeil",
+        OK:           "Iawn",
+        Cancel:       "Canslo",
+        Yes:          "Ie",
+        No:           "Na",
+        Overwrite:    "Mae ffeil o'r enw %q yn bodoli eisoes. Ydych chi am ei disodli?",
+        Months:       [12]string{
+            "Ionawr", "Chwefror", "Mawrth", "Ebrill",
+            "Mai", "Mehefin", "Gorffennaf", "Awst",
+            "Medi", "Hydref", "Tachwedd", "Rhagfyr",
+        },
+        Weekdays:     [7]string{"Ll", "Ma", "Me", "Ia", "Gw", "Sa", "Su"},
+    })
+
+    RegisterLocale(language.German, Locale{
+        Message:      "Meldung",
+        Question:     "Frage",
+        Warning:      "Warnung",
+        Error:        "Fehler",
+        Input:        "Eingabe",
+        Password:     "Passwort",
+        Select:       "Auswählen",
+        Progress:     "Fortschritt",
+        Notification: "Benachrichtigung",
+        SelectColor:  "Farbe auswählen",
+        SelectDate:   "Datum auswählen",
+        OpenFile:     "Datei öffnen...",
+        OpenFiles:    "Dateien öffnen...",
+        SaveAs:       "Speichern unter...",
+        AllFiles:     "Alle Dateien",
+        OK:           "OK",
+        Cancel:       "Abbrechen",
+        Yes:          "Ja",
+        No:           "Nein",
+        Overwrite:    "Eine Datei mit dem Namen %q existiert bereits. Möchten Sie sie ersetzen?",
+        Months:       [12]string{
+            "Januar", "Februar", "März", "April",
+            "Mai", "Juni", "Juli", "August",
+            "September", "Oktober", "November", "Dezember",
+        },
+        Weekdays:     [7]string{"Mo", "Di", "Mi", "Do", "Fr", "Sa", "So"},
+    })
+
+    RegisterLocale(language.French, Locale{
+        Message:      "Message",
+        Question:     "Question",
+        Warning:      "Avertissement",
+        Error:        "Erreur",
+        Input:        "Saisie",
+        Password:     "Mot de passe",
+        Select:       "Sélectionner",
+        Progress:     "Progression",
+        Notification: "Notification",
+        SelectColor:  "Choisir une couleur",
+        SelectDate:   "Choisir une date",
+        OpenFile:     "Ouvrir un fichier...",
+        OpenFiles:    "Ouvrir des fichiers...",
+        SaveAs:       "Enregistrer sous...",
+        AllFiles:     "Tous les fichiers",
+        OK:           "OK",
+        Cancel:       "Annuler",
+        Yes:          "Oui",
+        No:           "Non",
+        Overwrite:    "Un fichier nommé %q existe déjà. Voulez-vous le remplacer ?",
+        Months:       [12]string{
+            "janvier", "février", "mars", "avril",
+            "mai", "juin", "juillet", "août",
+            "septembre", "octobre", "novembre", "décembre",
+        },
+        Weekdays:     [7]string{"lu", "ma", "me", "je", "ve", "sa", "di"},
+    })
+
+    RegisterLocale(language.Spanish, Locale{
+        Message:      "Mensaje",
+        Question:     "Pregunta",
+        Warning:      "Advertencia",
+        Error:        "Error",
+        Input:        "Entrada",
+        Password:     "Contraseña",
+        Select:       "Seleccionar",
+        Progress:     "Progreso",
+        Notification: "Notificación",
+        SelectColor:  "Seleccionar color",
+        SelectDate:   "Seleccionar fecha",
+        OpenFile:     "Abrir archivo...",
+        OpenFiles:    "Abrir archivos...",
+        SaveAs:       "Guardar como...",
+        AllFiles:     "Todos los archivos",
+        OK:           "Aceptar",
+        Cancel:       "Cancelar",
+        Yes:          "Sí",
+        No:           "No",
+        Overwrite:    "Ya existe un archivo llamado %q. ¿Desea reemplazarlo?",
+        Months:       [12]string{
+            "enero", "febrero", "marzo", "abril",
+            "mayo", "junio", "julio", "agosto",
+            "septiembre", "octubre", "noviembre", "diciembre",
+        },
+        Weekdays:     [7]string{"lu", "ma", "mi", "ju", "vi", "sá", "do"},
+    })
+
+    RegisterLocale(language.Arabic, Locale{
+        Message:      "رسالة",
+        Question:     "سؤال",
+        Warning:      "تحذير",
+        Error:        "خطأ",
+        Input:        "إدخال",
+        Password:     "كلمة المرور",
+        Select:       "اختيار",
+        Progress:     "التقدم",
+        Notification: "إشعار",
+        SelectColor:  "اختيار لون",
+        SelectDate:   "اختيار تاريخ",
+        OpenFile:     "فتح ملف...",
+        OpenFiles:    "فتح ملفات...",
+        SaveAs:       "حفظ باسم...",
+        AllFiles:     "كل الملفات",
+        OK:           "موافق",
+        Cancel:       "إلغاء",
+        Yes:          "نعم",
+        No:           "لا",
+        Overwrite:    "يوجد ملف باسم %q بالفعل. هل تريد استبداله؟",
+        Months:       [12]string{
+            "يناير", "فبراير", "مارس", "أبريل",
+            "مايو", "يونيو", "يوليو", "أغسطس",
+            "سبتمبر", "أكتوبر", "نوفمبر", "ديسمبر",
+        },
+        Weekdays:     [7]string{"ن", "ث", "ر", "خ", "ج", "س", "ح"},
+    })
+}
+
+// RegisterLocale makes a Locale available for a language, for example to
+// add a language, or to replace the text of an existing one. Any empty text
+// defaults to English.
+//
+// This package registers a Locale for English, Welsh, German, French,
+// Spanish, and Arabic.
+func RegisterLocale(tag language.Tag, l Locale) {
+    l = l.fill(localeEnglish)
+
+    locales.mu.Lock()
+    defer locales.mu.Unlock()
+
+    for i, t := range locales.tags {
+        if t == tag {
+            locales.values[i] = l
+            return
+        }
+    }
+    locales.tags = append(locales.tags, tag)
+    locales.values = append(locales.values, l)
+    locales.matcher = language.NewMatcher(locales.tags)
+}
+
+// SetLanguage sets the language of default window titles and button labels,
+// instead of detecting it from the environment. If tag is [language.Und],
+// restores detection from the environment.
+func SetLanguage(tag language.Tag) {
+    locales.mu.Lock()
+    defer locales.mu.Unlock()
+    locales.forced = tag
+}
+
+// Language returns the language of default window titles and button labels:
+// either the language set by [SetLanguage], or else the language detected
+// from the LC_ALL, LC_MESSAGES, or LANG environment variables, in that order
+// of priority (e.g. "cy_GB.UTF-8"). If no language is detected, returns
+// English.
+//
+// Where there is no registered [Locale] for the language, the closest
+// matching Locale, or else English, is used instead.
+func Language() language.Tag {
+    locales.mu.RLock()
+    forced := locales.forced
+    locales.mu.RUnlock()
+    if forced != language.Und { return forced }
+
+    for _, name := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+        value := os.Getenv(name)
+        if value == "" { continue }
+
+        // POSIX locale names e.g. "cy_GB.UTF-8@euro"
+        value, _, _ = strings.Cut(value, ".")
+        value, _, _ = strings.Cut(value, "@")
+        if (value == "C") || (value == "POSIX") { break }
+
+        if tag, err := language.Parse(strings.ReplaceAll(value, "_", "-")); err == nil {
+            return tag
+        }
+    }
+    return language.English
+}
+
+// locale returns the Locale for the current [Language].
+func locale() Locale {
+    tag := Language()
+
+    locales.mu.RLock()
+    defer locales.mu.RUnlock()
+    _, i, _ := locales.matcher.Match(tag)
+    return locales.values[i]
+}
+
+// isRTL returns true if the text contains a character from a right-to-left
+// writing system, such as Arabic or Hebrew, or if the current [Language] is
+// written right-to-left.
+func isRTL(text string) bool {
+    for _, r := range text {
+        prop, _ := bidi.LookupRune(r)
+        switch prop.Class() {
+            case bidi.R:   return true // Strong R-to-L
+            case bidi.AL:  return true // Strong R-to-L
+            case bidi.RLO: return true // Explicit R-to-L
+        }
+    }
+
+    script, _ := Language().Script()
+    switch script.String() {
+        case "Arab", "Hebr", "Thaa", "Syrc", "Nkoo", "Adlm", "Rohg":
+            return true
+    }
+    return false
+}
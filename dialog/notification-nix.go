@@ -0,0 +1,183 @@
+//go:build (linux || unix)
+
+package dialog
+
+import (
+    "context"
+    "errors"
+    "fmt"
+    "os/exec"
+    "strconv"
+    "strings"
+    "time"
+
+    "github.com/tawesoft/golib/v2/internal/dbus"
+)
+
+// notifier implements notifications with the org.freedesktop.Notifications
+// D-Bus interface, or else with the notify-send program.
+type notifier struct {
+    address    string // session bus address, or empty
+    notifySend string // path to notify-send, or empty
+}
+
+// errNoNotifications means the bus or notification server could not be
+// reached.
+var errNoNotifications = errors.New("no notification server available")
+
+const (
+    notificationsBus       = "org.freedesktop.Notifications"
+    notificationsPath      = dbus.ObjectPath("/org/freedesktop/Notifications")
+    notificationsInterface = "org.freedesktop.Notifications"
+)
+
+// notificationLifetime is how long to wait for the user to pick an action
+// before closing the notification regardless, because some notification
+// servers keep notifications in a history list indefinitely.
+var notificationLifetime = 60 * time.Second
+
+func (x notifier) iconString(i IconType) string {
+    switch i {
+        case IconInfo: return "dialog-information"
+        case IconWarning: return "dialog-warning"
+        case IconError: return "dialog-error"
+        default: return "dialog-information"
+    }
+}
+
+// urgencyLevel returns the urgency as defined by the Desktop Notifications
+// Specification: 0 for low, 1 for normal, or 2 for critical.
+func (x notifier) urgencyLevel(u Urgency) byte {
+    switch u {
+        case UrgencyLow:      return 0
+        case UrgencyCritical: return 2
+        default:              return 1
+    }
+}
+
+func (x notifier) notify(n Notification, body string) (<-chan int, error) {
+    if x.address != "" {
+        c, err := x.bus(n, body)
+        if (err == nil) || !errors.Is(err, errNoNotifications) || (x.notifySend == "") {
+            return c, err
+        }
+    }
+    if x.notifySend != "" {
+        return x.exec(n, body)
+    }
+    return closedAction(), errNoNotifications
+}
+
+// bus sends a notification over D-Bus. If the notification has actions, a
+// connection stays open, in the background, until the notification is
+// closed, or until its lifetime ends and it is closed by this function.
+func (x notifier) bus(n Notification, body string) (<-chan int, error) {
+    ctx, cancel := context.WithTimeout(context.Background(), 5 * time.Second)
+    defer cancel()
+
+    conn, err := dbus.Dial(ctx, x.address)
+    if err != nil { return closedAction(), fmt.Errorf("%w: %v", errNoNotifications, err) }
+
+    keep := false
+    defer func() { if !keep { conn.Close() } }()
+
+    // subscribe before sending, to avoid a race with a quick response
+    signals := make(chan *dbus.Message, 8)
+    if len(n.Actions) > 0 {
+        remove := conn.Handle(func(s *dbus.Message) {
+            if s.Interface != notificationsInterface { return }
+            if (s.Member != "ActionInvoked") && (s.Member != "NotificationClosed") { return }
+            select {
+                case signals <- s:
+                default:
+            }
+        })
+        defer func() { if !keep { remove() } }()
+
+        if err := conn.AddMatch(ctx, fmt.Sprintf(
+            "type='signal',interface='%s',path='%s'",
+            notificationsInterface, notificationsPath)); err != nil {
+            return closedAction(), fmt.Errorf("%w: %v", errNoNotifications, err)
+        }
+    }
+
+    // actions are pairs of (key, label), where the key is the index
+    actions := make([]string, 0, 2 * len(n.Actions))
+    for i, label := range n.Actions {
+        actions = append(actions, strconv.Itoa(i), label)
+    }
+
+    hints := map[string]dbus.Variant{
+        "urgency": {Value: x.urgencyLevel(n.Urgency)},
+    }
+
+    reply, err := conn.Call(ctx, notificationsBus, notificationsPath, notificationsInterface, "Notify",
+        n.AppName, uint32(0), x.iconString(n.Icon), n.Title, body, actions, hints, int32(-1))
+    if err != nil {
+        var e *dbus.Error
+        if errors.As(err, &e) && strings.HasPrefix(e.Name, "org.freedesktop.DBus.Error.") {
+            return closedAction(), fmt.Errorf("%w: %v", errNoNotifications, err)
+        }
+        return closedAction(), fmt.Errorf("notification error: %w", err)
+    }
+    if len(n.Actions) == 0 { return closedAction(), nil }
+
+    var id uint32
+    if len(reply) > 0 { id, _ = reply[0].(uint32) }
+
+    keep = true
+    result := make(chan int, 1)
+    go func() {
+        defer close(result)
+        defer conn.Close()
+
+        expired := time.After(notificationLifetime)
+        for {
+            select {
+                case s := <-signals:
+                    if len(s.Body) < 2 { continue }
+                    if sid, _ := s.Body[0].(uint32); sid != id { continue }
+                    if s.Member == "NotificationClosed" { return }
+
+                    key, _ := s.Body[1].(string)
+                    if i, err := strconv.Atoi(key); (err == nil) && (i >= 0) && (i < len(n.Actions)) {
+                        result <- i
+                        return
+                    }
+                case <-expired:
+                    ctx, cancel := context.WithTimeout(context.Background(), 5 * time.Second)
+                    conn.Call(ctx, notificationsBus, notificationsPath, notificationsInterface,
+                        "CloseNotification", id)
+                    cancel()
+                    return
+                case <-conn.Done():
+                    return
+            }
+        }
+    }()
+
+    return result, nil
+}
+
+// exec sends a notification with notify-send, which doesn't support actions.
+func (x notifier) exec(n Notification, body string) (<-chan int, error) {
+    urgency := "normal"
+    switch n.Urgency {
+        case UrgencyLow:      urgency = "low"
+        case UrgencyCritical: urgency = "critical"
+    }
+
+    cmd := exec.Command(x.notifySend,
+        "--urgency",  urgency,
+        "--icon",     x.iconString(n.Icon),
+        "--app-name", n.AppName,
+        "--",
+        n.Title,
+        body,
+    )
+
+    if err := cmd.Run(); err != nil {
+        return closedAction(), fmt.Errorf("notify-send error: %w", err)
+    }
+    return closedAction(), nil
+}
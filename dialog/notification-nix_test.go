@@ -0,0 +1,119 @@
+//go:build (linux || unix)
+
+package dialog
+
+import (
+    "testing"
+    "time"
+
+    "github.com/stretchr/testify/assert"
+    "github.com/tawesoft/golib/v2/internal/dbus"
+    "github.com/tawesoft/golib/v2/internal/dbus/dbustest"
+)
+
+func TestNotifier(t *testing.T) {
+    var body []any
+    bus, err := dbustest.New(func(call *dbus.Message) ([]any, []*dbus.Message, error) {
+        if (call.Interface != notificationsInterface) || (call.Member != "Notify") {
+            return nil, nil, &dbus.Error{Name: "org.freedesktop.DBus.Error.UnknownMethod"}
+        }
+        body = call.Body
+
+        // another notification's action, then the user picks the second
+        // action
+        signal := func(member string, id uint32, arg any) *dbus.Message {
+            return &dbus.Message{
+                Path:      notificationsPath,
+                Interface: notificationsInterface,
+                Member:    member,
+                Body:      []any{id, arg},
+            }
+        }
+        return []any{uint32(7)}, []*dbus.Message{
+            signal("ActionInvoked", 6, "0"),
+            signal("ActionInvoked", 7, "1"),
+            signal("NotificationClosed", 7, uint32(2)),
+        }, nil
+    })
+    if !assert.Nil(t, err) { return }
+    defer bus.Close()
+
+    x := notifier{address: bus.Address}
+    n := Notification{
+        Title:   "Sync complete",
+        Icon:    IconWarning,
+        Urgency: UrgencyCritical,
+        Actions: []string{"Dismiss", "Open folder"},
+        AppName: "example",
+    }
+
+    c, err := x.notify(n, "3 files changed")
+    assert.Nil(t, err)
+    assert.Equal(t, 1, <-c)
+    _, open := <-c
+    assert.False(t, open)
+
+    assert.Equal(t, []any{
+        "example",
+        uint32(0),
+        "dialog-warning",
+        "Sync complete",
+        "3 files changed",
+        []any{"0", "Dismiss", "1", "Open folder"},
+        map[any]any{"urgency": dbus.Variant{Value: byte(2)}},
+        int32(-1),
+    }, body)
+
+    // without actions, the channel is closed immediately
+    n.Actions = nil
+    c, err = x.notify(n, "3 files changed")
+    assert.Nil(t, err)
+    _, open = <-c
+    assert.False(t, open)
+}
+
+func TestNotifier_none(t *testing.T) {
+    bus, err := dbustest.New(func(call *dbus.Message) ([]any, []*dbus.Message, error) {
+        return nil, nil, &dbus.Error{Name: "org.freedesktop.DBus.Error.ServiceUnknown"}
+    })
+    if !assert.Nil(t, err) { return }
+    defer bus.Close()
+
+    _, err = notifier{address: bus.Address}.notify(Notification{}, "")
+    assert.ErrorIs(t, err, errNoNotifications)
+}
+
+func TestNotifier_lifetime(t *testing.T) {
+    closed := make(chan []any, 1)
+    bus, err := dbustest.New(func(call *dbus.Message) ([]any, []*dbus.Message, error) {
+        switch call.Member {
+            case "Notify":
+                return []any{uint32(9)}, nil, nil
+            case "CloseNotification":
+                closed <- call.Body
+                return nil, nil, nil
+        }
+        return nil, nil, &dbus.Error{Name: "org.freedesktop.DBus.Error.UnknownMethod"}
+    })
+    if !assert.Nil(t, err) { return }
+    defer bus.Close()
+
+    lifetime := notificationLifetime
+    notificationLifetime = 50 * time.Millisecond
+    defer func() { notificationLifetime = lifetime }()
+
+    // the notification is kept, e.g. in a history list, but never used
+    x := notifier{address: bus.Address}
+    c, err := x.notify(Notification{Actions: []string{"Open"}}, "")
+    assert.Nil(t, err)
+
+    _, open := <-c
+    assert.False(t, open)
+    assert.Equal(t, []any{uint32(9)}, <-closed)
+
+    // the connection is closed
+    for i := 0; (i < 100) && (bus.Conns() > 0); i++ {
+        time.Sleep(time.Millisecond)
+    }
+    assert.Equal(t, 0, bus.Conns())
+}
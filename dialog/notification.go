@@ -0,0 +1,103 @@
+package dialog
+
+import (
+    "os"
+    "path/filepath"
+)
+
+// Urgency is the urgency level of a [Notification].
+type Urgency int
+const (
+    UrgencyNormal Urgency = iota
+    UrgencyLow
+    UrgencyCritical
+)
+
+// Notify is a convenience function to show a desktop notification with a
+// title and a body of text. The body string can be a printf-style format
+// string for an optional sequence of additional arguments of any type. Use
+// the [Notification.Send] method on a configured [Notification] for more
+// options.
+//
+// Unlike a [Message], a notification does not block. Where not supported,
+// does nothing.
+func Notify(title string, body string, args ... interface{}) error {
+    _, err := Notification{
+        Title:  title,
+        Format: body,
+        Args:   args,
+    }.Send()
+    return err
+}
+
+// Notification is a non-blocking desktop notification, for example to tell
+// the user that a task running in the background has finished.
+type Notification struct {
+    // Title is a short summary of the notification. If omitted, defaults to
+    // a localised "Notification" (see [Language]).
+    Title string
+
+    // Format is a printf-style format string for the body of the
+    // notification (may be empty).
+    Format string
+
+    // Args are printf-style arguments
+    Args []interface{}
+
+    // Icon is a IconInfo, IconWarning, or IconError and is displayed when
+    // possible with the notification.
+    Icon IconType
+
+    // Urgency is a hint of the urgency of the notification. For example,
+    // where supported, a critical notification may stay visible until
+    // dismissed, and a low urgency notification may be shown without a
+    // sound.
+    Urgency Urgency
+
+    // Actions are the labels of optional buttons (may be empty) shown with
+    // the notification, such as "Open folder".
+    //
+    // Where actions are not supported, they are not shown. On Windows,
+    // clicking the notification chooses the first action.
+    Actions []string
+
+    // AppName is the name of the application sending the notification. If
+    // omitted, defaults to the name of the program.
+    AppName string
+}
+
+// clear returns a Notification with its format and args fields cleared, and
+// its body formatted with its args, in the manner of [Message].
+func (n Notification) clear() (Notification, string) {
+    m, body := Message{Format: n.Format, Args: n.Args}.clear()
+    n.Format, n.Args = m.Format, m.Args
+    return n, body
+}
+
+// Send shows the notification, and returns without blocking.
+//
+// The returned channel receives the index of an action chosen by the user,
+// if any, and is then closed. It is closed without receiving a value if the
+// notification is dismissed or expires, if it has no actions, or if actions
+// are not supported. It is safe to ignore the channel. So that resources are
+// not kept indefinitely, a notification with actions is closed after about a
+// minute if the user doesn't pick an action.
+//
+// Where not supported, does nothing, and returns a closed channel (see
+// [Supported]).
+func (n Notification) Send() (<-chan int, error) {
+    n, body := n.clear()
+    if n.Title == "" { n.Title = locale().Notification }
+    if n.AppName == "" { n.AppName = filepath.Base(os.Args[0]) }
+    b, err := Current()
+    if err != nil { return closedAction(), err }
+    return b.Notify(n, body)
+}
+
+// closedAction returns a closed channel, for a notification without any
+// chosen action.
+func closedAction() <-chan int {
+    c := make(chan int)
+    close(c)
+    return c
+}
@@ -0,0 +1,247 @@
+//go:build (linux || unix)
+
+package dialog
+
+import (
+    "context"
+    "crypto/rand"
+    "encoding/hex"
+    "errors"
+    "fmt"
+    "net/url"
+    "strings"
+    "time"
+
+    "github.com/tawesoft/golib/v2/internal/dbus"
+)
+
+// portal implements file pickers with the XDG desktop portal
+// org.freedesktop.portal.FileChooser D-Bus interface, which works from inside
+// a sandbox such as Flatpak or Snap.
+//
+// Where there is no portal, each dialog falls back to another
+// implementation.
+type portal struct {
+    address  string
+    fallback funs
+}
+
+// errNoPortal means the bus or portal could not be reached.
+var errNoPortal = errors.New("no desktop portal available")
+
+// portalBusTimeout is the timeout for connecting to the bus, and for each
+// call to the bus itself.
+const portalBusTimeout = 5 * time.Second
+
+// portalCallTimeout is the timeout for a portal to reply to a method call
+// (but not for the user to respond to the dialog). This is longer, because
+// the bus may need to start the portal first, and is the usual D-Bus default.
+var portalCallTimeout = 25 * time.Second
+
+const (
+    portalBus       = "org.freedesktop.portal.Desktop"
+    portalPath      = dbus.ObjectPath("/org/freedesktop/portal/desktop")
+    portalInterface = "org.freedesktop.portal.FileChooser"
+    portalRequest   = "org.freedesktop.portal.Request"
+)
+
+// portalFilter is a filter in the form (sa(us)).
+type portalFilter struct {
+    Name     string
+    Patterns []portalPattern
+}
+
+// portalPattern is a pattern in the form (us), where Kind is 0 for a glob
+// pattern, or 1 for a MIME type.
+type portalPattern struct {
+    Kind    uint32
+    Pattern string
+}
+
+func (x portal) options(m FilePicker, mode rune) map[string]dbus.Variant {
+    options := map[string]dbus.Variant{
+        "modal": {Value: true},
+    }
+
+    dir, name, err := m.location()
+    if err == nil {
+        options["current_folder"] = dbus.Variant{Value: []byte(dir + "\x00")}
+    }
+
+    if mode == 'm' {
+        options["multiple"] = dbus.Variant{Value: true}
+    }
+
+    if m.Directory && (mode != 's') {
+        options["directory"] = dbus.Variant{Value: true}
+        return options
+    }
+
+    filters := make([]portalFilter, 0, len(m.FileTypes))
+    for _, f := range m.FileTypes {
+        name, patterns := f[0], globs(f[1])
+        pf := portalFilter{Name: name}
+        for _, p := range patterns {
+            pf.Patterns = append(pf.Patterns, portalPattern{0, p})
+        }
+        filters = append(filters, pf)
+    }
+    if len(filters) > 0 {
+        options["filters"] = dbus.Variant{Value: filters}
+        if (m.DefaultFileType >= 0) && (m.DefaultFileType < len(filters)) {
+            options["current_filter"] = dbus.Variant{Value: filters[m.DefaultFileType]}
+        }
+    }
+
+    if (mode == 's') && (name != "") {
+        options["current_name"] = dbus.Variant{Value: name}
+    }
+
+    return options
+}
+
+// request calls a FileChooser method and waits for the response.
+func (x portal) request(m FilePicker, mode rune) ([]string, bool, error) {
+    ctx, cancel := context.WithTimeout(context.Background(), portalBusTimeout)
+    conn, err := dbus.Dial(ctx, x.address)
+    cancel()
+    if err != nil { return nil, false, fmt.Errorf("%w: %v", errNoPortal, err) }
+    defer conn.Close()
+
+    token, err := portalToken()
+    if err != nil { return nil, false, err }
+
+    // The response is a signal on a request object. Subscribe before
+    // calling the method, to avoid a race with a quick response.
+    sender := strings.ReplaceAll(strings.TrimPrefix(conn.Name(), ":"), ".", "_")
+    expected := dbus.ObjectPath("/org/freedesktop/portal/desktop/request/"+sender+"/"+token)
+
+    // Responses are filtered by path only once the request object path is
+    // known for certain, after the method call returns.
+    responses := make(chan *dbus.Message, 8)
+    remove := conn.Handle(func(s *dbus.Message) {
+        if (s.Interface != portalRequest) || (s.Member != "Response") { return }
+        select {
+            case responses <- s:
+            default:
+        }
+    })
+    defer remove()
+
+    match := func(path dbus.ObjectPath) error {
+        ctx, cancel := context.WithTimeout(context.Background(), portalBusTimeout)
+        defer cancel()
+        return conn.AddMatch(ctx, fmt.Sprintf(
+            "type='signal',interface='%s',member='Response',path='%s'",
+            portalRequest, path))
+    }
+    if err := match(expected); err != nil {
+        return nil, false, fmt.Errorf("%w: %v", errNoPortal, err)
+    }
+
+    method := "OpenFile"
+    if mode == 's' { method = "SaveFile" }
+
+    options := x.options(m, mode)
+    options["handle_token"] = dbus.Variant{Value: token}
+
+    ctx, cancel = context.WithTimeout(context.Background(), portalCallTimeout)
+    reply, err := conn.Call(ctx, portalBus, portalPath, portalInterface, method,
+        "", m.Title, options)
+    cancel()
+    if err != nil {
+        // no portal, or a portal that failed to start in time
+        var e *dbus.Error
+        if errors.As(err, &e) && strings.HasPrefix(e.Name, "org.freedesktop.DBus.Error.") {
+            return nil, false, fmt.Errorf("%w: %v", errNoPortal, err)
+        } else if errors.Is(err, context.DeadlineExceeded) {
+            return nil, false, fmt.Errorf("%w: %v", errNoPortal, err)
+        }
+        return nil, false, fmt.Errorf("portal error: %w", err)
+    }
+
+    // older portals may return a different request object path
+    handle := expected
+    if len(reply) > 0 {
+        if h, ok := reply[0].(dbus.ObjectPath); ok && (h != expected) {
+            handle = h
+            if err := match(handle); err != nil {
+                return nil, false, fmt.Errorf("portal error: %w", err)
+            }
+        }
+    }
+
+    // the user may take as long as they like
+    var response *dbus.Message
+    for response == nil {
+        select {
+            case r := <-responses:
+                if r.Path == handle { response = r }
+            case <-conn.Done():
+                return nil, false, errors.New("portal error: connection closed")
+        }
+    }
+
+    if len(response.Body) < 2 {
+        return nil, false, errors.New("portal error: invalid response")
+    }
+    code, _ := response.Body[0].(uint32)
+    if code != 0 { return nil, false, nil } // cancelled, or otherwise ended
+
+    results, _ := response.Body[1].(map[any]any)
+    uris, _ := results["uris"].(dbus.Variant)
+    list, _ := uris.Value.([]any)
+
+    files := make([]string, 0, len(list))
+    for _, u := range list {
+        s, _ := u.(string)
+        parsed, err := url.Parse(s)
+        if (err != nil) || (parsed.Scheme != "file") {
+            return nil, false, fmt.Errorf("portal error: unsupported URI %q", s)
+        }
+        files = append(files, parsed.Path)
+    }
+
+    return files, len(files) > 0, nil
+}
+
+func portalToken() (string, error) {
+    var buf [8]byte
+    if _, err := rand.Read(buf[:]); err != nil {
+        return "", fmt.Errorf("error generating portal token: %w", err)
+    }
+    return "golib_"+hex.EncodeToString(buf[:]), nil
+}
+
+func (x portal) open(m FilePicker) (string, bool, error) {
+    xs, ok, err := x.request(m, 'o')
+    if errors.Is(err, errNoPortal) { return x.fallback.FileOpen(m) }
+    if err != nil {
+        return "", false, fmt.Errorf("error opening file picker: %w", err)
+    } else if !ok {
+        return "", false, nil
+    }
+    return xs[0], true, nil
+}
+
+func (x portal) openMultiple(m FilePicker) ([]string, bool, error) {
+    xs, ok, err := x.request(m, 'm')
+    if errors.Is(err, errNoPortal) { return x.fallback.FileOpenMultiple(m) }
+    if err != nil {
+        return []string{}, false, fmt.Errorf("error opening file picker: %w", err)
+    } else if !ok {
+        return []string{}, false, nil
+    }
+    return xs, true, nil
+}
+
+func (x portal) save(m FilePicker) (string, bool, error) {
+    xs, ok, err := x.request(m, 's')
+    if errors.Is(err, errNoPortal) { return x.fallback.FileSave(m) }
+    if err != nil {
+        return "", false, fmt.Errorf("error opening save file picker: %w", err)
+    } else if !ok {
+        return "", false, nil
+    }
+    return xs[0], true, nil
+}
@@ -0,0 +1,126 @@
+//go:build (linux || unix)
+
+package dialog
+
+import (
+    "strings"
+    "testing"
+    "time"
+
+    "github.com/stretchr/testify/assert"
+    "github.com/tawesoft/golib/v2/internal/dbus"
+    "github.com/tawesoft/golib/v2/internal/dbus/dbustest"
+)
+
+func TestPortal(t *testing.T) {
+    var options map[any]any
+    bus, err := dbustest.New(func(call *dbus.Message) ([]any, []*dbus.Message, error) {
+        if (call.Interface != portalInterface) || (len(call.Body) != 3) {
+            return nil, nil, &dbus.Error{Name: "org.freedesktop.DBus.Error.UnknownMethod"}
+        }
+
+        options = call.Body[2].(map[any]any)
+        token := options["handle_token"].(dbus.Variant).Value.(string)
+        sender := strings.ReplaceAll(strings.TrimPrefix(call.Sender, ":"), ".", "_")
+        handle := dbus.ObjectPath("/org/freedesktop/portal/desktop/request/"+sender+"/"+token)
+
+        var uris []string
+        if call.Member == "SaveFile" {
+            uris = []string{"file:///home/example/My%20File.txt"}
+        } else {
+            uris = []string{"file:///a.txt", "file:///b.txt"}
+        }
+
+        return []any{handle}, []*dbus.Message{{
+            Path:      handle,
+            Interface: portalRequest,
+            Member:    "Response",
+            Body:      []any{uint32(0), map[string]dbus.Variant{"uris": {Value: uris}}},
+        }}, nil
+    })
+    if !assert.Nil(t, err) { return }
+    defer bus.Close()
+
+    x := portal{address: bus.Address}
+    m := FilePicker{
+        Title:     "Pick",
+        Path:      "/home/example/draft.txt",
+        FileTypes: [][2]string{{"Text", "*.txt *.md"}, {"All Files", "*.*"}},
+    }
+
+    files, ok, err := x.openMultiple(m)
+    assert.Nil(t, err)
+    assert.True(t, ok)
+    assert.Equal(t, []string{"/a.txt", "/b.txt"}, files)
+    assert.Equal(t, dbus.Variant{Value: true}, options["multiple"])
+    assert.Equal(t, dbus.Variant{Value: []byte("/home/example\x00")}, options["current_folder"])
+    assert.Equal(t, dbus.Variant{Value: []any{"Text", []any{
+        []any{uint32(0), "*.txt"},
+        []any{uint32(0), "*.md"},
+    }}}, options["current_filter"])
+
+    file, ok, err := x.save(m)
+    assert.Nil(t, err)
+    assert.True(t, ok)
+    assert.Equal(t, "/home/example/My File.txt", file)
+    assert.Equal(t, dbus.Variant{Value: "draft.txt"}, options["current_name"])
+
+    m.Dir, m.FileName, m.Directory = "/srv", "notes.txt", true
+    _, ok, err = x.open(m)
+    assert.Nil(t, err)
+    assert.True(t, ok)
+    assert.Equal(t, dbus.Variant{Value: true}, options["directory"])
+    assert.Equal(t, dbus.Variant{Value: []byte("/srv\x00")}, options["current_folder"])
+    assert.Nil(t, options["filters"])
+
+    _, ok, err = x.save(m)
+    assert.Nil(t, err)
+    assert.True(t, ok)
+    assert.Nil(t, options["directory"])
+    assert.Equal(t, dbus.Variant{Value: "notes.txt"}, options["current_name"])
+}
+
+func TestPortal_fallback(t *testing.T) {
+    bus, err := dbustest.New(func(call *dbus.Message) ([]any, []*dbus.Message, error) {
+        return nil, nil, &dbus.Error{Name: "org.freedesktop.DBus.Error.ServiceUnknown"}
+    })
+    if !assert.Nil(t, err) { return }
+    defer bus.Close()
+
+    fallback := funs{
+        filePickOpen: func(m FilePicker) (string, bool, error) {
+            return "fallback", true, nil
+        },
+    }
+
+    for _, address := range []string{bus.Address, "unix:path=/does/not/exist"} {
+        x := portal{address: address, fallback: fallback}
+        file, ok, err := x.open(FilePicker{})
+        assert.Nil(t, err)
+        assert.True(t, ok)
+        assert.Equal(t, "fallback", file)
+    }
+}
+
+func TestPortal_timeout(t *testing.T) {
+    bus, err := dbustest.New(func(call *dbus.Message) ([]any, []*dbus.Message, error) {
+        time.Sleep(200 * time.Millisecond) // a portal that is slow to start
+        return nil, nil, &dbus.Error{Name: "org.freedesktop.portal.Error.Failed"}
+    })
+    if !assert.Nil(t, err) { return }
+    defer bus.Close()
+
+    timeout := portalCallTimeout
+    portalCallTimeout = 50 * time.Millisecond
+    defer func() { portalCallTimeout = timeout }()
+
+    x := portal{address: bus.Address, fallback: funs{
+        filePickSave: func(m FilePicker) (string, bool, error) {
+            return "fallback", true, nil
+        },
+    }}
+    file, ok, err := x.save(FilePicker{})
+    assert.Nil(t, err)
+    assert.True(t, ok)
+    assert.Equal(t, "fallback", file)
+}
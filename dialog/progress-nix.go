@@ -0,0 +1,107 @@
+//go:build (linux || unix)
+
+package dialog
+
+import (
+    "errors"
+    "fmt"
+    "io"
+    "os/exec"
+    "strings"
+    "sync"
+    "syscall"
+)
+
+// pipeProgress is a ProgressUpdater for a program that reads updates from a
+// pipe, such as "zenity --progress" from its standard input.
+type pipeProgress struct {
+    name      string // e.g. "zenity", for errors
+    cmd       *exec.Cmd
+    w         io.WriteCloser
+
+    // format returns the line(s) to write, given the current state, after
+    // either the percentage or the text changes.
+    formatPercent func(percent int, text string) string
+    formatText    func(percent int, text string) string
+
+    mu        sync.Mutex
+    percent   int
+    text      string
+    closed    bool
+    exited    chan struct{}
+    cancelled chan struct{}
+}
+
+// start starts the program. If the program exits with a non-zero exit status
+// before Close is called, the user has cancelled. If the program exits
+// successfully by itself, for example if the user dismisses a completed
+// progress dialog, it hasn't been cancelled.
+func (p *pipeProgress) start() error {
+    p.exited = make(chan struct{})
+    p.cancelled = make(chan struct{})
+
+    if err := p.cmd.Start(); err != nil {
+        p.w.Close()
+        return fmt.Errorf("%s error: %w", p.name, err)
+    }
+
+    go func() {
+        err := p.cmd.Wait()
+
+        p.mu.Lock()
+        if (err != nil) && !p.closed { close(p.cancelled) }
+        p.mu.Unlock()
+
+        close(p.exited)
+    }()
+
+    return nil
+}
+
+func (p *pipeProgress) write(line string) error {
+    select {
+        case <-p.exited: return nil
+        default:
+    }
+    if _, err := io.WriteString(p.w, line); errors.Is(err, syscall.EPIPE) {
+        return nil // exited, but not yet noticed
+    } else if err != nil {
+        return fmt.Errorf("%s error: %w", p.name, err)
+    }
+    return nil
+}
+
+func (p *pipeProgress) SetPercent(percent int) error {
+    p.mu.Lock()
+    defer p.mu.Unlock()
+    if p.closed { return nil }
+    p.percent = percent
+    return p.write(p.formatPercent(p.percent, p.text))
+}
+
+func (p *pipeProgress) SetText(text string) error {
+    p.mu.Lock()
+    defer p.mu.Unlock()
+    if p.closed { return nil }
+    p.text = strings.ReplaceAll(text, "\n", " ")
+    return p.write(p.formatText(p.percent, p.text))
+}
+
+func (p *pipeProgress) Close() error {
+    p.mu.Lock()
+    p.closed = true
+    p.w.Close()
+    p.mu.Unlock()
+
+    select {
+        case <-p.exited:
+        default:
+            p.cmd.Process.Kill()
+            <-p.exited
+    }
+    return nil
+}
+
+func (p *pipeProgress) Cancelled() <-chan struct{} {
+    return p.cancelled
+}
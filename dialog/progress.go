@@ -0,0 +1,142 @@
+package dialog
+
+import (
+    "context"
+    "errors"
+)
+
+// ErrCancelled is the cause (see [context.Cause]) of the cancellation of a
+// [ProgressHandle] context when the user cancels a progress dialog.
+var ErrCancelled = errors.New("dialog: cancelled by user")
+
+// Progress is a dialog that shows the progress of a long-running task, with
+// an option for the user to cancel it.
+type Progress struct {
+    // Title is the progress dialog window title (may be empty). If omitted,
+    // defaults to a localised "Progress" (see [Language]).
+    Title string
+
+    // Text is the initial text describing the task (may be empty). It may be
+    // changed later with [ProgressHandle.SetText].
+    Text string
+
+    // Pulsate, if true, is a hint that the progress of the task is unknown.
+    // Where supported, this shows an animation instead of a percentage.
+    Pulsate bool
+
+    // NoCancel, if true, is a hint that the task can't be cancelled, so that
+    // no cancel button is shown. Where not supported, the cancel button is
+    // shown anyway.
+    NoCancel bool
+}
+
+// ProgressUpdater updates a progress dialog shown by a [Backend].
+//
+// SetPercent and SetText may be called concurrently with, or after, Close,
+// in which case they should do nothing.
+//
+// Most users will not need to implement a ProgressUpdater, except as part of
+// a Backend. Use the [ProgressHandle] returned by [Progress.Start] instead.
+type ProgressUpdater interface {
+    // SetPercent sets the progress, from 0 to 100 inclusive.
+    SetPercent(percent int) error
+
+    // SetText sets the text describing the task.
+    SetText(text string) error
+
+    // Close closes the dialog. It is called exactly once.
+    Close() error
+
+    // Cancelled returns a channel that is closed if the user cancels the
+    // dialog (or closes the window) before Close is called.
+    Cancelled() <-chan struct{}
+}
+
+// ProgressHandle controls a progress dialog shown by [Progress.Start].
+// Its methods are safe for concurrent use.
+type ProgressHandle struct {
+    u      ProgressUpdater
+    ctx    context.Context
+    cancel context.CancelCauseFunc
+    closed chan struct{}
+    err    error
+}
+
+// Start displays the progress dialog, and returns without blocking. The
+// dialog stays open until [ProgressHandle.Done] is called, or until the
+// context is cancelled.
+//
+// Where not supported, returns a handle that does nothing, and that is never
+// cancelled by the user (see [Supported]).
+func (m Progress) Start(ctx context.Context) (*ProgressHandle, error) {
+    if m.Title == "" { m.Title = locale().Progress }
+    b, err := Current()
+    if err != nil { return nil, err }
+
+    u, err := b.ProgressStart(m)
+    if err != nil { return nil, err }
+
+    ctx, cancel := context.WithCancelCause(ctx)
+    p := &ProgressHandle{
+        u:      u,
+        ctx:    ctx,
+        cancel: cancel,
+        closed: make(chan struct{}),
+    }
+
+    go func() {
+        select {
+            case <-u.Cancelled():
+                cancel(ErrCancelled)
+            case <-ctx.Done():
+        }
+        p.err = u.Close()
+        close(p.closed)
+    }()
+
+    return p, nil
+}
+
+// Context returns a context that is cancelled when the user cancels the
+// dialog, with the cause [ErrCancelled], or when the parent context given to
+// [Progress.Start] is cancelled, or after [ProgressHandle.Done] is called.
+func (p *ProgressHandle) Context() context.Context {
+    return p.ctx
+}
+
+// Cancelled returns a channel that is closed if the user cancels the dialog.
+// Unlike [ProgressHandle.Context], it is not closed by Done.
+func (p *ProgressHandle) Cancelled() <-chan struct{} {
+    return p.u.Cancelled()
+}
+
+// SetPercent sets the progress, from 0 to 100 inclusive. Values out of range
+// are clamped.
+func (p *ProgressHandle) SetPercent(percent int) error {
+    if p.ctx.Err() != nil { return nil }
+    if percent < 0 { percent = 0 }
+    if percent > 100 { percent = 100 }
+    return p.u.SetPercent(percent)
+}
+
+// SetText sets the text describing the task.
+func (p *ProgressHandle) SetText(text string) error {
+    if p.ctx.Err() != nil { return nil }
+    return p.u.SetText(text)
+}
+
+// Done closes the dialog, and blocks until it is closed. It may be called
+// more than once.
+func (p *ProgressHandle) Done() error {
+    p.cancel(nil)
+    <-p.closed
+    return p.err
+}
+
+// noProgress is a ProgressUpdater that does nothing.
+type noProgress struct{}
+
+func (noProgress) SetPercent(int) error       { return nil }
+func (noProgress) SetText(string) error       { return nil }
+func (noProgress) Close() error               { return nil }
+func (noProgress) Cancelled() <-chan struct{} { return nil }
@@ -0,0 +1,90 @@
+package dialog
+
+// AskButtons is a convenience function to display a message with custom
+// buttons, such as "Save", "Don't Save", and "Cancel". The message string
+// can be a printf-style format string for an optional sequence of additional
+// arguments of any type. Use the [Question.Ask] method on a configured
+// [Question] for more options.
+//
+// It blocks until a button is picked, then returns its index, or -1 if the
+// dialog is closed without picking a button. Where not supported,
+// immediately returns (0, nil) without blocking.
+func AskButtons(buttons []string, message string, args ... interface{}) (int, error) {
+    i, _, err := Question{
+        Format:  message,
+        Args:    args,
+        Buttons: buttons,
+    }.Ask()
+    return i, err
+}
+
+// Question is a message box with custom buttons, such as "Save", "Don't
+// Save", and "Cancel", and an optional checkbox, such as "Don't ask again".
+type Question struct {
+    // Title is the message box window title (may be empty). If omitted,
+    // defaults to a localised "Question" (see [Language]).
+    Title string
+
+    // Format is a printf-style format string. This is word-wrapped for you.
+    Format string
+
+    // Args are printf-style arguments
+    Args []interface{}
+
+    // Icon is a IconInfo, IconWarning, or IconError and is displayed when
+    // possible on the message box.
+    Icon IconType
+
+    // Buttons are the labels of the buttons, in order. If empty, defaults to
+    // a single localised "OK" button.
+    Buttons []string
+
+    // Default is the index of the button picked by pressing Enter, where
+    // possible, and otherwise focused first. An index out of range means the
+    // first button.
+    Default int
+
+    // Checkbox is the label of an optional checkbox shown with the message
+    // (may be empty, for no checkbox) e.g. "Don't ask again". Where not
+    // supported, the checkbox is not shown.
+    Checkbox string
+}
+
+// clear returns a Question with its format and args fields cleared, and its
+// message formatted with its args, in the manner of [Message], and with
+// defaults set (and its own copy of the buttons slice).
+func (m Question) clear() (Question, string) {
+    n, message := Message{Format: m.Format, Args: m.Args}.clear()
+    m.Format, m.Args = n.Format, n.Args
+
+    if m.Title == "" { m.Title = locale().Question }
+    if len(m.Buttons) == 0 {
+        m.Buttons = []string{locale().OK}
+    } else {
+        m.Buttons = append([]string(nil), m.Buttons...)
+    }
+    if (m.Default < 0) || (m.Default >= len(m.Buttons)) { m.Default = 0 }
+    return m, message
+}
+
+// Ask displays the message with its buttons. It blocks until a button is
+// picked, then returns its index, or -1 if the dialog is closed without
+// picking a button (for example, with the window's close button), and true
+// if the checkbox, if any, was checked.
+//
+// Where not supported, immediately returns (Default, false, nil) without
+// blocking (see [Supported]).
+func (m Question) Ask() (int, bool, error) {
+    m, message := m.clear()
+    b, err := Current()
+    if err != nil { return -1, false, err }
+    return b.QuestionAsk(m, message)
+}
+
+// button returns the index of the first button with the given label, or -1.
+func (m Question) button(label string) int {
+    for i, b := range m.Buttons {
+        if b == label { return i }
+    }
+    return -1
+}
@@ -0,0 +1,299 @@
+package dialog
+
+import (
+    "fmt"
+    "image/color"
+    "strconv"
+    "sync"
+    "time"
+)
+
+// Answer is a scripted response to a dialog shown by a [Script] backend.
+type Answer struct {
+    // Ok is the boolean result of the dialog. For [Message.Ask], this is the
+    // answer to the question. For pickers, false means the user selected
+    // the cancel option.
+    Ok bool
+
+    // Value is the picked value, if Ok is true: a string for
+    // [FilePicker.Open] and [FilePicker.Save], a []string for
+    // [FilePicker.OpenMultiple], a [color.Color] for [ColorPicker.Pick], a
+    // [time.Time] for [DatePicker.Pick], a string for [TextInput.Ask] and
+    // [PasswordInput.Ask], an int for [Choice.Pick], or a []int for
+    // [Choice.PickMultiple].
+    //
+    // For [Progress.Start], Ok false means the user cancels the dialog, after
+    // a number of updates given by an int Value (or immediately, if nil).
+    //
+    // For [Notification.Send], Ok true means the user chooses the action
+    // with the index given by an int Value.
+    //
+    // For [Question.Ask], Value is the int index of the button picked, or
+    // -1, and Ok is the state of the checkbox.
+    Value any
+
+    // Err, if not nil, is returned as the error result of the dialog.
+    Err error
+}
+
+// Record describes a dialog shown by a [Script] backend.
+type Record struct {
+    // Method is the name of the [Backend] method called e.g. "MessageAsk".
+    Method string
+
+    // Dialog is the dialog shown, such as a [Message] or a [FilePicker],
+    // after defaults have been applied.
+    Dialog any
+
+    // Text is the formatted text of a message, or empty.
+    Text string
+}
+
+// Script is an in-memory [Backend] that returns queued answers, instead of
+// showing anything, and records each dialog. It is intended for testing code
+// that uses dialogs, for example in headless continuous integration.
+//
+// Every dialog is supported. Each dialog that returns a result takes the
+// next queued [Answer], in order, and returns an error if the queue is empty
+// or if the answer has a Value of the wrong type. [Message.Raise], and
+// [Notification.Send] for a notification without actions, do not take an
+// answer.
+//
+// Updates to a progress dialog are recorded with the methods
+// "ProgressSetPercent", with the percentage as its Text, "ProgressSetText",
+// and "ProgressClose".
+//
+// The zero value is an empty Script ready to use, and its methods are safe
+// for concurrent use. For example:
+//
+//     script := &dialog.Script{}
+//     script.Push(dialog.Answer{Ok: true})
+//     defer dialog.Use(dialog.Use(script))
+//
+//     ok, err := dialog.Ask("Continue?") // true, nil
+type Script struct {
+    mu      sync.Mutex
+    answers []Answer
+    records []Record
+}
+
+// Push adds answers to the end of the queue.
+func (s *Script) Push(answers ... Answer) {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+    s.answers = append(s.answers, answers...)
+}
+
+// Remaining returns the number of queued answers not yet taken.
+func (s *Script) Remaining() int {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+    return len(s.answers)
+}
+
+// Records returns a copy of every dialog shown so far, in order.
+func (s *Script) Records() []Record {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+    return append([]Record(nil), s.records...)
+}
+
+// record records a dialog, and if take is true, takes the next answer.
+func (s *Script) record(method string, dialog any, text string, take bool) (Answer, error) {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+
+    s.records = append(s.records, Record{
+        Method: method,
+        Dialog: dialog,
+        Text:   text,
+    })
+    if !take { return Answer{}, nil }
+
+    if len(s.answers) == 0 {
+        return Answer{}, fmt.Errorf("dialog: script has no answer for %s", method)
+    }
+    answer := s.answers[0]
+    s.answers = s.answers[1:]
+    return answer, nil
+}
+
+// value takes the next answer and converts its value to type X.
+func value[X any](s *Script, method string, dialog any) (X, bool, error) {
+    var zero X
+
+    answer, err := s.record(method, dialog, "", true)
+    if err != nil { return zero, false, err }
+    if answer.Err != nil { return zero, false, answer.Err }
+    if !answer.Ok { return zero, false, nil }
+
+    v, ok := answer.Value.(X)
+    if !ok {
+        return zero, false, fmt.Errorf("dialog: script answer for %s has value of type %T, expected %T",
+            method, answer.Value, zero)
+    }
+    return v, true, nil
+}
+
+func (s *Script) Supported() Support {
+    return Support{
+        MessageRaise:    true,
+        MessageAsk:      true,
+        Question:        true,
+        FilePicker:      true,
+        MultiFilePicker: true,
+        ColorPicker:     true,
+        DatePicker:      true,
+        TextInput:       true,
+        PasswordInput:   true,
+        Choice:          true,
+        MultiChoice:     true,
+        Progress:        true,
+        Notification:    true,
+    }
+}
+
+func (s *Script) backends() []string {
+    return []string{"script"}
+}
+
+func (s *Script) MessageRaise(m Message, message string) error {
+    _, err := s.record("MessageRaise", m, message, false)
+    return err
+}
+
+func (s *Script) MessageAsk(m Message, message string) (bool, error) {
+    answer, err := s.record("MessageAsk", m, message, true)
+    if err != nil { return false, err }
+    return answer.Ok, answer.Err
+}
+
+func (s *Script) QuestionAsk(m Question, message string) (int, bool, error) {
+    answer, err := s.record("QuestionAsk", m, message, true)
+    if err != nil { return -1, false, err }
+    if answer.Err != nil { return -1, false, answer.Err }
+
+    i, ok := answer.Value.(int)
+    if !ok || (i < -1) || (i >= len(m.Buttons)) {
+        return -1, false, fmt.Errorf("dialog: script answer for QuestionAsk has value %v, expected a button index", answer.Value)
+    }
+    return i, answer.Ok, nil
+}
+
+func (s *Script) FileOpen(m FilePicker) (string, bool, error) {
+    return value[string](s, "FileOpen", m)
+}
+
+func (s *Script) FileOpenMultiple(m FilePicker) ([]string, bool, error) {
+    return value[[]string](s, "FileOpenMultiple", m)
+}
+
+func (s *Script) FileSave(m FilePicker) (string, bool, error) {
+    return value[string](s, "FileSave", m)
+}
+
+func (s *Script) ColorPick(m ColorPicker) (color.Color, bool, error) {
+    return value[color.Color](s, "ColorPick", m)
+}
+
+func (s *Script) DatePick(m DatePicker) (time.Time, bool, error) {
+    return value[time.Time](s, "DatePick", m)
+}
+
+func (s *Script) TextAsk(m TextInput) (string, bool, error) {
+    return value[string](s, "TextAsk", m)
+}
+
+func (s *Script) PasswordAsk(m PasswordInput) (string, bool, error) {
+    return value[string](s, "PasswordAsk", m)
+}
+
+func (s *Script) ChoicePick(m Choice) (int, bool, error) {
+    return value[int](s, "ChoicePick", m)
+}
+
+func (s *Script) ChoicePickMultiple(m Choice) ([]int, bool, error) {
+    return value[[]int](s, "ChoicePickMultiple", m)
+}
+
+func (s *Script) ProgressStart(m Progress) (ProgressUpdater, error) {
+    answer, err := s.record("ProgressStart", m, m.Text, true)
+    if err != nil { return nil, err }
+    if answer.Err != nil { return nil, answer.Err }
+
+    p := &scriptProgress{
+        script:    s,
+        dialog:    m,
+        remaining: -1,
+        cancelled: make(chan struct{}),
+    }
+    if !answer.Ok {
+        p.remaining = 0
+        if answer.Value != nil {
+            n, ok := answer.Value.(int)
+            if !ok {
+                return nil, fmt.Errorf("dialog: script answer for ProgressStart has value of type %T, expected int",
+                    answer.Value)
+            }
+            p.remaining = n
+        }
+        if p.remaining == 0 { close(p.cancelled) }
+    }
+    return p, nil
+}
+
+// scriptProgress is a ProgressUpdater that records updates, and optionally
+// cancels after a number of updates.
+type scriptProgress struct {
+    script    *Script
+    dialog    Progress
+    mu        sync.Mutex
+    remaining int // updates until cancelled, or -1 for never
+    cancelled chan struct{}
+}
+
+func (p *scriptProgress) update(method string, text string) error {
+    _, err := p.script.record(method, p.dialog, text, false)
+
+    p.mu.Lock()
+    defer p.mu.Unlock()
+    if p.remaining > 0 {
+        p.remaining--
+        if p.remaining == 0 { close(p.cancelled) }
+    }
+    return err
+}
+
+func (p *scriptProgress) SetPercent(percent int) error {
+    return p.update("ProgressSetPercent", strconv.Itoa(percent))
+}
+
+func (p *scriptProgress) SetText(text string) error {
+    return p.update("ProgressSetText", text)
+}
+
+func (p *scriptProgress) Close() error {
+    _, err := p.script.record("ProgressClose", p.dialog, "", false)
+    return err
+}
+
+func (p *scriptProgress) Cancelled() <-chan struct{} {
+    return p.cancelled
+}
+
+func (s *Script) Notify(n Notification, body string) (<-chan int, error) {
+    c := make(chan int, 1)
+    defer close(c)
+
+    answer, err := s.record("Notify", n, body, len(n.Actions) > 0)
+    if err != nil { return c, err }
+    if answer.Err != nil { return c, answer.Err }
+    if !answer.Ok { return c, nil }
+
+    i, ok := answer.Value.(int)
+    if !ok || (i < 0) || (i >= len(n.Actions)) {
+        return c, fmt.Errorf("dialog: script answer for Notify has value %v, expected an action index", answer.Value)
+    }
+    c <- i
+    return c, nil
+}
@@ -0,0 +1,12 @@
+//go:build darwin || dragonfly || freebsd || netbsd || openbsd
+
+package dialog
+
+import (
+    "golang.org/x/sys/unix"
+)
+
+const (
+    ioctlGetTermios = unix.TIOCGETA
+    ioctlSetTermios = unix.TIOCSETA
+)
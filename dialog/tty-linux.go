@@ -0,0 +1,12 @@
+//go:build linux
+
+package dialog
+
+import (
+    "golang.org/x/sys/unix"
+)
+
+const (
+    ioctlGetTermios = unix.TCGETS
+    ioctlSetTermios = unix.TCSETS
+)
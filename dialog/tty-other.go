@@ -0,0 +1,16 @@
+//go:build unix && !(linux || darwin || dragonfly || freebsd || netbsd || openbsd)
+
+package dialog
+
+import (
+    "errors"
+    "os"
+)
+
+func openTTY(path string) (*os.File, func(), error) {
+    return nil, nil, errors.New("terminal raw mode not supported on this platform")
+}
+
+func ttySize(f *os.File) (int, int) {
+    return 0, 0
+}
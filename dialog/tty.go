@@ -0,0 +1,52 @@
+//go:build linux || darwin || dragonfly || freebsd || netbsd || openbsd
+
+package dialog
+
+import (
+    "os"
+
+    "golang.org/x/sys/unix"
+)
+
+// openTTY opens a terminal device in raw mode, returning a function that
+// restores its previous state.
+func openTTY(path string) (*os.File, func(), error) {
+    f, err := os.OpenFile(path, os.O_RDWR, 0)
+    if err != nil { return nil, nil, err }
+
+    fd := int(f.Fd())
+    old, err := unix.IoctlGetTermios(fd, ioctlGetTermios)
+    if err != nil {
+        f.Close()
+        return nil, nil, err
+    }
+
+    // as in cfmakeraw(3), but keep output processing off and signals off so
+    // that Ctrl-C can be read as a cancel key.
+    raw := *old
+    raw.Iflag &^= unix.IGNBRK | unix.BRKINT | unix.PARMRK | unix.ISTRIP |
+        unix.INLCR | unix.IGNCR | unix.ICRNL | unix.IXON
+    raw.Oflag &^= unix.OPOST
+    raw.Lflag &^= unix.ECHO | unix.ECHONL | unix.ICANON | unix.ISIG | unix.IEXTEN
+    raw.Cflag &^= unix.CSIZE | unix.PARENB
+    raw.Cflag |= unix.CS8
+    raw.Cc[unix.VMIN] = 1
+    raw.Cc[unix.VTIME] = 0
+
+    if err := unix.IoctlSetTermios(fd, ioctlSetTermios, &raw); err != nil {
+        f.Close()
+        return nil, nil, err
+    }
+
+    restore := func() {
+        unix.IoctlSetTermios(fd, ioctlSetTermios, old)
+    }
+    return f, restore, nil
+}
+
+// ttySize returns the width and height of a terminal, or zero if unknown.
+func ttySize(f *os.File) (int, int) {
+    ws, err := unix.IoctlGetWinsize(int(f.Fd()), unix.TIOCGWINSZ)
+    if err != nil { return 0, 0 }
+    return int(ws.Col), int(ws.Row)
+}
@@ -0,0 +1,1083 @@
+//go:build (linux || unix)
+
+package dialog
+
+import (
+    "bufio"
+    "errors"
+    "fmt"
+    "image/color"
+    "io"
+    "os"
+    "path/filepath"
+    "regexp"
+    "sort"
+    "strconv"
+    "strings"
+    "sync"
+    "time"
+    "unicode"
+    "unicode/utf8"
+
+    "github.com/acarl005/stripansi"
+    "github.com/tawesoft/golib/v2/ks"
+)
+
+// tui implements dialogs drawn directly on the controlling terminal, for
+// example over SSH or in a console without a display server.
+type tui struct {
+    path string // e.g. "/dev/tty"
+}
+
+// start opens the terminal in raw mode, returning a function that restores
+// and closes it.
+func (x tui) start() (*term, func(), error) {
+    tty, restore, err := openTTY(x.path)
+    if err != nil { return nil, nil, fmt.Errorf("terminal error: %w", err) }
+
+    width, height := ttySize(tty)
+    t := newTerm(tty, tty, width, height)
+    t.start()
+
+    return t, func() {
+        t.stop()
+        restore()
+        tty.Close()
+    }, nil
+}
+
+// run opens the terminal in raw mode, and calls f to draw a dialog.
+func (x tui) run(f func(t *term) error) error {
+    t, done, err := x.start()
+    if err != nil { return err }
+    defer done()
+    return f(t)
+}
+
+func (x tui) progress(m Progress) (ProgressUpdater, error) {
+    t, done, err := x.start()
+    if err != nil { return nil, err }
+
+    p := &termProgress{
+        t:         t,
+        m:         m,
+        text:      m.Text,
+        done:      done,
+        cancelled: make(chan struct{}),
+    }
+    p.draw()
+
+    // read keys until the user cancels, or until the terminal is closed
+    go func() {
+        for {
+            k, err := t.key()
+            if err != nil { return }
+            if m.NoCancel { continue }
+            if (k.code == keyEscape) || (k.code == keyInterrupt) {
+                p.mu.Lock()
+                if !p.closed { close(p.cancelled) }
+                p.mu.Unlock()
+                return
+            }
+        }
+    }()
+
+    return p, nil
+}
+
+func (x tui) ask(m Message, message string) (result bool, err error) {
+    err = x.run(func(t *term) error {
+        result, err = t.ask(m.Title, message)
+        return err
+    })
+    return result, err
+}
+
+func (x tui) question(m Question, message string) (result int, checked bool, err error) {
+    err = x.run(func(t *term) error {
+        result, checked, err = t.question(m, message)
+        return err
+    })
+    return result, checked, err
+}
+
+func (x tui) raise(m Message, message string) error {
+    return x.run(func(t *term) error {
+        return t.raise(m.Title, message)
+    })
+}
+
+func (x tui) color(m ColorPicker) (result color.Color, ok bool, err error) {
+    err = x.run(func(t *term) error {
+        result, ok, err = t.color(m)
+        return err
+    })
+    return result, ok, err
+}
+
+func (x tui) date(m DatePicker) (result time.Time, ok bool, err error) {
+    err = x.run(func(t *term) error {
+        result, ok, err = t.date(m)
+        return err
+    })
+    return result, ok, err
+}
+
+func (x tui) pickFile(m FilePicker, mode rune) (result []string, ok bool, err error) {
+    err = x.run(func(t *term) error {
+        result, ok, err = t.pickFile(m, mode)
+        return err
+    })
+    return result, ok, err
+}
+
+func (x tui) open(m FilePicker) (string, bool, error) {
+    if xs, ok, err := x.pickFile(m, 'o'); err != nil {
+        return "", false, fmt.Errorf("error opening file picker: %w", err)
+    } else if !ok || (len(xs) == 0) {
+        return "", false, nil
+    } else {
+        return xs[0], true, nil
+    }
+}
+
+func (x tui) openMultiple(m FilePicker) ([]string, bool, error) {
+    if xs, ok, err := x.pickFile(m, 'm'); err != nil {
+        return []string{}, false, fmt.Errorf("error opening file picker: %w", err)
+    } else if !ok || (len(xs) == 0) {
+        return []string{}, false, nil
+    } else {
+        return xs, true, nil
+    }
+}
+
+func (x tui) save(m FilePicker) (string, bool, error) {
+    if xs, ok, err := x.pickFile(m, 's'); err != nil {
+        return "", false, fmt.Errorf("error opening save file picker: %w", err)
+    } else if !ok || (len(xs) == 0) {
+        return "", false, nil
+    } else {
+        return xs[0], true, nil
+    }
+}
+
+func (x tui) text(m TextInput) (result string, ok bool, err error) {
+    err = x.run(func(t *term) error {
+        result, ok, err = t.input(m.Title, m.Label, m.Initial, false, nil)
+        return err
+    })
+    return result, ok, err
+}
+
+func (x tui) password(m PasswordInput) (result string, ok bool, err error) {
+    err = x.run(func(t *term) error {
+        result, ok, err = t.input(m.Title, m.Label, "", true, nil)
+        return err
+    })
+    return result, ok, err
+}
+
+func (x tui) choice(m Choice) (int, bool, error) {
+    var xs []int
+    var ok bool
+    err := x.run(func(t *term) (err error) {
+        xs, ok, err = t.choice(m, false)
+        return err
+    })
+    if (err != nil) || !ok { return 0, false, err }
+    return xs[0], true, nil
+}
+
+func (x tui) choiceMultiple(m Choice) ([]int, bool, error) {
+    var xs []int
+    var ok bool
+    err := x.run(func(t *term) (err error) {
+        xs, ok, err = t.choice(m, true)
+        return err
+    })
+    if (err != nil) || !ok { return []int{}, false, err }
+    return xs, true, nil
+}
+
+type keyCode int
+
+const (
+    keyRune keyCode = iota
+    keyEnter
+    keyEscape
+    keyInterrupt
+    keyTab
+    keyBackspace
+    keyUp
+    keyDown
+    keyLeft
+    keyRight
+    keyPageUp
+    keyPageDown
+    keyHome
+    keyEnd
+    keyUnknown
+)
+
+type key struct {
+    code keyCode
+    r    rune // for keyRune
+}
+
+// ANSI escape sequences
+const (
+    ansiReverse     = "\x1b[7m"
+    ansiBold        = "\x1b[1m"
+    ansiReset       = "\x1b[0m"
+    ansiClear       = "\x1b[H\x1b[2J"
+    ansiAltScreen   = "\x1b[?1049h"
+    ansiMainScreen  = "\x1b[?1049l"
+    ansiHideCursor  = "\x1b[?25l"
+    ansiShowCursor  = "\x1b[?25h"
+)
+
+// term draws dialogs on a terminal, reading keys from in and writing to out.
+// It is independent of any actual terminal device.
+type term struct {
+    in     *bufio.Reader
+    out    io.Writer
+    width  int
+    height int
+}
+
+func newTerm(in io.Reader, out io.Writer, width int, height int) *term {
+    if width  < 20 { width  = 80 }
+    if height < 10 { height = 24 }
+    return &term{
+        in:     bufio.NewReader(in),
+        out:    out,
+        width:  width,
+        height: height,
+    }
+}
+
+func (t *term) start() {
+    io.WriteString(t.out, ansiAltScreen + ansiHideCursor)
+}
+
+func (t *term) stop() {
+    io.WriteString(t.out, ansiReset + ansiClear + ansiShowCursor + ansiMainScreen)
+}
+
+// errClosed is returned if input ends while a dialog is open.
+var errClosed = errors.New("terminal input closed")
+
+// key reads the next key press.
+func (t *term) key() (key, error) {
+    r, _, err := t.in.ReadRune()
+    if err != nil {
+        if err == io.EOF { err = errClosed }
+        return key{}, err
+    }
+
+    switch r {
+        case '\r', '\n': return key{code: keyEnter}, nil
+        case '\t':       return key{code: keyTab}, nil
+        case 0x03:       return key{code: keyInterrupt}, nil // Ctrl-C
+        case 0x7f, 0x08: return key{code: keyBackspace}, nil
+        case 0x1b:       return t.escape()
+    }
+
+    if unicode.IsControl(r) { return key{code: keyUnknown}, nil }
+    return key{code: keyRune, r: r}, nil
+}
+
+// escape reads the rest of an escape sequence, or returns the escape key if
+// the escape byte was on its own.
+func (t *term) escape() (key, error) {
+    if t.in.Buffered() == 0 { return key{code: keyEscape}, nil }
+
+    next, _ := t.in.Peek(1)
+    if (next[0] != '[') && (next[0] != 'O') { return key{code: keyEscape}, nil }
+    t.in.ReadByte()
+
+    // read parameters up to a final byte in the range 0x40-0x7E
+    var params []byte
+    for {
+        b, err := t.in.ReadByte()
+        if err != nil { return key{code: keyUnknown}, nil }
+        if (b >= 0x40) && (b <= 0x7e) {
+            switch b {
+                case 'A': return key{code: keyUp}, nil
+                case 'B': return key{code: keyDown}, nil
+                case 'C': return key{code: keyRight}, nil
+                case 'D': return key{code: keyLeft}, nil
+                case 'H': return key{code: keyHome}, nil
+                case 'F': return key{code: keyEnd}, nil
+                case '~':
+                    switch string(params) {
+                        case "1", "7": return key{code: keyHome}, nil
+                        case "4", "8": return key{code: keyEnd}, nil
+                        case "5":      return key{code: keyPageUp}, nil
+                        case "6":      return key{code: keyPageDown}, nil
+                    }
+            }
+            return key{code: keyUnknown}, nil
+        }
+        params = append(params, b)
+    }
+}
+
+// visibleLen returns the number of visible columns used by a string that
+// may contain ANSI escape sequences.
+func visibleLen(s string) int {
+    return utf8.RuneCountInString(stripansi.Strip(s))
+}
+
+// truncate shortens a string, without escape sequences, to at most n
+// columns.
+func truncate(s string, n int) string {
+    if utf8.RuneCountInString(s) <= n { return s }
+    if n < 1 { return "" }
+    runes := []rune(s)
+    return string(runes[:n-1]) + "…"
+}
+
+// wrap word-wraps text to a width, preserving line breaks.
+func wrap(text string, width int) []string {
+    var lines []string
+    for _, paragraph := range strings.Split(text, "\n") {
+        if strings.TrimSpace(paragraph) == "" {
+            lines = append(lines, "")
+            continue
+        }
+        lines = append(lines, strings.Split(ks.WrapBlock(paragraph, width), "\n")...)
+    }
+    return lines
+}
+
+// message returns text word-wrapped to the usable width inside a box, and
+// aligned to the right if it is written right-to-left.
+func (t *term) message(text string) []string {
+    lines := wrap(text, t.inner())
+    if !isRTL(text) { return lines }
+
+    for i, line := range lines {
+        if n := t.inner() - visibleLen(line); n > 0 {
+            lines[i] = strings.Repeat(" ", n) + line
+        }
+    }
+    return lines
+}
+
+// inner returns the usable width inside a box.
+func (t *term) inner() int {
+    return t.width - 6
+}
+
+// draw clears the screen and draws a box with a title and lines of content,
+// followed by a line of help text.
+func (t *term) draw(title string, lines []string, help string) {
+    width := visibleLen(title) + 4
+    for _, line := range lines {
+        if n := visibleLen(line); n > width { width = n }
+    }
+    if width > t.inner() { width = t.inner() }
+
+    var sb strings.Builder
+    sb.WriteString(ansiClear)
+
+    title = truncate(title, width - 2)
+    sb.WriteString("\r\n  ┌─ " + ansiBold + title + ansiReset + " ")
+    sb.WriteString(strings.Repeat("─", width - visibleLen(title) - 1))
+    sb.WriteString("┐\r\n")
+
+    for _, line := range lines {
+        sb.WriteString("  │ ")
+        sb.WriteString(line)
+        sb.WriteString(ansiReset)
+        if n := visibleLen(line); n < width {
+            sb.WriteString(strings.Repeat(" ", width - n))
+        }
+        sb.WriteString(" │\r\n")
+    }
+
+    sb.WriteString("  └")
+    sb.WriteString(strings.Repeat("─", width + 2))
+    sb.WriteString("┘\r\n")
+
+    if help != "" {
+        sb.WriteString("\r\n  ")
+        sb.WriteString(truncate(help, t.width - 4))
+        sb.WriteString("\r\n")
+    }
+
+    io.WriteString(t.out, sb.String())
+}
+
+// buttons returns a line of buttons, with the focused button highlighted.
+func buttons(labels []string, focus int) string {
+    var sb strings.Builder
+    for i, label := range labels {
+        if i > 0 { sb.WriteString("   ") }
+        if i == focus {
+            sb.WriteString(ansiReverse + "< " + label + " >" + ansiReset)
+        } else {
+            sb.WriteString("< " + label + " >")
+        }
+    }
+    return sb.String()
+}
+
+func (t *term) raise(title string, message string) error {
+    lines := append(t.message(message), "", buttons([]string{locale().OK}, 0))
+    for {
+        t.draw(title, lines, "Enter: close")
+        k, err := t.key()
+        if err != nil { return err }
+        switch k.code {
+            case keyEnter, keyEscape, keyInterrupt:
+                return nil
+        }
+    }
+}
+
+func (t *term) question(m Question, message string) (int, bool, error) {
+    focus, checked := m.Default, false
+
+    help := "←/→: choose   Enter: confirm   Esc: close"
+    if m.Checkbox != "" {
+        help = "←/→: choose   Space: check   Enter: confirm   Esc: close"
+    }
+
+    for {
+        lines := append(t.message(message), "")
+        if m.Checkbox != "" {
+            box := "[ ] "
+            if checked { box = "[x] " }
+            lines = append(lines, truncate(box + m.Checkbox, t.inner()), "")
+        }
+        lines = append(lines, buttons(m.Buttons, focus))
+        t.draw(m.Title, lines, help)
+
+        k, err := t.key()
+        if err != nil { return -1, false, err }
+        switch k.code {
+            case keyLeft:
+                if focus > 0 { focus-- }
+            case keyRight:
+                if focus < len(m.Buttons) - 1 { focus++ }
+            case keyTab:
+                focus = (focus + 1) % len(m.Buttons)
+            case keyEnter:
+                return focus, checked, nil
+            case keyEscape, keyInterrupt:
+                return -1, checked, nil
+            case keyRune:
+                if (k.r == ' ') && (m.Checkbox != "") { checked = !checked }
+        }
+    }
+}
+
+// shortcut returns the shortcut key for a button, which is the first letter
+// of its label, in lower case.
+func shortcut(label string) rune {
+    r, _ := utf8.DecodeRuneInString(label)
+    return unicode.ToLower(r)
+}
+
+func (t *term) ask(title string, message string) (bool, error) {
+    l := locale()
+    yes, no := shortcut(l.Yes), shortcut(l.No)
+    help := fmt.Sprintf("←/→: choose   Enter: confirm   %c/%c: %s/%s   Esc: %s",
+        unicode.ToUpper(yes), unicode.ToUpper(no), l.Yes, l.No, l.No)
+
+    focus := 1 // "No", like zenity --default-cancel
+    for {
+        lines := append(t.message(message), "", buttons([]string{l.Yes, l.No}, focus))
+        t.draw(title, lines, help)
+
+        k, err := t.key()
+        if err != nil { return false, err }
+        switch k.code {
+            case keyLeft, keyRight, keyTab:
+                focus = 1 - focus
+            case keyEnter:
+                return focus == 0, nil
+            case keyEscape, keyInterrupt:
+                return false, nil
+            case keyRune:
+                if yes == no { break } // ambiguous
+                switch unicode.ToLower(k.r) {
+                    case yes: return true, nil
+                    case no:  return false, nil
+                }
+        }
+    }
+}
+
+// input is a single-line text input. If mask is true, the text is hidden.
+// If validate is not nil, it is called with the current text, and returns
+// an optional preview line, or an error if the text can't be accepted.
+func (t *term) input(
+    title string,
+    label string,
+    initial string,
+    mask bool,
+    validate func(string) (string, error),
+) (string, bool, error) {
+    text := []rune(initial)
+    var problem string
+
+    for {
+        shown := string(text)
+        if mask { shown = strings.Repeat("•", len(text)) }
+        width := t.inner() - 2
+        if n := utf8.RuneCountInString(shown); n > width {
+            shown = "…" + string([]rune(shown)[n - width + 1:])
+        }
+
+        lines := append(wrap(label, t.inner()), "", "> " + shown + ansiReverse + " " + ansiReset)
+        if validate != nil {
+            preview, err := validate(string(text))
+            if err == nil && preview != "" {
+                lines = append(lines, "", preview)
+            }
+        }
+        if problem != "" {
+            lines = append(lines, "", truncate(problem, t.inner()))
+        }
+        t.draw(title, lines, "Enter: confirm   Esc: cancel")
+
+        k, err := t.key()
+        if err != nil { return "", false, err }
+        switch k.code {
+            case keyEnter:
+                if validate != nil {
+                    if _, err := validate(string(text)); err != nil {
+                        problem = err.Error()
+                        continue
+                    }
+                }
+                return string(text), true, nil
+            case keyEscape, keyInterrupt:
+                return "", false, nil
+            case keyBackspace:
+                if len(text) > 0 { text = text[:len(text)-1] }
+                problem = ""
+            case keyRune:
+                text = append(text, k.r)
+                problem = ""
+        }
+    }
+}
+
+// choice is a list of options. If multiple is false, exactly one option is
+// chosen; otherwise any number of options may be chosen with the space key.
+func (t *term) choice(m Choice, multiple bool) ([]int, bool, error) {
+    cursor, offset := 0, 0
+    chosen := make(map[int]bool)
+    for i := range m.Options {
+        if m.selected(i, multiple) {
+            chosen[i] = true
+            if !multiple { cursor = i }
+        }
+    }
+
+    rows := t.height - 10
+    if rows < 3 { rows = 3 }
+
+    for {
+        if cursor < offset { offset = cursor }
+        if cursor >= offset + rows { offset = cursor - rows + 1 }
+
+        width := t.inner()
+        var lines []string
+        if m.Label != "" {
+            lines = append(wrap(m.Label, width), "")
+        }
+        for i := offset; (i < len(m.Options)) && (i < offset + rows); i++ {
+            var label string
+            switch {
+                case multiple && chosen[i]: label = "[x] "
+                case multiple:              label = "[ ] "
+                case i == cursor:           label = "(•) "
+                default:                    label = "( ) "
+            }
+            label = truncate(label + m.Options[i], width)
+            if i == cursor {
+                label = ansiReverse + label + strings.Repeat(" ", width - visibleLen(label)) + ansiReset
+            }
+            lines = append(lines, label)
+        }
+
+        help := "↑/↓: move   Enter: confirm   Esc: cancel"
+        if multiple {
+            help = "↑/↓: move   Space: select   Enter: confirm   Esc: cancel"
+        }
+        t.draw(m.Title, lines, help)
+
+        k, err := t.key()
+        if err != nil { return nil, false, err }
+        switch k.code {
+            case keyUp:       cursor--
+            case keyDown:     cursor++
+            case keyPageUp:   cursor -= rows
+            case keyPageDown: cursor += rows
+            case keyHome:     cursor = 0
+            case keyEnd:      cursor = len(m.Options) - 1
+            case keyEscape, keyInterrupt:
+                return nil, false, nil
+            case keyRune:
+                if multiple && (k.r == ' ') {
+                    chosen[cursor] = !chosen[cursor]
+                }
+            case keyEnter:
+                if !multiple { return []int{cursor}, true, nil }
+                result := make([]int, 0, len(chosen))
+                for i := range m.Options {
+                    if chosen[i] { result = append(result, i) }
+                }
+                return result, true, nil
+        }
+        if cursor >= len(m.Options) { cursor = len(m.Options) - 1 }
+        if cursor < 0 { cursor = 0 }
+    }
+}
+
+// termProgress is a progress dialog drawn on a terminal.
+type termProgress struct {
+    t         *term
+    m         Progress
+    done      func()
+
+    mu        sync.Mutex
+    percent   int
+    text      string
+    closed    bool
+    cancelled chan struct{}
+}
+
+// draw draws the progress dialog, with the lock held.
+func (p *termProgress) draw() {
+    width := p.t.inner()
+    lines := wrap(p.text, width)
+    if len(lines) == 0 { lines = []string{""} }
+    lines = append(lines, "")
+
+    if p.m.Pulsate {
+        lines = append(lines, "Working…")
+    } else {
+        label := fmt.Sprintf(" %3d%%", p.percent)
+        n := width - len(label) - 2
+        filled := (n * p.percent) / 100
+        lines = append(lines, "[" + strings.Repeat("█", filled) +
+            strings.Repeat("░", n - filled) + "]" + label)
+    }
+
+    help := "Esc: cancel"
+    if p.m.NoCancel { help = "" }
+    p.t.draw(p.m.Title, lines, help)
+}
+
+func (p *termProgress) SetPercent(percent int) error {
+    p.mu.Lock()
+    defer p.mu.Unlock()
+    if p.closed { return nil }
+    p.percent = percent
+    p.draw()
+    return nil
+}
+
+func (p *termProgress) SetText(text string) error {
+    p.mu.Lock()
+    defer p.mu.Unlock()
+    if p.closed { return nil }
+    p.text = text
+    p.draw()
+    return nil
+}
+
+func (p *termProgress) Close() error {
+    p.mu.Lock()
+    defer p.mu.Unlock()
+    if p.closed { return nil }
+    p.closed = true
+    p.done()
+    return nil
+}
+
+func (p *termProgress) Cancelled() <-chan struct{} {
+    return p.cancelled
+}
+
+var (
+    tuiHexColorRE = regexp.MustCompile(`^#?([[:xdigit:]]{2})([[:xdigit:]]{2})([[:xdigit:]]{2})$`)
+    tuiShortHexColorRE = regexp.MustCompile(`^#?([[:xdigit:]])([[:xdigit:]])([[:xdigit:]])$`)
+    tuiRGBColorRE = regexp.MustCompile(`^(?:rgb\()?\s*(\d{1,3})\s*,\s*(\d{1,3})\s*,\s*(\d{1,3})\s*\)?$`)
+)
+
+// parseColor parses a colour in the form "#RRGGBB", "#RGB", or "R, G, B"
+// (or "rgb(R, G, B)") with decimal components from 0 to 255.
+func parseColor(s string) (color.RGBA, error) {
+    s = strings.ToLower(strings.TrimSpace(s))
+
+    var parts []string
+    base := 16
+    if m := tuiHexColorRE.FindStringSubmatch(s); m != nil {
+        parts = m[1:]
+    } else if m := tuiShortHexColorRE.FindStringSubmatch(s); m != nil {
+        parts = []string{m[1]+m[1], m[2]+m[2], m[3]+m[3]}
+    } else if m := tuiRGBColorRE.FindStringSubmatch(s); m != nil {
+        parts = m[1:]
+        base = 10
+    } else {
+        return color.RGBA{}, fmt.Errorf("invalid color %q: use #RRGGBB or R, G, B", s)
+    }
+
+    var c [3]uint8
+    for i, p := range parts {
+        x, err := strconv.ParseUint(p, base, 8)
+        if err != nil {
+            return color.RGBA{}, fmt.Errorf("invalid color component %q: must be 0 to 255", p)
+        }
+        c[i] = uint8(x)
+    }
+    return color.RGBA{R: c[0], G: c[1], B: c[2], A: 255}, nil
+}
+
+func (t *term) color(m ColorPicker) (color.Color, bool, error) {
+    initial := "#000000"
+    if m.Initial != nil {
+        r, g, b, _ := m.Initial.RGBA()
+        initial = fmt.Sprintf("#%02x%02x%02x", r / 256, g / 256, b / 256)
+    }
+
+    preview := func(s string) (string, error) {
+        c, err := parseColor(s)
+        if err != nil { return "", err }
+        return fmt.Sprintf("Preview: \x1b[48;2;%d;%d;%dm        %s  rgb(%d, %d, %d)",
+            c.R, c.G, c.B, ansiReset, c.R, c.G, c.B), nil
+    }
+
+    result, ok, err := t.input(m.Title, "Color (hexadecimal #RRGGBB, or decimal R, G, B):", initial, false, preview)
+    if (err != nil) || !ok { return nil, false, err }
+
+    c, err := parseColor(result)
+    if err != nil { return nil, false, err }
+    return c, true, nil
+}
+
+func daysIn(year int, month time.Month) int {
+    return time.Date(year, month + 1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
+
+// addMonths adds months to a date, clamping the day to the end of the month
+// e.g. one month after 31st January is 28th or 29th February.
+func addMonths(d time.Time, months int) time.Time {
+    first := time.Date(d.Year(), d.Month() + time.Month(months), 1, 0, 0, 0, 0, d.Location())
+    day := d.Day()
+    if n := daysIn(first.Year(), first.Month()); day > n { day = n }
+    return first.AddDate(0, 0, day - 1)
+}
+
+func (t *term) date(m DatePicker) (time.Time, bool, error) {
+    l := locale()
+    title := m.Title
+    if title == "" { title = l.SelectDate }
+
+    // each weekday name is two columns wide, to line up with each day
+    names := make([]string, 0, 7)
+    for _, name := range l.Weekdays {
+        if runes := []rune(name); len(runes) > 2 { name = string(runes[:2]) }
+        names = append(names, name + strings.Repeat(" ", 2 - visibleLen(name)))
+    }
+    weekdays := strings.Join(names, " ")
+
+    in := m.Initial.In(m.Location)
+    d := time.Date(in.Year(), in.Month(), in.Day(), 0, 0, 0, 0, m.Location)
+
+    for {
+        var lines []string
+        if m.LongTitle != "" {
+            lines = append(lines, wrap(m.LongTitle, t.inner())...)
+            lines = append(lines, "")
+        }
+
+        header := fmt.Sprintf("%s %d", l.Months[d.Month() - 1], d.Year())
+        pad := (20 - visibleLen(header)) / 2
+        if pad < 0 { pad = 0 }
+        lines = append(lines, strings.Repeat(" ", pad) + header)
+        lines = append(lines, weekdays)
+
+        first := time.Date(d.Year(), d.Month(), 1, 0, 0, 0, 0, m.Location)
+        offset := (int(first.Weekday()) + 6) % 7 // Monday first
+        var week strings.Builder
+        week.WriteString(strings.Repeat("   ", offset))
+        for day := 1; day <= daysIn(d.Year(), d.Month()); day++ {
+            if day == d.Day() {
+                week.WriteString(fmt.Sprintf("%s%2d%s", ansiReverse, day, ansiReset))
+            } else {
+                week.WriteString(fmt.Sprintf("%2d", day))
+            }
+            if (offset + day) % 7 == 0 {
+                lines = append(lines, week.String())
+                week.Reset()
+            } else {
+                week.WriteString(" ")
+            }
+        }
+        if week.Len() > 0 { lines = append(lines, week.String()) }
+
+        t.draw(title, lines, "Arrows: day/week   PgUp/PgDn: month   Enter: confirm   Esc: cancel")
+
+        k, err := t.key()
+        if err != nil { return time.Time{}, false, err }
+        switch k.code {
+            case keyLeft:     d = d.AddDate(0, 0, -1)
+            case keyRight:    d = d.AddDate(0, 0,  1)
+            case keyUp:       d = d.AddDate(0, 0, -7)
+            case keyDown:     d = d.AddDate(0, 0,  7)
+            case keyPageUp:   d = addMonths(d, -1)
+            case keyPageDown: d = addMonths(d,  1)
+            case keyEnter:    return d, true, nil
+            case keyEscape, keyInterrupt:
+                return time.Time{}, false, nil
+        }
+    }
+}
+
+// fileEntry is an entry in a file browser.
+type fileEntry struct {
+    name  string
+    isDir bool
+}
+
+// matchFilter returns true if a file name matches a space-separated list of
+// glob patterns. As on Windows, "*.*" matches every file.
+func matchFilter(patterns string, name string) bool {
+    for _, p := range strings.Fields(patterns) {
+        if (p == "*") || (p == "*.*") { return true }
+        if ok, _ := filepath.Match(strings.ToLower(p), strings.ToLower(name)); ok { return true }
+    }
+    return false
+}
+
+// browser is the state of a file browser.
+type browser struct {
+    m        FilePicker
+    mode     rune // (o)pen, (m)ultiple, (s)ave
+    dirs     bool // selecting directories instead of files
+    dir      string
+    entries  []fileEntry
+    cursor   int
+    offset   int
+    filter   int
+    selected map[string]bool
+    name     []rune // for save
+    problem  string
+}
+
+func (b *browser) load() {
+    b.entries = b.entries[:0]
+    b.cursor, b.offset = 0, 0
+    b.problem = ""
+
+    if b.dirs {
+        // selects the current directory
+        b.entries = append(b.entries, fileEntry{".", true})
+    } else if b.dir != "/" {
+        b.entries = append(b.entries, fileEntry{"..", true})
+    }
+
+    des, err := os.ReadDir(b.dir)
+    if err != nil { b.problem = err.Error() }
+
+    var dirs, files []fileEntry
+    for _, de := range des {
+        name := de.Name()
+        if strings.HasPrefix(name, ".") && !b.m.AlwaysShowHidden { continue }
+
+        isDir := de.IsDir()
+        if de.Type() & os.ModeSymlink != 0 {
+            if info, err := os.Stat(filepath.Join(b.dir, name)); err == nil {
+                isDir = info.IsDir()
+            }
+        }
+
+        if isDir {
+            dirs = append(dirs, fileEntry{name, true})
+        } else if b.dirs {
+            continue
+        } else if (len(b.m.FileTypes) == 0) || matchFilter(b.m.FileTypes[b.filter][1], name) {
+            files = append(files, fileEntry{name, false})
+        }
+    }
+
+    less := func(xs []fileEntry) func(i, j int) bool {
+        return func(i, j int) bool {
+            return strings.ToLower(xs[i].name) < strings.ToLower(xs[j].name)
+        }
+    }
+    sort.Slice(dirs, less(dirs))
+    sort.Slice(files, less(files))
+    b.entries = append(b.entries, dirs...)
+    b.entries = append(b.entries, files...)
+}
+
+func (b *browser) enter(name string) {
+    if name == ".." {
+        b.dir = filepath.Dir(b.dir)
+    } else {
+        b.dir = filepath.Join(b.dir, name)
+    }
+    b.load()
+}
+
+func (b *browser) move(delta int) {
+    b.cursor += delta
+    if b.cursor >= len(b.entries) { b.cursor = len(b.entries) - 1 }
+    if b.cursor < 0 { b.cursor = 0 }
+
+    // in save mode, picking an existing file fills in its name
+    if (b.mode == 's') && (len(b.entries) > 0) && !b.entries[b.cursor].isDir {
+        b.name = []rune(b.entries[b.cursor].name)
+    }
+}
+
+func (b *browser) current() (fileEntry, bool) {
+    if len(b.entries) == 0 { return fileEntry{}, false }
+    return b.entries[b.cursor], true
+}
+
+func (t *term) pickFile(m FilePicker, mode rune) ([]string, bool, error) {
+    b := &browser{
+        m:        m,
+        mode:     mode,
+        dirs:     m.Directory && (mode != 's'),
+        filter:   m.DefaultFileType,
+        selected: make(map[string]bool),
+    }
+    if (b.filter < 0) || (b.filter >= len(m.FileTypes)) { b.filter = 0 }
+
+    dir, name, err := m.location()
+    if err != nil { return nil, false, err }
+    b.dir = dir
+    b.load()
+
+    if mode == 's' { b.name = []rune(name) }
+    for i, e := range b.entries {
+        if e.name == name { b.cursor = i; break }
+    }
+
+    rows := t.height - 12
+    if rows < 3 { rows = 3 }
+
+    for {
+        if b.cursor < b.offset { b.offset = b.cursor }
+        if b.cursor >= b.offset + rows { b.offset = b.cursor - rows + 1 }
+
+        width := t.inner()
+        lines := []string{truncate(b.dir, width), ""}
+        for i := b.offset; (i < len(b.entries)) && (i < b.offset + rows); i++ {
+            e := b.entries[i]
+            label := e.name
+            if e.isDir { label += "/" }
+            if mode == 'm' {
+                if b.selected[filepath.Join(b.dir, e.name)] {
+                    label = "[x] " + label
+                } else if e.isDir && !(b.dirs && (e.name != ".")) {
+                    label = "    " + label
+                } else {
+                    label = "[ ] " + label
+                }
+            }
+            label = truncate(label, width)
+            if i == b.cursor {
+                label = ansiReverse + label + strings.Repeat(" ", width - visibleLen(label)) + ansiReset
+            }
+            lines = append(lines, label)
+        }
+        for i := len(b.entries) - b.offset; i < rows; i++ {
+            lines = append(lines, "")
+        }
+
+        lines = append(lines, "")
+        if (len(m.FileTypes) > 0) && !b.dirs {
+            ft := m.FileTypes[b.filter]
+            lines = append(lines, truncate(fmt.Sprintf("Type: %s (%s)", ft[0], ft[1]), width))
+        }
+        if mode == 's' {
+            lines = append(lines, "Name: " + string(b.name) + ansiReverse + " " + ansiReset)
+        }
+        if b.problem != "" {
+            lines = append(lines, truncate(b.problem, width))
+        }
+
+        var help string
+        switch {
+            case b.dirs && (mode == 'm'):
+                help = "↑/↓: move   ←/→: folder   Space: select   Enter: confirm   Esc: cancel"
+            case b.dirs:
+                help = "↑/↓: move   ←/→: folder   Enter: confirm   Esc: cancel"
+            case mode == 'm': help = "↑/↓: move   ←/→: folder   Space: select   Tab: type   Enter: confirm   Esc: cancel"
+            case mode == 's': help = "↑/↓: move   ←/→: folder   type a name   Tab: type   Enter: confirm   Esc: cancel"
+            default:  help = "↑/↓: move   ←/→: folder   Tab: type   Enter: confirm   Esc: cancel"
+        }
+        t.draw(m.Title, lines, help)
+
+        k, err := t.key()
+        if err != nil { return nil, false, err }
+        switch k.code {
+            case keyEscape, keyInterrupt:
+                return nil, false, nil
+            case keyUp:       b.move(-1)
+            case keyDown:     b.move(1)
+            case keyPageUp:   b.move(-rows)
+            case keyPageDown: b.move(rows)
+            case keyHome:     b.move(-len(b.entries))
+            case keyEnd:      b.move(len(b.entries))
+            case keyLeft:
+                if b.dir != "/" { b.enter("..") }
+            case keyRight:
+                if e, ok := b.current(); ok && e.isDir { b.enter(e.name) }
+            case keyTab:
+                if (len(m.FileTypes) > 1) && !b.dirs {
+                    b.filter = (b.filter + 1) % len(m.FileTypes)
+                    b.load()
+                }
+            case keyBackspace:
+                if (mode == 's') && (len(b.name) > 0) { b.name = b.name[:len(b.name)-1] }
+            case keyRune:
+                if mode == 's' {
+                    if k.r != '/' { b.name = append(b.name, k.r) }
+                } else if (mode == 'm') && (k.r == ' ') {
+                    if e, ok := b.current(); ok && (e.isDir == b.dirs) && (e.name != "..") && (e.name != ".") {
+                        path := filepath.Join(b.dir, e.name)
+                        b.selected[path] = !b.selected[path]
+                        if !b.selected[path] { delete(b.selected, path) }
+                    }
+                }
+            case keyEnter:
+                e, ok := b.current()
+                switch {
+                    case (mode == 's') && (len(b.name) > 0):
+                        path := filepath.Join(b.dir, string(b.name))
+                        if q, msg, ask := m.overwrite(path); ask {
+                            if yes, err := t.ask(q.Title, msg); err != nil {
+                                return nil, false, err
+                            } else if !yes {
+                                continue
+                            }
+                        }
+                        return []string{path}, true, nil
+                    case (mode == 'm') && (len(b.selected) > 0):
+                        paths := make([]string, 0, len(b.selected))
+                        for p := range b.selected { paths = append(paths, p) }
+                        sort.Strings(paths)
+                        return paths, true, nil
+                    case ok && b.dirs:
+                        return []string{filepath.Join(b.dir, e.name)}, true, nil
+                    case ok && e.isDir:
+                        b.enter(e.name)
+                    case ok && (mode != 's'):
+                        return []string{filepath.Join(b.dir, e.name)}, true, nil
+                }
+        }
+    }
+}
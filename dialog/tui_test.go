@@ -0,0 +1,344 @@
+//go:build (linux || unix)
+
+package dialog
+
+import (
+    "image/color"
+    "io"
+    "os"
+    "path/filepath"
+    "strings"
+    "testing"
+    "time"
+
+    "github.com/stretchr/testify/assert"
+)
+
+// keys returns a term that reads the given input, discarding its output.
+func keys(input string) *term {
+    return newTerm(strings.NewReader(input), io.Discard, 80, 24)
+}
+
+const (
+    up    = "\x1b[A"
+    down  = "\x1b[B"
+    right = "\x1b[C"
+    left  = "\x1b[D"
+    pgDn  = "\x1b[6~"
+)
+
+func TestTerm_ask(t *testing.T) {
+    t.Setenv("LC_ALL", "en_GB.UTF-8") // for shortcut keys
+    tests := []struct {
+        input    string
+        expected bool
+    }{
+        {"\r",        false},
+        {left + "\r", true},
+        {"y",         true},
+        {"N",         false},
+        {"\x03",      false},
+    }
+
+    for _, test := range tests {
+        result, err := keys(test.input).ask("Title", "Question?")
+        assert.Nil(t, err)
+        assert.Equal(t, test.expected, result, "input %q", test.input)
+    }
+
+    _, err := keys("").ask("Title", "Question?")
+    assert.ErrorIs(t, err, errClosed)
+}
+
+func TestTerm_question(t *testing.T) {
+    m := Question{
+        Title:    "Title",
+        Buttons:  []string{"Save", "Don't Save", "Cancel"},
+        Default:  2,
+        Checkbox: "Don't ask again",
+    }
+
+    tests := []struct {
+        input    string
+        expected int
+        checked  bool
+    }{
+        {"\r",                 2, false},
+        {left + left + "\r",   0, false},
+        {" " + left + "\r",    1, true},
+        {"  \t\r",            0, false},
+        {"\x1b",              -1, false},
+    }
+
+    for _, test := range tests {
+        result, checked, err := keys(test.input).question(m, "Save changes?")
+        assert.Nil(t, err)
+        assert.Equal(t, test.expected, result, "input %q", test.input)
+        assert.Equal(t, test.checked, checked, "input %q", test.input)
+    }
+}
+
+func TestTerm_input(t *testing.T) {
+    result, ok, err := keys("x\x7fhello\r").input("Title", "Label", "", true, nil)
+    assert.Nil(t, err)
+    assert.True(t, ok)
+    assert.Equal(t, "hello", result)
+
+    _, ok, err = keys("hello\x1b").input("Title", "Label", "", false, nil)
+    assert.Nil(t, err)
+    assert.False(t, ok)
+}
+
+func TestTerm_choice(t *testing.T) {
+    m := Choice{Options: []string{"a", "b", "c"}, Default: []int{1}}
+
+    xs, ok, err := keys(down + "\r").choice(m, false)
+    assert.Nil(t, err)
+    assert.True(t, ok)
+    assert.Equal(t, []int{2}, xs)
+
+    xs, ok, err = keys(" " + down + " \r").choice(m, true)
+    assert.Nil(t, err)
+    assert.True(t, ok)
+    assert.Equal(t, []int{0}, xs)
+}
+
+func TestParseIndexes(t *testing.T) {
+    xs, err := parseIndexes("2|\n0|\n", "|", 3)
+    assert.Nil(t, err)
+    assert.Equal(t, []int{0, 2}, xs)
+
+    xs, err = parseIndexes(`"1" "0"`, "", 3)
+    assert.Nil(t, err)
+    assert.Equal(t, []int{0, 1}, xs)
+
+    _, err = parseIndexes("3", "", 3)
+    assert.NotNil(t, err)
+}
+
+func TestTerm_color(t *testing.T) {
+    m := ColorPicker{Initial: color.RGBA{0x11, 0x22, 0x33, 0xff}}
+
+    c, ok, err := keys("\r").color(m)
+    assert.Nil(t, err)
+    assert.True(t, ok)
+    assert.Equal(t, color.RGBA{0x11, 0x22, 0x33, 0xff}, c)
+
+    // erase "#112233", try an invalid colour, then enter a decimal colour
+    c, ok, err = keys(strings.Repeat("\x7f", 7) + "xyz\r\x7f\x7f\x7f255, 128, 0\r").color(m)
+    assert.Nil(t, err)
+    assert.True(t, ok)
+    assert.Equal(t, color.RGBA{255, 128, 0, 255}, c)
+
+    _, ok, err = keys("\x1b").color(m)
+    assert.Nil(t, err)
+    assert.False(t, ok)
+
+    for _, s := range []string{"#fff", "ffffff", "rgb(255,255,255)"} {
+        c, err := parseColor(s)
+        assert.Nil(t, err)
+        assert.Equal(t, color.RGBA{255, 255, 255, 255}, c, s)
+    }
+    _, err = parseColor("256, 0, 0")
+    assert.NotNil(t, err)
+}
+
+func TestTerm_date(t *testing.T) {
+    m := DatePicker{
+        Initial:  time.Date(2023, time.January, 31, 12, 0, 0, 0, time.UTC),
+        Location: time.UTC,
+    }
+
+    d, ok, err := keys(right + down + "\r").date(m)
+    assert.Nil(t, err)
+    assert.True(t, ok)
+    assert.Equal(t, time.Date(2023, time.February, 8, 0, 0, 0, 0, time.UTC), d)
+
+    // the day is clamped to the end of a shorter month
+    d, ok, err = keys(pgDn + "\r").date(m)
+    assert.Nil(t, err)
+    assert.True(t, ok)
+    assert.Equal(t, time.Date(2023, time.February, 28, 0, 0, 0, 0, time.UTC), d)
+}
+
+func TestTermProgress_Close(t *testing.T) {
+    closes := 0
+    p := &termProgress{
+        t:         keys(""),
+        done:      func() { closes++ },
+        cancelled: make(chan struct{}),
+    }
+
+    assert.Nil(t, p.SetPercent(50))
+    assert.Nil(t, p.Close())
+    assert.Nil(t, p.Close())
+    assert.Nil(t, p.SetText("closed"))
+    assert.Equal(t, 1, closes)
+}
+
+func TestTerm_pickFile(t *testing.T) {
+    dir := t.TempDir()
+    assert.Nil(t, os.Mkdir(filepath.Join(dir, "sub"), 0755))
+    for _, name := range []string{"a.txt", "b.png", "c.txt", ".hidden", "sub/d.txt"} {
+        assert.Nil(t, os.WriteFile(filepath.Join(dir, name), nil, 0644))
+    }
+
+    m := FilePicker{
+        Path: dir + "/",
+        FileTypes: [][2]string{
+            {"Text files", "*.txt"},
+            {"All files",  "*.*"},
+        },
+    }
+
+    // entries are: "..", "sub/", "a.txt", "c.txt"
+    result, ok, err := keys(down + down + down + "\r").pickFile(m, 'o')
+    assert.Nil(t, err)
+    assert.True(t, ok)
+    assert.Equal(t, []string{filepath.Join(dir, "c.txt")}, result)
+
+    // after changing the filter: "..", "sub/", "a.txt", "b.png", "c.txt"
+    result, ok, err = keys("\t" + down + down + down + "\r").pickFile(m, 'o')
+    assert.Nil(t, err)
+    assert.True(t, ok)
+    assert.Equal(t, []string{filepath.Join(dir, "b.png")}, result)
+
+    // enter the subdirectory, then its only file
+    result, ok, err = keys(down + right + down + "\r").pickFile(m, 'o')
+    assert.Nil(t, err)
+    assert.True(t, ok)
+    assert.Equal(t, []string{filepath.Join(dir, "sub", "d.txt")}, result)
+
+    result, ok, err = keys(down + down + " " + down + " \r").pickFile(m, 'm')
+    assert.Nil(t, err)
+    assert.True(t, ok)
+    assert.Equal(t, []string{
+        filepath.Join(dir, "a.txt"),
+        filepath.Join(dir, "c.txt"),
+    }, result)
+
+    m.Path = filepath.Join(dir, "new.txt")
+    result, ok, err = keys("\x7f\x7f\x7fmd\r").pickFile(m, 's')
+    assert.Nil(t, err)
+    assert.True(t, ok)
+    assert.Equal(t, []string{filepath.Join(dir, "new.md")}, result)
+
+    _, ok, err = keys("\x1b").pickFile(m, 'o')
+    assert.Nil(t, err)
+    assert.False(t, ok)
+}
+
+func TestTerm_pickFile_directory(t *testing.T) {
+    dir := t.TempDir()
+    for _, name := range []string{"x", "y", "y/z"} {
+        assert.Nil(t, os.Mkdir(filepath.Join(dir, name), 0755))
+    }
+    assert.Nil(t, os.WriteFile(filepath.Join(dir, "a.txt"), nil, 0644))
+
+    m := FilePicker{
+        Dir:       dir,
+        Directory: true,
+    }
+
+    // entries are: "./", "x/", "y/"
+    result, ok, err := keys("\r").pickFile(m, 'o')
+    assert.Nil(t, err)
+    assert.True(t, ok)
+    assert.Equal(t, []string{dir}, result)
+
+    result, ok, err = keys(down + down + right + down + "\r").pickFile(m, 'o')
+    assert.Nil(t, err)
+    assert.True(t, ok)
+    assert.Equal(t, []string{filepath.Join(dir, "y", "z")}, result)
+
+    result, ok, err = keys(" " + down + " " + down + " \r").pickFile(m, 'm')
+    assert.Nil(t, err)
+    assert.True(t, ok)
+    assert.Equal(t, []string{
+        filepath.Join(dir, "x"),
+        filepath.Join(dir, "y"),
+    }, result)
+}
+
+func TestTerm_pickFile_confirmOverwrite(t *testing.T) {
+    t.Setenv("LC_ALL", "en_GB.UTF-8") // for shortcut keys
+    dir := t.TempDir()
+    assert.Nil(t, os.WriteFile(filepath.Join(dir, "a.txt"), nil, 0644))
+
+    m := FilePicker{
+        Dir:              dir,
+        FileName:         "a.txt",
+        ConfirmOverwrite: true,
+    }
+
+    // decline, rename, then accept a new file without asking
+    result, ok, err := keys("\rn\x7f\x7f\x7fmd\r").pickFile(m, 's')
+    assert.Nil(t, err)
+    assert.True(t, ok)
+    assert.Equal(t, []string{filepath.Join(dir, "a.md")}, result)
+
+    result, ok, err = keys("\ry").pickFile(m, 's')
+    assert.Nil(t, err)
+    assert.True(t, ok)
+    assert.Equal(t, []string{filepath.Join(dir, "a.txt")}, result)
+
+    m.ConfirmOverwrite = false
+    result, ok, err = keys("\r").pickFile(m, 's')
+    assert.Nil(t, err)
+    assert.True(t, ok)
+    assert.Equal(t, []string{filepath.Join(dir, "a.txt")}, result)
+}
+
+func TestTerm_locale(t *testing.T) {
+    t.Setenv("LC_ALL", "fr_FR.UTF-8")
+
+    // shortcut keys are the first letter of "Oui" and "Non"
+    result, err := keys("o").ask("Title", "Question?")
+    assert.Nil(t, err)
+    assert.True(t, result)
+    result, err = keys("N").ask("Title", "Question?")
+    assert.Nil(t, err)
+    assert.False(t, result)
+
+    t.Setenv("LC_ALL", "de_DE.UTF-8")
+    var output strings.Builder
+    term := newTerm(strings.NewReader("\r"), &output, 80, 24)
+    _, ok, err := term.date(DatePicker{
+        Initial:  time.Date(2023, time.March, 1, 0, 0, 0, 0, time.UTC),
+        Location: time.UTC,
+    })
+    assert.Nil(t, err)
+    assert.True(t, ok)
+    assert.Contains(t, output.String(), "März 2023")
+    assert.Contains(t, output.String(), "Mo Di Mi Do Fr Sa So")
+
+    t.Setenv("LC_ALL", "cy_GB.UTF-8")
+    dir := t.TempDir()
+    path := filepath.Join(dir, "a.txt")
+    assert.Nil(t, os.WriteFile(path, nil, 0644))
+    _, msg, ask := FilePicker{ConfirmOverwrite: true}.overwrite(path)
+    assert.True(t, ask)
+    assert.Equal(t, `Mae ffeil o'r enw "a.txt" yn bodoli eisoes. Ydych chi am ei disodli?`, msg)
+}
+
+func TestIsRTL(t *testing.T) {
+    t.Setenv("LC_ALL", "en_GB.UTF-8")
+    assert.False(t, isRTL("Hello"))
+    assert.True(t, isRTL("مرحبا"))
+    assert.True(t, isRTL("שלום"))
+
+    t.Setenv("LC_ALL", "ar_EG.UTF-8")
+    assert.True(t, isRTL("Hello"))
+}
+
+func TestTerm_message(t *testing.T) {
+    t.Setenv("LC_ALL", "en_GB.UTF-8")
+    term := keys("")
+
+    assert.Equal(t, []string{"Hello"}, term.message("Hello"))
+
+    lines := term.message("مرحبا")
+    assert.Len(t, lines, 1)
+    assert.Equal(t, term.inner(), visibleLen(lines[0]))
+    assert.True(t, strings.HasSuffix(lines[0], " مرحبا"))
+}
@@ -8,12 +8,14 @@ import (
     "io"
     "os"
     "os/exec"
+    "path/filepath"
     "regexp"
     "strconv"
     "strings"
     "time"
 
     "github.com/tawesoft/golib/v2/operator"
+    "golang.org/x/sys/unix"
 )
 
 type whiptail struct {
@@ -61,7 +63,9 @@ func tryGetGeometry(shell string) (int, int) {
     return pw, ph
 }
 
-func (x whiptail) getString(title string, label string, placeholder string) (string, bool, error) {
+// show runs whiptail in an xterm with the given arguments, returning the
+// output, and false if there is no output (e.g. the user cancelled).
+func (x whiptail) show(title string, args ... string) (string, bool, error) {
 
     f, err := os.CreateTemp("", "dialog")
     if err != nil {
@@ -73,17 +77,19 @@ func (x whiptail) getString(title string, label string, placeholder string) (str
     if width == 0  { width  = 10 } else { width = (width / 2) - 242 }
     if height == 0 { height = 10 } else { height = (height / 2) - 158 }
 
+    l := locale()
+    command := []string{clean(x.whiptail),
+        "--ok-button", clean(l.OK), "--cancel-button", clean(l.Cancel)}
+    for _, arg := range args {
+        command = append(command, clean(arg))
+    }
+    command = append(command, "--title", clean(title))
+
     cmd := exec.Command(x.xterm,
         "-geometry", fmt.Sprintf("80x24+%d+%d", width, height),
         "-T", title,
         "-e", x.shell, "-l", "-c",
-        strings.Join([]string{
-            clean(x.whiptail),
-                "--inputbox",
-                    clean(label), "8", "70", clean(placeholder),
-                "--title",
-                    clean(title),
-        }, " ") + " 2> " + clean(f.Name()),
+        strings.Join(command, " ") + " 2> " + clean(f.Name()),
     )
     if err := cmd.Run(); err != nil {
         return "", false, fmt.Errorf("xterm/whiptail error: %v", err)
@@ -96,6 +102,57 @@ func (x whiptail) getString(title string, label string, placeholder string) (str
     }
 }
 
+func (x whiptail) getString(title string, label string, placeholder string) (string, bool, error) {
+    return x.show(title, "--inputbox", label, "8", "70", placeholder)
+}
+
+func (x whiptail) text(m TextInput) (string, bool, error) {
+    return x.getString(m.Title, m.Label, m.Initial)
+}
+
+func (x whiptail) password(m PasswordInput) (string, bool, error) {
+    return x.show(m.Title, "--passwordbox", m.Label, "8", "70")
+}
+
+// pickChoice displays a list where the tag of each option is its index.
+func (x whiptail) pickChoice(m Choice, multiple bool) ([]int, bool, error) {
+    rows := len(m.Options)
+    if rows > 14 { rows = 14 }
+
+    var args []string
+    if multiple {
+        args = append(args, "--separate-output", "--checklist")
+    } else {
+        args = append(args, "--radiolist")
+    }
+    args = append(args, m.Label, strconv.Itoa(rows + 8), "70", strconv.Itoa(rows))
+
+    for i, option := range m.Options {
+        selected := "off"
+        if m.selected(i, multiple) { selected = "on" }
+        args = append(args, strconv.Itoa(i), option, selected)
+    }
+
+    result, ok, err := x.show(m.Title, args...)
+    if (err != nil) || !ok { return nil, false, err }
+
+    xs, err := parseIndexes(result, "", len(m.Options))
+    if err != nil { return nil, false, fmt.Errorf("whiptail list parse error: %w", err) }
+    return xs, len(xs) > 0, nil
+}
+
+func (x whiptail) choice(m Choice) (int, bool, error) {
+    xs, ok, err := x.pickChoice(m, false)
+    if (err != nil) || !ok { return 0, false, err }
+    return xs[0], true, nil
+}
+
+func (x whiptail) choiceMultiple(m Choice) ([]int, bool, error) {
+    xs, ok, err := x.pickChoice(m, true)
+    if (err != nil) || !ok { return []int{}, false, err }
+    return xs, true, nil
+}
+
 var whiptailColorPickerRE = regexp.MustCompile(`^#(?P<red>[[:xdigit:]]{2})(?P<green>[[:xdigit:]]{2})(?P<blue>[[:xdigit:]]{2})$`)
 
 func (x whiptail) color(m ColorPicker) (color.Color, bool, error) {
@@ -134,7 +191,7 @@ var whiptailDatePickerRE = regexp.MustCompile(`^(?P<year>\d+)/(?P<month>\d+)/(?P
 
 func (x whiptail) date(m DatePicker) (time.Time, bool, error) {
     if m.Title == "" {
-        m.Title = "Select Date"
+        m.Title = locale().SelectDate
     }
 
     if m.LongTitle == "" {
@@ -168,28 +225,142 @@ func (x whiptail) date(m DatePicker) (time.Time, bool, error) {
     return time.Date(dy, time.Month(dm), dd, 0, 0, 0, 0, m.Location), true, nil
 }
 
-func (x whiptail) open(m FilePicker) (string, bool, error) {
-    path := m.Path
-    if path == "" {
-        cwd, err := os.Getwd()
-        if err != nil {
-            return "", false, fmt.Errorf("error getting working directory: %v", err)
+// confirm asks a yes or no question. This is a menu, because whiptail runs
+// in an xterm that doesn't return the exit status of a --yesno box.
+func (x whiptail) confirm(title string, message string) (bool, error) {
+    l := locale()
+    result, ok, err := x.show(title, "--notags", "--menu", message, "12", "70", "2",
+        "yes", l.Yes,
+        "no",  l.No,
+    )
+    return ok && (result == "yes"), err
+}
+
+// question shows a menu of buttons, for the same reason as confirm. whiptail
+// can't show a checkbox.
+func (x whiptail) question(m Question, message string) (int, bool, error) {
+    args := []string{"--notags", "--default-item", strconv.Itoa(m.Default),
+        "--menu", message, "16", "70", strconv.Itoa(len(m.Buttons))}
+    for i, label := range m.Buttons {
+        args = append(args, strconv.Itoa(i), label)
+    }
+
+    result, ok, err := x.show(m.Title, args...)
+    if (err != nil) || !ok { return -1, false, err }
+
+    i, err := strconv.Atoi(result)
+    if (err != nil) || (i < 0) || (i >= len(m.Buttons)) {
+        return -1, false, fmt.Errorf("whiptail menu parse error parsing %q", result)
+    }
+    return i, false, nil
+}
+
+// pickFile asks for a path in an input box, because whiptail doesn't have a
+// file picker, and asks again until the path is acceptable. File types are
+// not checked.
+func (x whiptail) pickFile(m FilePicker, mode rune) (string, bool, error) {
+    dir, name, err := m.location()
+    if err != nil { return "", false, err }
+
+    directory := m.Directory && (mode != 's')
+    prompt := "Enter a file path:"
+    if directory { prompt = "Enter a directory path:" }
+
+    label := prompt
+    initial := strings.TrimSuffix(dir, "/") + "/"
+    if name != "" { initial = filepath.Join(dir, name) }
+
+    for {
+        result, ok, err := x.getString(m.Title, label, initial)
+        if (err != nil) || !ok { return "", false, err }
+        initial = result
+
+        path := result
+        if !filepath.IsAbs(path) { path = filepath.Join(dir, path) }
+        info, statErr := os.Stat(path)
+
+        switch {
+            case (mode != 's') && (statErr != nil):
+                label = fmt.Sprintf("Not found: %s\n%s", path, prompt)
+            case (mode != 's') && directory && !info.IsDir():
+                label = fmt.Sprintf("Not a directory: %s\n%s", path, prompt)
+            case (statErr == nil) && !directory && info.IsDir():
+                label = fmt.Sprintf("Is a directory: %s\n%s", path, prompt)
+            default:
+                if q, msg, ask := m.overwrite(path); ask && (mode == 's') {
+                    if yes, err := x.confirm(q.Title, msg); err != nil {
+                        return "", false, err
+                    } else if !yes {
+                        continue
+                    }
+                }
+                return path, true, nil
         }
-        path = cwd
     }
+}
 
-    return x.getString(m.Title, m.Title, path)
+func (x whiptail) open(m FilePicker) (string, bool, error) {
+    return x.pickFile(m, 'o')
 }
 
 func (x whiptail) save(m FilePicker) (string, bool, error) {
-    path := m.Path
-    if path == "" {
-        cwd, err := os.Getwd()
-        if err != nil {
-            return "", false, fmt.Errorf("error getting working directory: %v", err)
-        }
-        path = cwd
+    return x.pickFile(m, 's')
+}
+
+func (x whiptail) progress(m Progress) (ProgressUpdater, error) {
+    // whiptail runs inside an xterm, so updates are sent through a named pipe
+    dir, err := os.MkdirTemp("", "dialog")
+    if err != nil {
+        return nil, fmt.Errorf("error creating temporary communication directory %w", err)
+    }
+    fifo := filepath.Join(dir, "progress")
+    if err := unix.Mkfifo(fifo, 0600); err != nil {
+        os.RemoveAll(dir)
+        return nil, fmt.Errorf("error creating named pipe %w", err)
+    }
+
+    // opening for reading and writing doesn't block waiting for a reader
+    w, err := os.OpenFile(fifo, os.O_RDWR, 0)
+    if err != nil {
+        os.RemoveAll(dir)
+        return nil, fmt.Errorf("error opening named pipe %w", err)
+    }
+
+    width, height := tryGetGeometry(x.shell)
+    if width == 0  { width  = 10 } else { width = (width / 2) - 242 }
+    if height == 0 { height = 10 } else { height = (height / 2) - 158 }
+
+    cmd := exec.Command(x.xterm,
+        "-geometry", fmt.Sprintf("80x24+%d+%d", width, height),
+        "-T", m.Title,
+        "-e", x.shell, "-l", "-c",
+        strings.Join([]string{
+            clean(x.whiptail),
+                "--gauge", clean(m.Text), "8", "70", "0",
+                "--title", clean(m.Title),
+        }, " ") + " < " + clean(fifo),
+    )
+
+    p := &pipeProgress{
+        name: "xterm/whiptail",
+        cmd:  cmd,
+        w:    w,
+        text: m.Text,
+        formatPercent: func(percent int, _ string) string {
+            return fmt.Sprintf("%d\n", percent)
+        },
+        formatText: func(percent int, text string) string {
+            return fmt.Sprintf("XXX\n%d\n%s\nXXX\n", percent, text)
+        },
+    }
+    if err := p.start(); err != nil {
+        os.RemoveAll(dir)
+        return nil, err
     }
 
-    return x.getString(m.Title, m.Title, path)
+    go func() {
+        <-p.exited
+        os.RemoveAll(dir)
+    }()
+    return p, nil
 }
@@ -14,7 +14,7 @@ type xmessage struct {
     path string
 }
 
-func (x xmessage) exec(m Message, buttons []string) *exec.Cmd {
+func (x xmessage) exec(title string, buttons []string, focus int) *exec.Cmd {
     var btns strings.Builder
     for i, b := range buttons {
         btns.WriteString(fmt.Sprintf("%s:%d", b, i + 1))
@@ -26,10 +26,10 @@ func (x xmessage) exec(m Message, buttons []string) *exec.Cmd {
     return exec.Command(x.path,
         "-xrm",     "*international:true", // unicode support
         "-xrm",     "*fontSet:-*-fixed-medium-r-normal-*-20-*-*-*-*-*-*-*,-*-fixed-*-*-*-*-20-*-*-*-*-*-*-*,-*-*-*-*-*-*-20-*-*-*-*-*-*-*",
-        "-title",   m.Title,
+        "-title",   title,
         "-g",       "600x400", // bigger window
         "-buttons", btns.String(), // return code is 100 + button code (unreliable)
-        "-default", buttons[0],   // set focus
+        "-default", buttons[focus], // set focus
         "-center",
         "-print",        // return code isn't working properly!
         "-file",    "-", // read from stdin
@@ -39,14 +39,15 @@ func (x xmessage) exec(m Message, buttons []string) *exec.Cmd {
 func (x xmessage) ask(m Message, message string) (bool, error) {
     var output strings.Builder
     message = ks.WrapBlock(message, 56)
-    cmd := x.exec(m, []string{"Yes", "No"})
+    l := locale()
+    cmd := x.exec(m.Title, []string{l.Yes, l.No}, 0)
     cmd.Stdin = strings.NewReader(message)
     cmd.Stdout = &output
 
     if err := cmd.Run(); err == nil {
         // supposed to have a return code, but -print the label works too
         clicked := strings.TrimSpace(output.String())
-        if clicked == "Yes" {
+        if clicked == l.Yes {
             return true, nil
         } else {
             return false, nil
@@ -70,7 +71,7 @@ func (x xmessage) ask(m Message, message string) (bool, error) {
 
 func (x xmessage) raise(m Message, message string) error {
     message = ks.WrapBlock(message, 56)
-    cmd := x.exec(m, []string{"OK"})
+    cmd := x.exec(m.Title, []string{locale().OK}, 0)
     cmd.Stdin = strings.NewReader(message)
 
     if err := cmd.Run(); err == nil {
@@ -85,3 +86,29 @@ func (x xmessage) raise(m Message, message string) error {
         }
     }
 }
+
+// question can't show a checkbox.
+func (x xmessage) question(m Question, message string) (int, bool, error) {
+    // labels can't contain a comma or colon, because xmessage uses those
+    labels := make([]string, len(m.Buttons))
+    for i, label := range m.Buttons {
+        labels[i] = strings.NewReplacer(",", " ", ":", " ").Replace(label)
+    }
+
+    var output strings.Builder
+    message = ks.WrapBlock(message, 56)
+    cmd := x.exec(m.Title, labels, m.Default)
+    cmd.Stdin = strings.NewReader(message)
+    cmd.Stdout = &output
+
+    if err := cmd.Run(); err != nil {
+        if _, ok := err.(*exec.ExitError); !ok { return -1, false, err }
+    }
+
+    // -print the label works, regardless of the return code
+    clicked := strings.TrimSpace(output.String())
+    for i, label := range labels {
+        if label == clicked { return i, false, nil }
+    }
+    return -1, false, nil // assume X close button press
+}
@@ -0,0 +1,367 @@
+//go:build (linux || unix)
+
+package dialog
+
+import (
+    "fmt"
+    "image/color"
+    "os/exec"
+    "path/filepath"
+    "regexp"
+    "strconv"
+    "strings"
+    "time"
+)
+
+// yad ("yet another dialog") is a fork of zenity with more features.
+type yad struct {
+    path string
+}
+
+func (x yad) iconString(i IconType) string {
+    switch i {
+        case IconInfo: return "dialog-information"
+        case IconWarning: return "dialog-warning"
+        case IconError: return "dialog-error"
+        default: return "dialog-information"
+    }
+}
+
+func (x yad) run(args ... string) (string, bool, error) {
+    var sb strings.Builder
+    cmd := exec.Command(x.path, args...)
+    cmd.Stdout = &sb
+
+    if err := cmd.Run(); err != nil {
+        // 1 is the cancel button, 252 is the window close button
+        if ExitError, ok := err.(*exec.ExitError); ok && ((ExitError.ExitCode() == 1) || (ExitError.ExitCode() == 252)) {
+            return "", false, nil
+        } else {
+            return "", false, fmt.Errorf("yad error: %w", err)
+        }
+    }
+
+    return strings.TrimRight(sb.String(), "\n"), true, nil
+}
+
+func (x yad) ask(m Message, message string) (bool, error) {
+    _, ok, err := x.run(
+        "--title",       m.Title,
+        "--text",        message,
+        "--no-markup",
+        "--width",       "480",
+        "--image",       "dialog-question",
+        "--window-icon", "dialog-question",
+        "--button",      "yad-yes:0",
+        "--button",      "yad-no:1",
+    )
+    return ok, err
+}
+
+// question exits with an even code for each button, so that yad also prints
+// the state of the checkbox form field, if any. yad can't set a default
+// button.
+func (x yad) question(m Question, message string) (int, bool, error) {
+    icon := "dialog-question"
+    if m.Icon != IconInfo { icon = x.iconString(m.Icon) }
+
+    args := []string{
+        "--title",       m.Title,
+        "--text",        message,
+        "--no-markup",
+        "--width",       "480",
+        "--image",       icon,
+        "--window-icon", icon,
+    }
+    if m.Checkbox != "" {
+        // field labels can't contain a colon, because yad uses that
+        args = append(args, "--form", "--field", strings.ReplaceAll(m.Checkbox, ":", " ") + ":CHK", "FALSE")
+    }
+    for i, label := range m.Buttons {
+        // labels can't contain a colon, because yad uses that
+        args = append(args, "--button", fmt.Sprintf("%s:%d", strings.ReplaceAll(label, ":", " "), 10 + (2 * i)))
+    }
+
+    var sb strings.Builder
+    cmd := exec.Command(x.path, args...)
+    cmd.Stdout = &sb
+
+    code := 0
+    if err := cmd.Run(); err != nil {
+        ExitError, ok := err.(*exec.ExitError)
+        if !ok { return -1, false, fmt.Errorf("yad error: %w", err) }
+        code = ExitError.ExitCode()
+    }
+
+    i := (code - 10) / 2
+    if (code < 10) || (code % 2 != 0) || (i >= len(m.Buttons)) {
+        return -1, false, nil // e.g. 252 for the window close button
+    }
+    return i, strings.HasPrefix(sb.String(), "TRUE"), nil
+}
+
+func (x yad) raise(m Message, message string) error {
+    _, _, err := x.run(
+        "--title",       m.Title,
+        "--text",        message,
+        "--no-markup",
+        "--width",       "480",
+        "--image",       x.iconString(m.Icon),
+        "--window-icon", x.iconString(m.Icon),
+        "--button",      "yad-ok:0",
+    )
+    return err
+}
+
+var yadColorPickerRE = regexp.MustCompile(`^#(?P<red>[[:xdigit:]]{2})(?P<green>[[:xdigit:]]{2})(?P<blue>[[:xdigit:]]{2})`)
+
+func (x yad) color(m ColorPicker) (color.Color, bool, error) {
+    var zero color.Color
+
+    args := []string{
+        "--color",
+        "--title", m.Title,
+    }
+
+    if m.Palette {
+        args = append(args, "--palette")
+    }
+
+    if m.Initial != zero {
+        r, g, b, _ := m.Initial.RGBA()
+        r /= 256; g /= 256; b /= 256;
+        args = append(args, "--init-color", fmt.Sprintf("#%02x%02x%02x", r, g, b))
+    }
+
+    result, ok, err := x.run(args...)
+    if (err != nil) || !ok { return zero, false, err }
+
+    result = strings.TrimSpace(result)
+    matches := yadColorPickerRE.FindStringSubmatch(result)
+    if len(matches) != 4 {
+        return zero, false, fmt.Errorf("yad --color parse error parsing %q", result)
+    }
+    cr, errR := strconv.ParseUint(matches[1], 16, 8)
+    cg, errG := strconv.ParseUint(matches[2], 16, 8)
+    cb, errB := strconv.ParseUint(matches[3], 16, 8)
+    if (errR != nil) || (errG != nil) || (errB != nil) {
+        return zero, false, fmt.Errorf("yad --color parse error parsing %q", result)
+    }
+
+    return color.RGBA{
+        R: uint8(cr),
+        G: uint8(cg),
+        B: uint8(cb),
+        A: 255,
+    }, true, nil
+}
+
+func (x yad) date(m DatePicker) (time.Time, bool, error) {
+    var zero time.Time
+
+    args := []string{
+        "--calendar",
+        "--day",         strconv.Itoa(m.Initial.Day()),
+        "--month",       strconv.Itoa(int(m.Initial.Month())),
+        "--year",        strconv.Itoa(m.Initial.Year()),
+        "--date-format", "%Y%m%d",
+    }
+
+    if len(m.Title) > 0 {
+        args = append(args, "--title", m.Title)
+    }
+
+    if len(m.LongTitle) > 0 {
+        args = append(args, "--text", m.LongTitle)
+    }
+
+    result, ok, err := x.run(args...)
+    if (err != nil) || !ok { return zero, false, err }
+
+    t, err := time.ParseInLocation("20060102", strings.TrimSpace(result), m.Location)
+    if err != nil {
+        return zero, false, fmt.Errorf("yad --calendar format error: %w", err)
+    }
+    return t, true, nil
+}
+
+func (x yad) pickFile(
+    m FilePicker,
+    mode rune, // (o)pen, (m)ultiple, (s)ave
+) ([]string, bool, error) {
+    dir, name, err := m.location()
+    if err != nil { return nil, false, err }
+
+    // a trailing slash opens a directory without selecting a file
+    path := strings.TrimSuffix(dir, "/") + "/"
+    if name != "" { path = filepath.Join(dir, name) }
+
+    const sep = "\n"
+
+    args := []string{
+        "--file",
+        "--title",     m.Title,
+        "--filename",  path,
+        "--separator", sep,
+        "--width",     "800",
+        "--height",    "600",
+    }
+
+    if mode == 'm' {
+        args = append(args, "--multiple")
+    } else if mode == 's' {
+        args = append(args, "--save")
+        if m.ConfirmOverwrite { args = append(args, "--confirm-overwrite") }
+    }
+
+    if m.Directory && (mode != 's') {
+        args = append(args, "--directory")
+    } else {
+        for _, f := range m.FileTypes {
+            // name can't contain a stave, because yad uses that
+            name, patterns := strings.ReplaceAll(f[0], "|", "/"), globs(f[1])
+
+            args = append(args, "--file-filter", fmt.Sprintf("%s (%s) | %s",
+                name,
+                strings.Join(patterns, ", "),
+                strings.Join(patterns, " "),
+            ))
+        }
+    }
+
+    result, ok, err := x.run(args...)
+    if (err != nil) || !ok { return nil, false, err }
+    if len(result) == 0 { return nil, false, nil }
+
+    if mode == 'm' {
+        return strings.Split(result, sep), true, nil
+    } else {
+        return []string{result}, true, nil
+    }
+}
+
+func (x yad) open(m FilePicker) (string, bool, error) {
+    if xs, ok, err := x.pickFile(m, 'o'); err != nil {
+        return "", false, fmt.Errorf("error opening file picker: %w", err)
+    } else if !ok || (len(xs) == 0) {
+        return "", false, nil
+    } else {
+        return xs[0], true, nil
+    }
+}
+
+func (x yad) openMultiple(m FilePicker) ([]string, bool, error) {
+    if xs, ok, err := x.pickFile(m, 'm'); err != nil {
+        return []string{}, false, fmt.Errorf("error opening file picker: %w", err)
+    } else if !ok || (len(xs) == 0) {
+        return []string{}, false, nil
+    } else {
+        return xs, true, nil
+    }
+}
+
+func (x yad) save(m FilePicker) (string, bool, error) {
+    if xs, ok, err := x.pickFile(m, 's'); err != nil {
+        return "", false, fmt.Errorf("error opening save file picker: %w", err)
+    } else if !ok || (len(xs) == 0) {
+        return "", false, nil
+    } else {
+        return xs[0], true, nil
+    }
+}
+
+func (x yad) text(m TextInput) (string, bool, error) {
+    return x.run(
+        "--entry",
+        "--title",      m.Title,
+        "--text",       m.Label,
+        "--entry-text", m.Initial,
+    )
+}
+
+func (x yad) password(m PasswordInput) (string, bool, error) {
+    return x.run(
+        "--entry",
+        "--hide-text",
+        "--title", m.Title,
+        "--text",  m.Label,
+    )
+}
+
+// pickChoice displays a list with a hidden column of option indexes, which
+// is the column printed for each chosen option.
+func (x yad) pickChoice(m Choice, multiple bool) ([]int, bool, error) {
+    mode, kind := "--radiolist", ":RD"
+    if multiple { mode, kind = "--checklist", ":CHK" }
+
+    args := []string{
+        "--list",
+        mode,
+        "--title",        m.Title,
+        "--text",         m.Label,
+        "--column",       kind,
+        "--column",       "#:NUM",
+        "--column",       ":TEXT",
+        "--hide-column",  "2",
+        "--print-column", "2",
+        "--no-headers",
+        "--separator",    "|",
+        "--height",       "320",
+    }
+
+    for i, option := range m.Options {
+        selected := "FALSE"
+        if m.selected(i, multiple) { selected = "TRUE" }
+        args = append(args, selected, strconv.Itoa(i), option)
+    }
+
+    result, ok, err := x.run(args...)
+    if (err != nil) || !ok { return nil, false, err }
+
+    xs, err := parseIndexes(result, "|", len(m.Options))
+    if err != nil { return nil, false, fmt.Errorf("yad --list parse error: %w", err) }
+    return xs, len(xs) > 0, nil
+}
+
+func (x yad) choice(m Choice) (int, bool, error) {
+    xs, ok, err := x.pickChoice(m, false)
+    if (err != nil) || !ok { return 0, false, err }
+    return xs[0], true, nil
+}
+
+func (x yad) choiceMultiple(m Choice) ([]int, bool, error) {
+    xs, ok, err := x.pickChoice(m, true)
+    if (err != nil) || !ok { return []int{}, false, err }
+    return xs, true, nil
+}
+
+func (x yad) progress(m Progress) (ProgressUpdater, error) {
+    args := []string{
+        "--progress",
+        "--no-markup",
+        "--width",      "480",
+        "--title",      m.Title,
+        "--text",       m.Text,
+        "--percentage", "0",
+    }
+    if m.Pulsate  { args = append(args, "--pulsate") }
+    if m.NoCancel { args = append(args, "--no-buttons") }
+
+    cmd := exec.Command(x.path, args...)
+    w, err := cmd.StdinPipe()
+    if err != nil { return nil, fmt.Errorf("yad error: %w", err) }
+
+    p := &pipeProgress{
+        name: "yad",
+        cmd:  cmd,
+        w:    w,
+        text: m.Text,
+        formatPercent: func(percent int, _ string) string {
+            return fmt.Sprintf("%d\n", percent)
+        },
+        formatText: func(_ int, text string) string {
+            return "# " + text + "\n"
+        },
+    }
+    return p, p.start()
+}
@@ -5,8 +5,8 @@ package dialog
 import (
     "fmt"
     "image/color"
-    "os"
     "os/exec"
+    "path/filepath"
     "regexp"
     "strconv"
     "strings"
@@ -36,7 +36,7 @@ func (x zenity) ask(m Message, message string) (bool, error) {
         "--text",        message,
     }
 
-    if m.Title != "Question" {
+    if m.Title != locale().Question {
         args = append(args, "--title", m.Title)
     }
 
@@ -53,6 +53,42 @@ func (x zenity) ask(m Message, message string) (bool, error) {
     return true, nil // "yes"
 }
 
+// question shows only "extra" buttons, which print their label when clicked.
+// zenity can't show a checkbox, or set a default button.
+func (x zenity) question(m Question, message string) (int, bool, error) {
+    icon := "dialog-question"
+    switch m.Icon {
+        case IconWarning: icon = "dialog-warning"
+        case IconError:   icon = "dialog-error"
+    }
+
+    args := []string{
+        "--question",
+        "--switch",
+        "--no-markup",
+        "--width",       "480",
+        "--icon-name",   icon,
+        "--window-icon", "question",
+        "--text",        message,
+        "--title",       m.Title,
+    }
+    for _, label := range m.Buttons {
+        args = append(args, "--extra-button", label)
+    }
+
+    var sb strings.Builder
+    cmd := exec.Command(x.path, args...)
+    cmd.Stdout = &sb
+
+    if err := cmd.Run(); err != nil {
+        if ExitError, ok := err.(*exec.ExitError); !ok || (ExitError.ExitCode() != 1) {
+            return -1, false, fmt.Errorf("zenity error: %w", err)
+        }
+    }
+
+    return m.button(strings.TrimRight(sb.String(), "\n")), false, nil
+}
+
 func (x zenity) raise(m Message, message string) error {
     args := []string{
         "--"+x.iconString(m.Icon),
@@ -62,7 +98,7 @@ func (x zenity) raise(m Message, message string) error {
         "--text",        message,
     }
 
-    if (m.Title != x.iconString(m.Icon)) && (m.Title != "Message")  {
+    if (m.Title != x.iconString(m.Icon)) && (m.Title != locale().Message) {
         args = append(args, "--title", m.Title)
     }
 
@@ -177,45 +213,43 @@ func (x zenity) pickFile(
     m FilePicker,
     mode rune, // (o)pen, (m)ultiple, (s)ave
 ) ([]string, bool, error) {
-    path := m.Path
-    if path == "" {
-        cwd, err := os.Getwd()
-        if err != nil {
-            return nil, false, fmt.Errorf("error getting working directory: %v", err)
-        }
-        path = cwd + "/"
-    }
+    dir, name, err := m.location()
+    if err != nil { return nil, false, err }
+
+    // a trailing slash opens a directory without selecting a file
+    path := strings.TrimSuffix(dir, "/") + "/"
+    if name != "" { path = filepath.Join(dir, name) }
 
-    const sep = ","
+    const sep = "\n"
 
     args := []string{
         "--file-selection",
+        "--title", m.Title,
         "--filename", path,
-        "--separator", ",",
+        "--separator", sep,
     }
 
     if mode == 'm' {
         args = append(args, "--multiple")
     } else if mode == 's' {
         args = append(args, "--save")
+        if m.ConfirmOverwrite { args = append(args, "--confirm-overwrite") }
     }
 
-    // append filters...
-    for _, f := range m.FileTypes {
-        name, patterns := f[0], f[1]
-
-        // name can't contain a stave, because zenity uses that
-        // TODO we should clean the string rather than skip
-        if strings.ContainsRune(name, '|') { continue }
-
-        var filter strings.Builder
-        filter.WriteString(fmt.Sprintf("%s (%s)",
-            name,
-            strings.Join(strings.Split(patterns, " "), ", "),
-        ))
-        filter.WriteString(" | ")
-        filter.WriteString(patterns) // space separated is fine as-is
-        args = append(args, "--file-filter", filter.String())
+    if m.Directory && (mode != 's') {
+        args = append(args, "--directory")
+    } else {
+        // append filters...
+        for _, f := range m.FileTypes {
+            // name can't contain a stave, because zenity uses that
+            name, patterns := strings.ReplaceAll(f[0], "|", "/"), globs(f[1])
+
+            args = append(args, "--file-filter", fmt.Sprintf("%s (%s) | %s",
+                name,
+                strings.Join(patterns, ", "),
+                strings.Join(patterns, " "),
+            ))
+        }
     }
 
     var sb strings.Builder
@@ -228,7 +262,7 @@ func (x zenity) pickFile(
             return nil, false, fmt.Errorf("zenity error: %w", err)
         }
     } else {
-        f := sb.String()
+        f := strings.TrimRight(sb.String(), "\n")
         if len(f) == 0 {
             return nil, false, nil
         }
@@ -281,3 +315,116 @@ func (x zenity) save(m FilePicker) (string, bool, error) {
         }
     }
 }
+
+// run runs zenity, returning its output, or false if cancelled.
+func (x zenity) run(args ... string) (string, bool, error) {
+    var sb strings.Builder
+    cmd := exec.Command(x.path, args...)
+    cmd.Stdout = &sb
+
+    if err := cmd.Run(); err != nil {
+        if ExitError, ok := err.(*exec.ExitError); ok && (ExitError.ExitCode() == 1) {
+            return "", false, nil // cancel
+        } else {
+            return "", false, fmt.Errorf("zenity error: %w", err)
+        }
+    }
+
+    return strings.TrimRight(sb.String(), "\n"), true, nil
+}
+
+func (x zenity) text(m TextInput) (string, bool, error) {
+    return x.run(
+        "--entry",
+        "--title",      m.Title,
+        "--text",       m.Label,
+        "--entry-text", m.Initial,
+    )
+}
+
+func (x zenity) password(m PasswordInput) (string, bool, error) {
+    return x.run(
+        "--entry",
+        "--hide-text",
+        "--title", m.Title,
+        "--text",  m.Label,
+    )
+}
+
+// pickChoice displays a list with a hidden column of option indexes, which
+// is the column printed for each chosen option.
+func (x zenity) pickChoice(m Choice, multiple bool) ([]int, bool, error) {
+    mode := "--radiolist"
+    if multiple { mode = "--checklist" }
+
+    args := []string{
+        "--list",
+        mode,
+        "--title",        m.Title,
+        "--text",         m.Label,
+        "--column",       "",
+        "--column",       "#",
+        "--column",       "",
+        "--hide-column",  "2",
+        "--print-column", "2",
+        "--hide-header",
+        "--separator",    "\n",
+        "--height",       "320",
+    }
+
+    for i, option := range m.Options {
+        selected := "FALSE"
+        if m.selected(i, multiple) { selected = "TRUE" }
+        args = append(args, selected, strconv.Itoa(i), option)
+    }
+
+    result, ok, err := x.run(args...)
+    if (err != nil) || !ok { return nil, false, err }
+
+    xs, err := parseIndexes(result, "|", len(m.Options))
+    if err != nil { return nil, false, fmt.Errorf("zenity --list parse error: %w", err) }
+    return xs, len(xs) > 0, nil
+}
+
+func (x zenity) choice(m Choice) (int, bool, error) {
+    xs, ok, err := x.pickChoice(m, false)
+    if (err != nil) || !ok { return 0, false, err }
+    return xs[0], true, nil
+}
+
+func (x zenity) choiceMultiple(m Choice) ([]int, bool, error) {
+    xs, ok, err := x.pickChoice(m, true)
+    if (err != nil) || !ok { return []int{}, false, err }
+    return xs, true, nil
+}
+
+func (x zenity) progress(m Progress) (ProgressUpdater, error) {
+    args := []string{
+        "--progress",
+        "--no-markup",
+        "--width",      "480",
+        "--title",      m.Title,
+        "--text",       m.Text,
+        "--percentage", "0",
+    }
+    if m.Pulsate  { args = append(args, "--pulsate") }
+    if m.NoCancel { args = append(args, "--no-cancel") }
+
+    cmd := exec.Command(x.path, args...)
+    w, err := cmd.StdinPipe()
+    if err != nil { return nil, fmt.Errorf("zenity error: %w", err) }
+
+    p := &pipeProgress{
+        name: "zenity",
+        cmd:  cmd,
+        w:    w,
+        text: m.Text,
+        formatPercent: func(percent int, _ string) string {
+            return fmt.Sprintf("%d\n", percent)
+        },
+        formatText: func(_ int, text string) string {
+            return "# " + text + "\n"
+        },
+    }
+    return p, p.start()
+}
@@ -1,9 +1,16 @@
 // Package either implements a simple generic "Either" type that can represent
-// exactly one value out of two options.
+// exactly one value out of two options, and the types E3, E4 and E5 that can
+// represent exactly one value out of three, four, or five options.
+//
+// These are "sum types". Use the Match and Fold functions (and Match3, Fold3,
+// etc.) to handle every possible case.
 package either
 
 import (
     "fmt"
+
+    "github.com/tawesoft/golib/v2/fun/maybe"
+    "github.com/tawesoft/golib/v2/fun/result"
 )
 
 // E represents a type that can hold either a value "a" of type A or a
@@ -69,3 +76,102 @@ func (e E[A, B]) B() (result B, ok bool) {
     }
     return
 }
+
+// invalid returns an error for a function called with a zero-value either
+// type, which holds no value.
+func invalid(name string, e any) error {
+    return fmt.Errorf("either: %s(): invalid index value for %T", name, e)
+}
+
+// Match calls function fa with the value "a" if the E contains a value of
+// type A, or function fb with the value "b" if the E contains a value of type
+// B. Because a handler must be provided for each option, every case is
+// handled.
+//
+// Match panics if the E is the zero value, which holds no value.
+func Match[A any, B any](e E[A, B], fa func(A), fb func(B)) {
+    switch e.index {
+        case 'a': fa(e.a)
+        case 'b': fb(e.b)
+        default: panic(invalid("Match", e))
+    }
+}
+
+// Fold is like [Match], but each function returns a value of the same type,
+// which is returned by Fold.
+func Fold[A any, B any, R any](e E[A, B], fa func(A) R, fb func(B) R) R {
+    switch e.index {
+        case 'a': return fa(e.a)
+        case 'b': return fb(e.b)
+        default: panic(invalid("Fold", e))
+    }
+}
+
+// Map returns a function that maps an E[A, B] to an E[C, D], by calling
+// function fa on the value "a" if the E contains a value of type A, or
+// function fb on the value "b" if the E contains a value of type B.
+func Map[A any, B any, C any, D any](
+    fa func(A) C,
+    fb func(B) D,
+) func(E[A, B]) E[C, D] {
+    return func(e E[A, B]) E[C, D] {
+        switch e.index {
+            case 'a': return E[C, D]{a: fa(e.a), index: 'a'}
+            case 'b': return E[C, D]{b: fb(e.b), index: 'b'}
+            default: panic(invalid("Map", e))
+        }
+    }
+}
+
+// MapA returns a function that maps an E[A, B] to an E[C, B], by calling
+// function f on the value "a" if the E contains a value of type A. Otherwise,
+// the value "b" is unchanged.
+func MapA[A any, B any, C any](f func(A) C) func(E[A, B]) E[C, B] {
+    return Map(f, func(b B) B { return b })
+}
+
+// MapB returns a function that maps an E[A, B] to an E[A, D], by calling
+// function f on the value "b" if the E contains a value of type B. Otherwise,
+// the value "a" is unchanged.
+func MapB[A any, B any, D any](f func(B) D) func(E[A, B]) E[A, D] {
+    return Map(func(a A) A { return a }, f)
+}
+
+// FromResult returns an E that holds the value of a [result.R] as the value
+// "a", or the error of a result.R as the value "b".
+func FromResult[V any](r result.R[V]) E[V, error] {
+    if r.Success() { return A[V, error](r.Value) }
+    return B[V, error](r.Error)
+}
+
+// ToResult returns a [result.R] that holds the value "a" of the E, if it
+// contains a value of type V, or the error "b" of the E otherwise.
+//
+// A nil error "b" becomes a result.R that holds the zero value and is not an
+// error.
+func ToResult[V any](e E[V, error]) result.R[V] {
+    switch e.index {
+        case 'a': return result.Some(e.a)
+        case 'b': return result.Error[V](e.b)
+        default: panic(invalid("ToResult", e))
+    }
+}
+
+// FromMaybe returns an E that holds the value of a [maybe.M] as the value
+// "a", if it has a value, or the value "b" otherwise.
+func FromMaybe[X any, Y any](m maybe.M[X], b Y) E[X, Y] {
+    if m.Ok { return A[X, Y](m.Value) }
+    return B[X, Y](b)
+}
+
+// ToMaybeA returns a [maybe.M] that holds the value "a" of the E, if it
+// contains a value of type A, or nothing otherwise.
+func ToMaybeA[A any, B any](e E[A, B]) maybe.M[A] {
+    return maybe.New(e.A())
+}
+
+// ToMaybeB returns a [maybe.M] that holds the value "b" of the E, if it
+// contains a value of type B, or nothing otherwise.
+func ToMaybeB[A any, B any](e E[A, B]) maybe.M[B] {
+    return maybe.New(e.B())
+}
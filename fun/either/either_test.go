@@ -0,0 +1,120 @@
+package either_test
+
+import (
+    "encoding/json"
+    "errors"
+    "testing"
+
+    "github.com/tawesoft/golib/v2/fun/either"
+    "github.com/tawesoft/golib/v2/fun/maybe"
+    "github.com/tawesoft/golib/v2/fun/result"
+)
+
+func TestE_JSON(t *testing.T) {
+    type E = either.E[int, string]
+
+    tests := []struct {
+        e    E
+        json string
+    }{
+        {either.A[int, string](7), `{"type":"a","value":7}`},
+        {either.B[int, string]("x"), `{"type":"b","value":"x"}`},
+    }
+
+    for i, tt := range tests {
+        data, err := json.Marshal(tt.e)
+        if err != nil {
+            t.Errorf("test %d: unexpected marshal error: %v", i, err)
+            continue
+        }
+        if string(data) != tt.json {
+            t.Errorf("test %d: got %s, expected %s", i, data, tt.json)
+        }
+
+        var e E
+        if err := json.Unmarshal(data, &e); err != nil {
+            t.Errorf("test %d: unexpected unmarshal error: %v", i, err)
+        } else if e != tt.e {
+            t.Errorf("test %d: got %v, expected %v", i, e, tt.e)
+        }
+    }
+
+    var e E
+    if _, err := json.Marshal(e); err == nil {
+        t.Errorf("expected error marshalling zero value")
+    }
+    if err := json.Unmarshal([]byte(`{"type":"c","value":1}`), &e); err == nil {
+        t.Errorf("expected error for unknown discriminator")
+    }
+    if err := json.Unmarshal([]byte(`{"type":"a","value":"x"}`), &e); err == nil {
+        t.Errorf("expected error for wrong value type")
+    }
+}
+
+func TestE5_JSON(t *testing.T) {
+    type E = either.E5[int, string, bool, float64, []int]
+
+    in := either.E5D[int, string, bool, float64, []int](1.5)
+    data, err := json.Marshal(in)
+    if err != nil { t.Fatalf("unexpected marshal error: %v", err) }
+    if string(data) != `{"type":"d","value":1.5}` {
+        t.Errorf("got %s", data)
+    }
+
+    var out E
+    if err := json.Unmarshal(data, &out); err != nil {
+        t.Fatalf("unexpected unmarshal error: %v", err)
+    }
+    if d, ok := out.D(); !ok || d != 1.5 {
+        t.Errorf("got %v, %t", d, ok)
+    }
+}
+
+func TestMatch(t *testing.T) {
+    defer func() {
+        if recover() == nil {
+            t.Errorf("expected panic on zero value")
+        }
+    }()
+
+    var got string
+    either.Match4(either.E4C[int, int, string, int]("c"),
+        func(int) { got = "a" },
+        func(int) { got = "b" },
+        func(s string) { got = s },
+        func(int) { got = "d" },
+    )
+    if got != "c" { t.Errorf("got %q", got) }
+
+    either.Match(either.E[int, int]{}, func(int) {}, func(int) {})
+}
+
+func TestConversions(t *testing.T) {
+    errFoo := errors.New("foo")
+
+    if v, ok := either.FromResult(result.Some(1)).A(); !ok || v != 1 {
+        t.Errorf("FromResult(Some): got %v, %t", v, ok)
+    }
+    if err, ok := either.FromResult(result.Error[int](errFoo)).B(); !ok || err != errFoo {
+        t.Errorf("FromResult(Error): got %v, %t", err, ok)
+    }
+    if r := either.ToResult(either.A[int, error](2)); !r.Success() || r.Value != 2 {
+        t.Errorf("ToResult(A): got %v", r)
+    }
+    if r := either.ToResult(either.B[int, error](errFoo)); r.Error != errFoo {
+        t.Errorf("ToResult(B): got %v", r)
+    }
+
+    if v, ok := either.FromMaybe(maybe.Some(3), "none").A(); !ok || v != 3 {
+        t.Errorf("FromMaybe(Some): got %v, %t", v, ok)
+    }
+    if v, ok := either.FromMaybe(maybe.Nothing[int](), "none").B(); !ok || v != "none" {
+        t.Errorf("FromMaybe(Nothing): got %v, %t", v, ok)
+    }
+    if m := either.ToMaybeA(either.B[int, string]("x")); m.Ok {
+        t.Errorf("ToMaybeA(B): got %v", m)
+    }
+    if m := either.ToMaybeB(either.B[int, string]("x")); !m.Ok || m.Value != "x" {
+        t.Errorf("ToMaybeB(B): got %v", m)
+    }
+}
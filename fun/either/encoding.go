@@ -0,0 +1,157 @@
+package either
+
+import (
+    "encoding/json"
+    "fmt"
+    "reflect"
+)
+
+// Tagger may be implemented by the type of a value held by an E, E3, E4, or
+// E5, to name that option in the discriminator field used for JSON encoding.
+//
+// EitherTag must return a constant, and must not depend on the receiver,
+// because it is also called on the zero value of each type when decoding.
+type Tagger interface {
+    EitherTag() string
+}
+
+// jsonEither is the JSON encoding of an E, E3, E4, or E5.
+type jsonEither struct {
+    Type  string          `json:"type"`
+    Value json.RawMessage `json:"value"`
+}
+
+// tag returns the discriminator for the option at index, where v is a value
+// of the type of that option.
+func tag(v any, index byte) string {
+    if t, ok := v.(Tagger); ok { return t.EitherTag() }
+    return string(rune(index))
+}
+
+// marshal encodes the value v held at index.
+func marshal(name string, v any, index byte) ([]byte, error) {
+    if index == 0 {
+        return nil, fmt.Errorf("either: %s.MarshalJSON(): cannot encode the zero value", name)
+    }
+
+    value, err := json.Marshal(v)
+    if err != nil { return nil, err }
+
+    return json.Marshal(jsonEither{
+        Type:  tag(v, index),
+        Value: value,
+    })
+}
+
+// unmarshal decodes data into whichever of the values pointed to by dests
+// is named by its discriminator, and returns the index of that option.
+func unmarshal(name string, data []byte, dests ... any) (byte, error) {
+    var j jsonEither
+    if err := json.Unmarshal(data, &j); err != nil { return 0, err }
+
+    for i, dest := range dests {
+        index := byte('a' + i)
+        if tag(reflect.ValueOf(dest).Elem().Interface(), index) != j.Type { continue }
+        if err := json.Unmarshal(j.Value, dest); err != nil { return 0, err }
+        return index, nil
+    }
+
+    return 0, fmt.Errorf("either: %s.UnmarshalJSON(): unknown type %q", name, j.Type)
+}
+
+// MarshalJSON implements the [json.Marshaler] interface. The E is encoded as
+// a JSON object with a "type" field, which is the discriminator, and a
+// "value" field, which is the encoded value that the E holds.
+//
+// The discriminator is "a" or "b", unless the type of the value implements
+// the [Tagger] interface. For example, with a Tagger, a protocol message
+// type modelled as an E might be encoded as:
+//
+//     {"type": "ping", "value": {"seq": 1}}
+//
+// Encoding the zero value of an E, which holds no value, returns an error.
+func (e E[A, B]) MarshalJSON() ([]byte, error) {
+    switch e.index {
+        case 'a': return marshal("E", e.a, e.index)
+        default:  return marshal("E", e.b, e.index)
+    }
+}
+
+// UnmarshalJSON implements the [json.Unmarshaler] interface, decoding JSON
+// in the format described by [E.MarshalJSON]. If decoding fails, the E is
+// left unchanged and the decoding error is returned. If more than one option
+// has the same discriminator, the first is used.
+func (e *E[A, B]) UnmarshalJSON(data []byte) error {
+    var a A
+    var b B
+    index, err := unmarshal("E", data, &a, &b)
+    if err != nil { return err }
+    *e = Pack(a, b, index)
+    return nil
+}
+
+// MarshalJSON is like [E.MarshalJSON], but for an E3.
+func (e E3[A, B, C]) MarshalJSON() ([]byte, error) {
+    switch e.index {
+        case 'a': return marshal("E3", e.a, e.index)
+        case 'b': return marshal("E3", e.b, e.index)
+        default:  return marshal("E3", e.c, e.index)
+    }
+}
+
+// UnmarshalJSON is like [E.UnmarshalJSON], but for an E3.
+func (e *E3[A, B, C]) UnmarshalJSON(data []byte) error {
+    var a A
+    var b B
+    var c C
+    index, err := unmarshal("E3", data, &a, &b, &c)
+    if err != nil { return err }
+    *e = Pack3(a, b, c, index)
+    return nil
+}
+
+// MarshalJSON is like [E.MarshalJSON], but for an E4.
+func (e E4[A, B, C, D]) MarshalJSON() ([]byte, error) {
+    switch e.index {
+        case 'a': return marshal("E4", e.a, e.index)
+        case 'b': return marshal("E4", e.b, e.index)
+        case 'c': return marshal("E4", e.c, e.index)
+        default:  return marshal("E4", e.d, e.index)
+    }
+}
+
+// UnmarshalJSON is like [E.UnmarshalJSON], but for an E4.
+func (e *E4[A, B, C, D]) UnmarshalJSON(data []byte) error {
+    var a A
+    var b B
+    var c C
+    var d D
+    index, err := unmarshal("E4", data, &a, &b, &c, &d)
+    if err != nil { return err }
+    *e = Pack4(a, b, c, d, index)
+    return nil
+}
+
+// MarshalJSON is like [E.MarshalJSON], but for an E5.
+func (e E5[A, B, C, D, E]) MarshalJSON() ([]byte, error) {
+    switch e.index {
+        case 'a': return marshal("E5", e.a, e.index)
+        case 'b': return marshal("E5", e.b, e.index)
+        case 'c': return marshal("E5", e.c, e.index)
+        case 'd': return marshal("E5", e.d, e.index)
+        default:  return marshal("E5", e.e, e.index)
+    }
+}
+
+// UnmarshalJSON is like [E.UnmarshalJSON], but for an E5.
+func (e *E5[A, B, C, D, E]) UnmarshalJSON(data []byte) error {
+    var a A
+    var b B
+    var c C
+    var d D
+    var x E
+    index, err := unmarshal("E5", data, &a, &b, &c, &d, &x)
+    if err != nil { return err }
+    *e = Pack5(a, b, c, d, x, index)
+    return nil
+}
@@ -0,0 +1,82 @@
+package either_test
+
+import (
+    "encoding/json"
+    "fmt"
+    "strconv"
+
+    "github.com/tawesoft/golib/v2/fun/either"
+)
+
+type Ping struct {
+    Seq int `json:"seq"`
+}
+
+func (Ping) EitherTag() string { return "ping" }
+
+type Pong struct {
+    Seq int `json:"seq"`
+}
+
+func (Pong) EitherTag() string { return "pong" }
+
+type Quit struct{}
+
+func (Quit) EitherTag() string { return "quit" }
+
+func ExampleFold3() {
+    type Message = either.E3[Ping, Pong, Quit]
+
+    describe := func(m Message) string {
+        return either.Fold3(m,
+            func(p Ping) string { return "ping " + strconv.Itoa(p.Seq) },
+            func(p Pong) string { return "pong " + strconv.Itoa(p.Seq) },
+            func(Quit) string { return "quit" },
+        )
+    }
+
+    fmt.Println(describe(either.E3A[Ping, Pong, Quit](Ping{Seq: 1})))
+    fmt.Println(describe(either.E3B[Ping, Pong, Quit](Pong{Seq: 1})))
+    fmt.Println(describe(either.E3C[Ping, Pong, Quit](Quit{})))
+
+    // Output:
+    // ping 1
+    // pong 1
+    // quit
+}
+
+func ExampleE3_MarshalJSON() {
+    type Message = either.E3[Ping, Pong, Quit]
+
+    messages := []Message{
+        either.E3A[Ping, Pong, Quit](Ping{Seq: 1}),
+        either.E3C[Ping, Pong, Quit](Quit{}),
+    }
+
+    data, err := json.Marshal(messages)
+    if err != nil { panic(err) }
+    fmt.Println(string(data))
+
+    var decoded []Message
+    if err := json.Unmarshal(data, &decoded); err != nil { panic(err) }
+    ping, _ := decoded[0].A()
+    _, quit := decoded[1].C()
+    fmt.Printf("%+v %t\n", ping, quit)
+
+    // Output:
+    // [{"type":"ping","value":{"seq":1}},{"type":"quit","value":{}}]
+    // {Seq:1} true
+}
+
+func ExampleMap() {
+    double := func(x int) int { return x * 2 }
+    length := func(s string) int { return len(s) }
+
+    f := either.Map(double, length)
+
+    a, _ := f(either.A[int, string](4)).A()
+    b, _ := f(either.B[int, string]("hello")).B()
+    fmt.Println(a, b)
+
+    // Output: 8 5
+}
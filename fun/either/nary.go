@@ -0,0 +1,379 @@
+package either
+
+import (
+    "fmt"
+)
+
+// E3 is like [E], but represents a type that can hold exactly one value out
+// of three options: a value "a" of type A, "b" of type B, or "c" of type C.
+type E3[A any, B any, C any] struct {
+    a A
+    b B
+    c C
+    index byte // 'a', 'b' or 'c'
+}
+
+// Pack3 is like [Pack], but returns an E3 that contains the value selected
+// by index, which must be one of 'a', 'b', or 'c'.
+func Pack3[A any, B any, C any](a A, b B, c C, index byte) E3[A, B, C] {
+    switch index {
+        case 'a': return E3[A, B, C]{a: a, index: index}
+        case 'b': return E3[A, B, C]{b: b, index: index}
+        case 'c': return E3[A, B, C]{c: c, index: index}
+        default:
+            panic(fmt.Errorf("either: Pack3[%T, %T, %T](): invalid index value", a, b, c))
+    }
+}
+
+// Unpack returns the components of an E3. The last return value is a
+// discriminator with the value 'a', 'b', or 'c' representing
+// which value exists.
+func (e E3[A, B, C]) Unpack() (A, B, C, byte) {
+    return e.a, e.b, e.c, e.index
+}
+
+// E3A returns a new E3 that holds a value "a" of type A.
+func E3A[A any, B any, C any](a A) E3[A, B, C] {
+    return E3[A, B, C]{
+        a: a,
+        index: 'a',
+    }
+}
+
+// E3B returns a new E3 that holds a value "b" of type B.
+func E3B[A any, B any, C any](b B) E3[A, B, C] {
+    return E3[A, B, C]{
+        b: b,
+        index: 'b',
+    }
+}
+
+// E3C returns a new E3 that holds a value "c" of type C.
+func E3C[A any, B any, C any](c C) E3[A, B, C] {
+    return E3[A, B, C]{
+        c: c,
+        index: 'c',
+    }
+}
+
+// A returns the value "a" of type A and true if the E3 contains that
+// value, or the zero value and false otherwise.
+func (e E3[A, B, C]) A() (result A, ok bool) {
+    if e.index == 'a' {
+        result = e.a
+        ok = true
+    }
+    return
+}
+
+// B returns the value "b" of type B and true if the E3 contains that
+// value, or the zero value and false otherwise.
+func (e E3[A, B, C]) B() (result B, ok bool) {
+    if e.index == 'b' {
+        result = e.b
+        ok = true
+    }
+    return
+}
+
+// C returns the value "c" of type C and true if the E3 contains that
+// value, or the zero value and false otherwise.
+func (e E3[A, B, C]) C() (result C, ok bool) {
+    if e.index == 'c' {
+        result = e.c
+        ok = true
+    }
+    return
+}
+
+// Match3 is like [Match], but for an E3.
+func Match3[A any, B any, C any](e E3[A, B, C], fa func(A), fb func(B), fc func(C)) {
+    switch e.index {
+        case 'a': fa(e.a)
+        case 'b': fb(e.b)
+        case 'c': fc(e.c)
+        default: panic(invalid("Match3", e))
+    }
+}
+
+// Fold3 is like [Fold], but for an E3.
+func Fold3[A any, B any, C any, R any](e E3[A, B, C], fa func(A) R, fb func(B) R, fc func(C) R) R {
+    switch e.index {
+        case 'a': return fa(e.a)
+        case 'b': return fb(e.b)
+        case 'c': return fc(e.c)
+        default: panic(invalid("Fold3", e))
+    }
+}
+
+// E4 is like [E], but represents a type that can hold exactly one value out
+// of four options: a value "a" of type A, "b" of type B, "c" of type C, or "d"
+// of type D.
+type E4[A any, B any, C any, D any] struct {
+    a A
+    b B
+    c C
+    d D
+    index byte // 'a', 'b', 'c' or 'd'
+}
+
+// Pack4 is like [Pack], but returns an E4 that contains the value selected
+// by index, which must be one of 'a', 'b', 'c', or 'd'.
+func Pack4[A any, B any, C any, D any](a A, b B, c C, d D, index byte) E4[A, B, C, D] {
+    switch index {
+        case 'a': return E4[A, B, C, D]{a: a, index: index}
+        case 'b': return E4[A, B, C, D]{b: b, index: index}
+        case 'c': return E4[A, B, C, D]{c: c, index: index}
+        case 'd': return E4[A, B, C, D]{d: d, index: index}
+        default:
+            panic(fmt.Errorf("either: Pack4[%T, %T, %T, %T](): invalid index value", a, b, c, d))
+    }
+}
+
+// Unpack returns the components of an E4. The last return value is a
+// discriminator with the value 'a', 'b', 'c', or 'd' representing
+// which value exists.
+func (e E4[A, B, C, D]) Unpack() (A, B, C, D, byte) {
+    return e.a, e.b, e.c, e.d, e.index
+}
+
+// E4A returns a new E4 that holds a value "a" of type A.
+func E4A[A any, B any, C any, D any](a A) E4[A, B, C, D] {
+    return E4[A, B, C, D]{
+        a: a,
+        index: 'a',
+    }
+}
+
+// E4B returns a new E4 that holds a value "b" of type B.
+func E4B[A any, B any, C any, D any](b B) E4[A, B, C, D] {
+    return E4[A, B, C, D]{
+        b: b,
+        index: 'b',
+    }
+}
+
+// E4C returns a new E4 that holds a value "c" of type C.
+func E4C[A any, B any, C any, D any](c C) E4[A, B, C, D] {
+    return E4[A, B, C, D]{
+        c: c,
+        index: 'c',
+    }
+}
+
+// E4D returns a new E4 that holds a value "d" of type D.
+func E4D[A any, B any, C any, D any](d D) E4[A, B, C, D] {
+    return E4[A, B, C, D]{
+        d: d,
+        index: 'd',
+    }
+}
+
+// A returns the value "a" of type A and true if the E4 contains that
+// value, or the zero value and false otherwise.
+func (e E4[A, B, C, D]) A() (result A, ok bool) {
+    if e.index == 'a' {
+        result = e.a
+        ok = true
+    }
+    return
+}
+
+// B returns the value "b" of type B and true if the E4 contains that
+// value, or the zero value and false otherwise.
+func (e E4[A, B, C, D]) B() (result B, ok bool) {
+    if e.index == 'b' {
+        result = e.b
+        ok = true
+    }
+    return
+}
+
+// C returns the value "c" of type C and true if the E4 contains that
+// value, or the zero value and false otherwise.
+func (e E4[A, B, C, D]) C() (result C, ok bool) {
+    if e.index == 'c' {
+        result = e.c
+        ok = true
+    }
+    return
+}
+
+// D returns the value "d" of type D and true if the E4 contains that
+// value, or the zero value and false otherwise.
+func (e E4[A, B, C, D]) D() (result D, ok bool) {
+    if e.index == 'd' {
+        result = e.d
+        ok = true
+    }
+    return
+}
+
+// Match4 is like [Match], but for an E4.
+func Match4[A any, B any, C any, D any](e E4[A, B, C, D], fa func(A), fb func(B), fc func(C), fd func(D)) {
+    switch e.index {
+        case 'a': fa(e.a)
+        case 'b': fb(e.b)
+        case 'c': fc(e.c)
+        case 'd': fd(e.d)
+        default: panic(invalid("Match4", e))
+    }
+}
+
+// Fold4 is like [Fold], but for an E4.
+func Fold4[A any, B any, C any, D any, R any](e E4[A, B, C, D], fa func(A) R, fb func(B) R, fc func(C) R, fd func(D) R) R {
+    switch e.index {
+        case 'a': return fa(e.a)
+        case 'b': return fb(e.b)
+        case 'c': return fc(e.c)
+        case 'd': return fd(e.d)
+        default: panic(invalid("Fold4", e))
+    }
+}
+
+// E5 is like [E], but represents a type that can hold exactly one value out
+// of five options: a value "a" of type A, "b" of type B, "c" of type C, "d" of
+// type D, or "e" of type E.
+type E5[A any, B any, C any, D any, E any] struct {
+    a A
+    b B
+    c C
+    d D
+    e E
+    index byte // 'a', 'b', 'c', 'd' or 'e'
+}
+
+// Pack5 is like [Pack], but returns an E5 that contains the value selected
+// by index, which must be one of 'a', 'b', 'c', 'd', or 'e'.
+func Pack5[A any, B any, C any, D any, E any](a A, b B, c C, d D, e E, index byte) E5[A, B, C, D, E] {
+    switch index {
+        case 'a': return E5[A, B, C, D, E]{a: a, index: index}
+        case 'b': return E5[A, B, C, D, E]{b: b, index: index}
+        case 'c': return E5[A, B, C, D, E]{c: c, index: index}
+        case 'd': return E5[A, B, C, D, E]{d: d, index: index}
+        case 'e': return E5[A, B, C, D, E]{e: e, index: index}
+        default:
+            panic(fmt.Errorf("either: Pack5[%T, %T, %T, %T, %T](): invalid index value", a, b, c, d, e))
+    }
+}
+
+// Unpack returns the components of an E5. The last return value is a
+// discriminator with the value 'a', 'b', 'c', 'd', or 'e' representing
+// which value exists.
+func (e E5[A, B, C, D, E]) Unpack() (A, B, C, D, E, byte) {
+    return e.a, e.b, e.c, e.d, e.e, e.index
+}
+
+// E5A returns a new E5 that holds a value "a" of type A.
+func E5A[A any, B any, C any, D any, E any](a A) E5[A, B, C, D, E] {
+    return E5[A, B, C, D, E]{
+        a: a,
+        index: 'a',
+    }
+}
+
+// E5B returns a new E5 that holds a value "b" of type B.
+func E5B[A any, B any, C any, D any, E any](b B) E5[A, B, C, D, E] {
+    return E5[A, B, C, D, E]{
+        b: b,
+        index: 'b',
+    }
+}
+
+// E5C returns a new E5 that holds a value "c" of type C.
+func E5C[A any, B any, C any, D any, E any](c C) E5[A, B, C, D, E] {
+    return E5[A, B, C, D, E]{
+        c: c,
+        index: 'c',
+    }
+}
+
+// E5D returns a new E5 that holds a value "d" of type D.
+func E5D[A any, B any, C any, D any, E any](d D) E5[A, B, C, D, E] {
+    return E5[A, B, C, D, E]{
+        d: d,
+        index: 'd',
+    }
+}
+
+// E5E returns a new E5 that holds a value "e" of type E.
+func E5E[A any, B any, C any, D any, E any](e E) E5[A, B, C, D, E] {
+    return E5[A, B, C, D, E]{
+        e: e,
+        index: 'e',
+    }
+}
+
+// A returns the value "a" of type A and true if the E5 contains that
+// value, or the zero value and false otherwise.
+func (e E5[A, B, C, D, E]) A() (result A, ok bool) {
+    if e.index == 'a' {
+        result = e.a
+        ok = true
+    }
+    return
+}
+
+// B returns the value "b" of type B and true if the E5 contains that
+// value, or the zero value and false otherwise.
+func (e E5[A, B, C, D, E]) B() (result B, ok bool) {
+    if e.index == 'b' {
+        result = e.b
+        ok = true
+    }
+    return
+}
+
+// C returns the value "c" of type C and true if the E5 contains that
+// value, or the zero value and false otherwise.
+func (e E5[A, B, C, D, E]) C() (result C, ok bool) {
+    if e.index == 'c' {
+        result = e.c
+        ok = true
+    }
+    return
+}
+
+// D returns the value "d" of type D and true if the E5 contains that
+// value, or the zero value and false otherwise.
+func (e E5[A, B, C, D, E]) D() (result D, ok bool) {
+    if e.index == 'd' {
+        result = e.d
+        ok = true
+    }
+    return
+}
+
+// E returns the value "e" of type E and true if the E5 contains that
+// value, or the zero value and false otherwise.
+func (e E5[A, B, C, D, E]) E() (result E, ok bool) {
+    if e.index == 'e' {
+        result = e.e
+        ok = true
+    }
+    return
+}
+
+// Match5 is like [Match], but for an E5.
+func Match5[A any, B any, C any, D any, E any](e E5[A, B, C, D, E], fa func(A), fb func(B), fc func(C), fd func(D), fe func(E)) {
+    switch e.index {
+        case 'a': fa(e.a)
+        case 'b': fb(e.b)
+        case 'c': fc(e.c)
+        case 'd': fd(e.d)
+        case 'e': fe(e.e)
+        default: panic(invalid("Match5", e))
+    }
+}
+
+// Fold5 is like [Fold], but for an E5.
+func Fold5[A any, B any, C any, D any, E any, R any](e E5[A, B, C, D, E], fa func(A) R, fb func(B) R, fc func(C) R, fd func(D) R, fe func(E) R) R {
+    switch e.index {
+        case 'a': return fa(e.a)
+        case 'b': return fb(e.b)
+        case 'c': return fc(e.c)
+        case 'd': return fd(e.d)
+        case 'e': return fe(e.e)
+        default: panic(invalid("Fold5", e))
+    }
+}
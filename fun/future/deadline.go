@@ -0,0 +1,55 @@
+package future
+
+import (
+    "context"
+    "errors"
+    "time"
+)
+
+type deadline[T any] struct {
+    future F[T]
+    deadline time.Time
+}
+
+// WithDeadline returns a future that wraps an existing future, but where
+// Collect and CollectCtx give up waiting, with the error
+// [context.DeadlineExceeded], at the given deadline. After the deadline,
+// Peek returns [context.DeadlineExceeded] instead of [NotReady].
+//
+// The deadline is also applied to the context used to compute the promise of
+// a future created by [NewSync], so that the promise may be cancelled, so far
+// as the underlying promise supports being cancelled.
+//
+// Giving up waiting does not stop the wrapped future. Stop the returned
+// future, which stops the wrapped future, once it is no longer needed.
+func WithDeadline[T any](f F[T], t time.Time) F[T] {
+    return deadline[T]{future: f, deadline: t}
+}
+
+// WithTimeout is like [WithDeadline], with a deadline the given duration
+// after the time of the call to WithTimeout.
+func WithTimeout[T any](f F[T], d time.Duration) F[T] {
+    return WithDeadline(f, time.Now().Add(d))
+}
+
+func (f deadline[T]) Collect() (T, error) {
+    return f.CollectCtx(context.TODO())
+}
+
+func (f deadline[T]) CollectCtx(ctx context.Context) (T, error) {
+    ctx, cancel := context.WithDeadline(ctx, f.deadline)
+    defer cancel()
+    return f.future.CollectCtx(ctx)
+}
+
+func (f deadline[T]) Peek() (T, error) {
+    value, err := f.future.Peek()
+    if errors.Is(err, NotReady) && !time.Now().Before(f.deadline) {
+        err = context.DeadlineExceeded
+    }
+    return value, err
+}
+
+func (f deadline[T]) Stop() {
+    f.future.Stop()
+}
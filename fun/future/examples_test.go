@@ -0,0 +1,100 @@
+package future_test
+
+import (
+    "context"
+    "errors"
+    "fmt"
+    "time"
+
+    "github.com/tawesoft/golib/v2/fun/future"
+    "github.com/tawesoft/golib/v2/fun/promise"
+)
+
+func ExampleAllSettled() {
+    errNegative := errors.New("negative number")
+    sqrt := func(x int) promise.P[int] {
+        return promise.FromResultFunc(func() (int, error) {
+            if x < 0 { return 0, errNegative }
+            for i := 0; ; i++ {
+                if i * i >= x { return i, nil }
+            }
+        })
+    }
+
+    futures := future.NewSyncs([]promise.P[int]{sqrt(16), sqrt(-1), sqrt(81)})
+    results, _ := future.AllSettled(futures).Compute()
+
+    for _, r := range results {
+        if r.Success() {
+            fmt.Println(r.Value)
+        } else {
+            fmt.Printf("error: %v\n", r.Error)
+        }
+    }
+
+    // Output:
+    // 4
+    // error: negative number
+    // 9
+}
+
+func ExampleAny() {
+    slow := func(x string, d time.Duration, err error) promise.P[string] {
+        return promise.FromResultFuncCtx(func(ctx context.Context) (string, error) {
+            select {
+                case <- time.After(d): return x, err
+                case <- ctx.Done(): return "", ctx.Err()
+            }
+        })
+    }
+
+    ctx, cancel := context.WithCancel(context.Background())
+    defer cancel()
+
+    futures := future.NewAsyncs(ctx, []promise.P[string]{
+        slow("mirror 1", 50 * time.Millisecond, nil),
+        slow("mirror 2", 10 * time.Millisecond, errors.New("unavailable")),
+        slow("mirror 3", 20 * time.Millisecond, nil),
+    })
+
+    fastest, err := future.Any(futures).ComputeCtx(ctx)
+    fmt.Println(fastest, err)
+
+    // Output:
+    // mirror 3 <nil>
+}
+
+func ExampleRetry() {
+    attempts := 0
+    flaky := promise.FromResultFunc(func() (string, error) {
+        attempts++
+        if attempts < 3 { return "", fmt.Errorf("attempt %d failed", attempts) }
+        return fmt.Sprintf("attempt %d succeeded", attempts), nil
+    })
+
+    result, err := future.Retry(flaky, future.Backoff{
+        Attempts: 5,
+        Initial:  time.Millisecond,
+        Jitter:   0.5,
+    }).Compute()
+    fmt.Println(result, err)
+
+    // Output:
+    // attempt 3 succeeded <nil>
+}
+
+func ExampleWithTimeout() {
+    forever := promise.FromResultFuncCtx(func(ctx context.Context) (int, error) {
+        <- ctx.Done()
+        return 0, ctx.Err()
+    })
+
+    f := future.WithTimeout(future.NewSync(forever), 10 * time.Millisecond)
+    defer f.Stop()
+
+    _, err := f.Collect()
+    fmt.Println(err)
+
+    // Output:
+    // context deadline exceeded
+}
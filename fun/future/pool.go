@@ -0,0 +1,398 @@
+package future
+
+import (
+    "context"
+    "errors"
+    "fmt"
+    "runtime"
+    "runtime/debug"
+    gosync "sync" // this package has its own sync type
+    "sync/atomic"
+
+    "github.com/tawesoft/golib/v2/fun/promise"
+    "github.com/tawesoft/golib/v2/fun/result"
+)
+
+var (
+    // PoolClosed is the error computed by a future submitted to a [Pool]
+    // after Shutdown has been called.
+    PoolClosed = errors.New("future.PoolClosed")
+
+    // Rejected is the error computed by a future submitted to a [Pool] with
+    // a full queue and the [Reject] overflow policy.
+    Rejected = errors.New("future.Rejected")
+
+    // Dropped is the error computed by a future that was removed from the
+    // queue of a [Pool] with the [DropOldest] overflow policy.
+    Dropped = errors.New("future.Dropped")
+)
+
+// PanicError is the error computed by a future submitted to a [Pool] when
+// its promise panics.
+type PanicError struct {
+    Value any    // the value passed to panic
+    Stack []byte // the stack trace of the goroutine that panicked
+}
+
+func (e PanicError) Error() string {
+    return fmt.Sprintf("future: promise panicked: %v", e.Value)
+}
+
+// Overflow is a policy for submitting a promise to a [Pool] when its queue
+// is full.
+type Overflow int
+
+const (
+    // Block waits for space in the queue.
+    Block Overflow = iota
+
+    // Reject does not queue the new promise. Its future computes the error
+    // [Rejected].
+    Reject
+
+    // DropOldest removes the promise that has been waiting in the queue the
+    // longest to make space for the new promise. The removed promise's future
+    // computes the error [Dropped].
+    DropOldest
+)
+
+// PoolStats is a snapshot of the metrics of a [Pool].
+type PoolStats struct {
+    Queued    int    // number of promises waiting in the queue
+    Running   int    // number of promises being computed by a worker
+    Completed uint64 // number of promises computed by a worker
+    Rejected  uint64 // number of promises rejected with [Rejected]
+    Dropped   uint64 // number of promises dropped with [Dropped]
+}
+
+// PoolHooks are optional functions called when the metrics of a [Pool]
+// change, for example to export them to a monitoring system. Each hook is
+// called with a snapshot of the pool's metrics. Hooks may be called
+// concurrently from several goroutines, so must be safe for concurrent use.
+type PoolHooks struct {
+    Queued    func(PoolStats) // called after a promise is added to the queue
+    Running   func(PoolStats) // called after a worker starts a promise
+    Completed func(PoolStats) // called after a worker finishes a promise
+}
+
+// PoolConfig configures a new [Pool].
+type PoolConfig struct {
+    // Workers is the fixed number of goroutines that compute promises. If
+    // zero or negative, defaults to [runtime.GOMAXPROCS].
+    Workers int
+
+    // QueueSize is the maximum number of promises waiting for a worker. If
+    // zero or negative, defaults to the number of workers.
+    QueueSize int
+
+    // Overflow is the policy for submitting a promise when the queue is full.
+    // If omitted, defaults to [Block].
+    Overflow Overflow
+
+    // Hooks are optional metrics hooks.
+    Hooks PoolHooks
+}
+
+// Pool is a fixed number of worker goroutines that compute promises
+// submitted with [Submit] or [SubmitCtx], each returning a future.
+//
+// Unlike [NewAsync], which starts a new goroutine for every promise, a Pool
+// limits the number of promises computed at once, and the number of
+// promises waiting to be computed, which bounds resource usage under bursts
+// of load.
+//
+// The methods of a Pool are safe for concurrent use.
+type Pool struct {
+    ctx     context.Context
+    cancel  context.CancelFunc
+    config  PoolConfig
+    queue   chan task
+    workers gosync.WaitGroup
+
+    mu       gosync.RWMutex
+    closed   bool
+    closing  chan struct{} // closed when Shutdown is called
+    drain    chan struct{} // closed once no more promises can be queued
+    shutdown gosync.Once
+
+    queued    atomic.Int64
+    running   atomic.Int64
+    completed atomic.Uint64
+    rejected  atomic.Uint64
+    dropped   atomic.Uint64
+}
+
+// task is the non-generic interface of a pool future.
+type task interface {
+    start() bool // false if the task has already finished, without running
+    run()
+    fail(err error)
+}
+
+// NewPool returns a new [Pool] and starts its workers. The context passed to
+// each promise is derived from ctx, so cancelling ctx cancels every promise.
+//
+// Call [Pool.Shutdown] to stop the workers once the Pool is no longer
+// needed.
+func NewPool(ctx context.Context, config PoolConfig) *Pool {
+    if config.Workers <= 0 { config.Workers = runtime.GOMAXPROCS(0) }
+    if config.QueueSize <= 0 { config.QueueSize = config.Workers }
+
+    ctx, cancel := context.WithCancel(ctx)
+    p := &Pool{
+        ctx:     ctx,
+        cancel:  cancel,
+        config:  config,
+        queue:   make(chan task, config.QueueSize),
+        closing: make(chan struct{}),
+        drain:   make(chan struct{}),
+    }
+
+    p.workers.Add(config.Workers)
+    for i := 0; i < config.Workers; i++ {
+        go p.work()
+    }
+
+    return p
+}
+
+// Stats returns a snapshot of the Pool's metrics.
+func (p *Pool) Stats() PoolStats {
+    return PoolStats{
+        Queued:    int(p.queued.Load()),
+        Running:   int(p.running.Load()),
+        Completed: p.completed.Load(),
+        Rejected:  p.rejected.Load(),
+        Dropped:   p.dropped.Load(),
+    }
+}
+
+func (p *Pool) hook(f func(PoolStats)) {
+    if f != nil { f(p.Stats()) }
+}
+
+// Shutdown stops the Pool from accepting new promises, and waits for the
+// workers to compute every promise already queued or running.
+//
+// If ctx is cancelled first, Shutdown cancels the context of every promise
+// (queued promises are not computed, and their futures compute a context
+// error) and returns the context error without waiting any further.
+//
+// It is not an error to call Shutdown more than once.
+func (p *Pool) Shutdown(ctx context.Context) error {
+    p.shutdown.Do(func() {
+        close(p.closing) // unblock any blocked submitters
+        p.mu.Lock()
+        p.closed = true
+        p.mu.Unlock()
+        close(p.drain)
+    })
+
+    done := make(chan struct{})
+    go func() {
+        p.workers.Wait()
+        close(done)
+    }()
+
+    select {
+        case <- done:
+            p.cancel()
+            return nil
+        case <- ctx.Done():
+            p.cancel()
+            return ctx.Err()
+    }
+}
+
+func (p *Pool) work() {
+    defer p.workers.Done()
+    for {
+        select {
+            case t := <- p.queue:
+                p.run(t)
+            case <- p.drain:
+                for {
+                    select {
+                        case t := <- p.queue:
+                            p.run(t)
+                        default:
+                            return
+                    }
+                }
+        }
+    }
+}
+
+func (p *Pool) run(t task) {
+    p.queued.Add(-1)
+
+    // a task cancelled or stopped while queued isn't computed by a worker
+    if !t.start() { return }
+
+    p.running.Add(1)
+    p.hook(p.config.Hooks.Running)
+
+    t.run()
+
+    p.running.Add(-1)
+    p.completed.Add(1)
+    p.hook(p.config.Hooks.Completed)
+}
+
+// enqueue adds a task to the queue according to the overflow policy.
+func (p *Pool) enqueue(ctx context.Context, t task) {
+    p.mu.RLock()
+    defer p.mu.RUnlock()
+
+    if p.closed {
+        t.fail(PoolClosed)
+        return
+    }
+
+    // count before sending, so that a worker never sees a negative count
+    p.queued.Add(1)
+
+    switch p.config.Overflow {
+        case Reject:
+            select {
+                case p.queue <- t:
+                default:
+                    p.queued.Add(-1)
+                    p.rejected.Add(1)
+                    t.fail(Rejected)
+                    return
+            }
+        case DropOldest:
+            for sent := false; !sent; {
+                select {
+                    case p.queue <- t:
+                        sent = true
+                    default:
+                        select {
+                            case old := <- p.queue:
+                                p.queued.Add(-1)
+                                p.dropped.Add(1)
+                                old.fail(Dropped)
+                            default:
+                        }
+                }
+            }
+        default: // Block
+            select {
+                case p.queue <- t:
+                case <- ctx.Done():
+                    p.queued.Add(-1)
+                    t.fail(ctx.Err())
+                    return
+                case <- p.closing:
+                    p.queued.Add(-1)
+                    t.fail(PoolClosed)
+                    return
+            }
+    }
+
+    p.hook(p.config.Hooks.Queued)
+}
+
+type pooled[T any] struct {
+    ctx     context.Context
+    cancel  context.CancelFunc
+    promise promise.P[T]
+    once    gosync.Once
+    done    chan struct{}
+    result  result.R[T]
+}
+
+// Submit adds a promise to the queue of a [Pool], and returns a future for
+// its result. If the queue is full, the Pool's [Overflow] policy applies. In
+// the case of [Block], Submit blocks until there is space in the queue or
+// until the Pool is shut down.
+//
+// Each promise is computed with a context derived from the Pool's context,
+// which is also cancelled if the returned future is stopped. If the promise
+// panics, the future computes a [PanicError].
+//
+// Unlike futures created by [NewAsync], the returned future does not need to
+// be stopped once no longer needed, but may be stopped to cancel the promise.
+func Submit[T any](pool *Pool, p promise.P[T]) F[T] {
+    return SubmitCtx(context.TODO(), pool, p)
+}
+
+// SubmitCtx is like [Submit], but if the [Block] overflow policy applies,
+// gives up waiting for space in the queue when ctx is cancelled, in which
+// case the returned future computes the context error. The context does not
+// otherwise affect the computation of the promise.
+func SubmitCtx[T any](ctx context.Context, pool *Pool, p promise.P[T]) F[T] {
+    taskCtx, cancel := context.WithCancel(pool.ctx)
+    f := &pooled[T]{
+        ctx:     taskCtx,
+        cancel:  cancel,
+        promise: p,
+        done:    make(chan struct{}),
+    }
+    pool.enqueue(ctx, f)
+    return f
+}
+
+func (f *pooled[T]) complete(r result.R[T]) {
+    f.once.Do(func() {
+        f.result = r
+        f.cancel()
+        close(f.done)
+    })
+}
+
+func (f *pooled[T]) fail(err error) {
+    f.complete(result.Error[T](err))
+}
+
+func (f *pooled[T]) start() bool {
+    if err := f.ctx.Err(); err != nil {
+        f.fail(err)
+        return false
+    }
+
+    select {
+        case <- f.done: return false // stopped while queued
+        default: return true
+    }
+}
+
+func (f *pooled[T]) run() {
+    p := f.promise
+    defer func() {
+        if r := recover(); r != nil {
+            f.fail(PanicError{Value: r, Stack: debug.Stack()})
+        }
+    }()
+
+    f.complete(result.New(p.ComputeCtx(f.ctx)))
+}
+
+func (f *pooled[T]) Collect() (T, error) {
+    return f.CollectCtx(context.TODO())
+}
+
+func (f *pooled[T]) CollectCtx(ctx context.Context) (value T, err error) {
+    select {
+        case <- ctx.Done():
+            err = ctx.Err()
+            return
+        case <- f.done:
+            return f.result.Unpack()
+    }
+}
+
+func (f *pooled[T]) Peek() (value T, err error) {
+    select {
+        case <- f.done:
+            return f.result.Unpack()
+        default:
+            err = NotReady
+            return
+    }
+}
+
+func (f *pooled[T]) Stop() {
+    f.fail(context.Canceled)
+}
@@ -0,0 +1,194 @@
+package future_test
+
+import (
+    "context"
+    "errors"
+    "sync/atomic"
+    "testing"
+    "time"
+
+    "github.com/stretchr/testify/assert"
+    "github.com/tawesoft/golib/v2/fun/future"
+    "github.com/tawesoft/golib/v2/fun/promise"
+    "github.com/tawesoft/golib/v2/must"
+)
+
+// blocker returns a promise that blocks until the returned channel is closed.
+func blocker[T any](value T) (promise.P[T], chan struct{}) {
+    release := make(chan struct{})
+    return promise.FromResultFuncCtx(func(ctx context.Context) (T, error) {
+        select {
+            case <- release: return value, nil
+            case <- ctx.Done(): return value, ctx.Err()
+        }
+    }), release
+}
+
+func TestPool(t *testing.T) {
+    var maxRunning atomic.Int64
+    pool := future.NewPool(context.Background(), future.PoolConfig{
+        Workers:   3,
+        QueueSize: 100,
+        Hooks: future.PoolHooks{
+            Running: func(stats future.PoolStats) {
+                for {
+                    n := maxRunning.Load()
+                    if int64(stats.Running) <= n { break }
+                    if maxRunning.CompareAndSwap(n, int64(stats.Running)) { break }
+                }
+            },
+        },
+    })
+
+    futures := make([]future.F[int], 0, 100)
+    for i := 0; i < 100; i++ {
+        i := i
+        futures = append(futures, future.Submit(pool, promise.FromFunc(func() int {
+            time.Sleep(time.Millisecond)
+            return i * i
+        })))
+    }
+
+    values, err := future.CollectAll(futures).Compute()
+    assert.Nil(t, err)
+    for i, v := range values {
+        assert.Equal(t, i * i, v)
+    }
+
+    assert.Nil(t, pool.Shutdown(context.Background()))
+    assert.LessOrEqual(t, maxRunning.Load(), int64(3))
+    assert.Equal(t, future.PoolStats{Completed: 100}, pool.Stats())
+
+    _, err = future.Submit(pool, promise.FromValue(1)).Collect()
+    assert.ErrorIs(t, err, future.PoolClosed)
+}
+
+func TestPool_overflow(t *testing.T) {
+    {
+        pool := future.NewPool(context.Background(), future.PoolConfig{
+            Workers: 1, QueueSize: 1, Overflow: future.Reject,
+        })
+        p, release := blocker(1)
+        running := future.Submit(pool, p)
+        for pool.Stats().Running == 0 { time.Sleep(time.Millisecond) }
+
+        queued := future.Submit(pool, promise.FromValue(2))
+        _, err := future.Submit(pool, promise.FromValue(3)).Collect()
+        assert.ErrorIs(t, err, future.Rejected)
+
+        close(release)
+        assert.Equal(t, 1, must.Result(running.Collect()))
+        assert.Equal(t, 2, must.Result(queued.Collect()))
+        assert.Nil(t, pool.Shutdown(context.Background()))
+        assert.Equal(t, uint64(1), pool.Stats().Rejected)
+    }
+    {
+        pool := future.NewPool(context.Background(), future.PoolConfig{
+            Workers: 1, QueueSize: 1, Overflow: future.DropOldest,
+        })
+        p, release := blocker(1)
+        running := future.Submit(pool, p)
+        for pool.Stats().Running == 0 { time.Sleep(time.Millisecond) }
+
+        dropped := future.Submit(pool, promise.FromValue(2))
+        queued := future.Submit(pool, promise.FromValue(3))
+        _, err := dropped.Collect()
+        assert.ErrorIs(t, err, future.Dropped)
+
+        close(release)
+        assert.Equal(t, 1, must.Result(running.Collect()))
+        assert.Equal(t, 3, must.Result(queued.Collect()))
+        assert.Nil(t, pool.Shutdown(context.Background()))
+        assert.Equal(t, uint64(1), pool.Stats().Dropped)
+    }
+    {
+        pool := future.NewPool(context.Background(), future.PoolConfig{
+            Workers: 1, QueueSize: 1, Overflow: future.Block,
+        })
+        p, release := blocker(1)
+        running := future.Submit(pool, p)
+        for pool.Stats().Running == 0 { time.Sleep(time.Millisecond) }
+        queued := future.Submit(pool, promise.FromValue(2))
+
+        ctx, cancel := context.WithTimeout(context.Background(), 10 * time.Millisecond)
+        defer cancel()
+        _, err := future.SubmitCtx(ctx, pool, promise.FromValue(3)).Collect()
+        assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+        close(release)
+        assert.Equal(t, 1, must.Result(running.Collect()))
+        assert.Equal(t, 2, must.Result(queued.Collect()))
+        assert.Nil(t, pool.Shutdown(context.Background()))
+    }
+}
+
+func TestPool_panic(t *testing.T) {
+    pool := future.NewPool(context.Background(), future.PoolConfig{Workers: 1})
+    defer pool.Shutdown(context.Background())
+
+    _, err := future.Submit(pool, promise.FromFunc(func() int {
+        panic("oops")
+    })).Collect()
+
+    var panicErr future.PanicError
+    assert.True(t, errors.As(err, &panicErr))
+    assert.Equal(t, "oops", panicErr.Value)
+
+    // the worker survives
+    assert.Equal(t, 5, must.Result(future.Submit(pool, promise.FromValue(5)).Collect()))
+}
+
+func TestPool_shutdown(t *testing.T) {
+    pool := future.NewPool(context.Background(), future.PoolConfig{Workers: 1})
+    p, _ := blocker(1)
+    running := future.Submit(pool, p)
+    queued := future.Submit(pool, promise.FromValue(2))
+
+    ctx, cancel := context.WithTimeout(context.Background(), 10 * time.Millisecond)
+    defer cancel()
+    assert.ErrorIs(t, pool.Shutdown(ctx), context.DeadlineExceeded)
+
+    _, err := running.Collect()
+    assert.ErrorIs(t, err, context.Canceled)
+    _, err = queued.Collect()
+    assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestPool_stop(t *testing.T) {
+    pool := future.NewPool(context.Background(), future.PoolConfig{Workers: 1})
+    defer pool.Shutdown(context.Background())
+
+    p, _ := blocker(1)
+    f := future.Submit(pool, p)
+    f.Stop()
+    _, err := f.Collect()
+    assert.ErrorIs(t, err, context.Canceled)
+
+    // the worker is released
+    assert.Equal(t, 5, must.Result(future.Submit(pool, promise.FromValue(5)).Collect()))
+}
+
+func TestPool_stopQueued(t *testing.T) {
+    var completed atomic.Int64
+    pool := future.NewPool(context.Background(), future.PoolConfig{
+        Workers: 1,
+        Hooks: future.PoolHooks{
+            Completed: func(future.PoolStats) { completed.Add(1) },
+        },
+    })
+
+    p, release := blocker(1)
+    running := future.Submit(pool, p)
+    for pool.Stats().Running == 0 { time.Sleep(time.Millisecond) }
+
+    queued := future.Submit(pool, promise.FromValue(2))
+    queued.Stop()
+    close(release)
+
+    assert.Equal(t, 1, must.Result(running.Collect()))
+    assert.Nil(t, pool.Shutdown(context.Background()))
+
+    // a promise stopped while queued isn't computed by a worker
+    assert.Equal(t, future.PoolStats{Completed: 1}, pool.Stats())
+    assert.Equal(t, int64(1), completed.Load())
+}
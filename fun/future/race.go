@@ -0,0 +1,91 @@
+package future
+
+import (
+    "context"
+    "errors"
+
+    "github.com/tawesoft/golib/v2/fun/promise"
+    "github.com/tawesoft/golib/v2/fun/result"
+)
+
+// NoFutures is the error computed by the promises returned by [Race] and
+// [Any] when given an empty slice of futures.
+var NoFutures = errors.New("future.NoFutures")
+
+// collectEach collects every input future concurrently, each in a new
+// goroutine, and returns a channel that receives each result as it becomes
+// available. The channel is buffered so that no goroutine blocks forever once
+// the caller stops receiving. Cancel ctx to signal the remaining collectors to
+// give up waiting.
+func collectEach[T any](ctx context.Context, xs []F[T]) <-chan result.R[T] {
+    results := make(chan result.R[T], len(xs))
+    for _, x := range xs {
+        go func(x F[T]) {
+            results <- result.New(x.CollectCtx(ctx))
+        }(x)
+    }
+    return results
+}
+
+// Race returns a promise to compute the value or error of whichever input
+// future is the first to be ready.
+//
+// Each future is collected in its own goroutine, so the input futures must be
+// safe to collect concurrently with each other (a future created by
+// [NewSync] computes its promise in that goroutine). Once the promise has
+// computed a result, it stops waiting on the remaining futures, but does not
+// stop them. If the slice of futures is empty, the promise computes the error
+// [NoFutures].
+func Race[T any](xs []F[T]) promise.P[T] {
+    return promise.FromResultFuncCtx(func(ctx context.Context) (T, error) {
+        if len(xs) == 0 {
+            var zero T
+            return zero, NoFutures
+        }
+
+        ctx, cancel := context.WithCancel(ctx)
+        defer cancel()
+
+        r := <- collectEach(ctx, xs)
+        return r.Unpack()
+    })
+}
+
+// Any is like [Race], but returns a promise to compute the value of whichever
+// input future is the first to be ready without an error. If every future
+// computes an error, the promise computes all the errors, joined with
+// [errors.Join].
+func Any[T any](xs []F[T]) promise.P[T] {
+    return promise.FromResultFuncCtx(func(ctx context.Context) (T, error) {
+        var zero T
+        if len(xs) == 0 { return zero, NoFutures }
+
+        ctx, cancel := context.WithCancel(ctx)
+        defer cancel()
+
+        results := collectEach(ctx, xs)
+        errs := make([]error, 0, len(xs))
+        for i := 0; i < len(xs); i++ {
+            r := <- results
+            if r.Success() { return r.Value, nil }
+            errs = append(errs, r.Error)
+        }
+        return zero, errors.Join(errs...)
+    })
+}
+
+// AllSettled returns a promise to compute the slice of the results of every
+// input future, in the same order, whether each is a value or an error.
+// Unlike [CollectAll], it does not stop at the first error.
+//
+// If the context is cancelled, the results of the futures still waiting
+// are the context's error.
+func AllSettled[T any](xs []F[T]) promise.P[[]result.R[T]] {
+    return promise.FromFuncCtx(func(ctx context.Context) []result.R[T] {
+        results := make([]result.R[T], 0, len(xs))
+        for _, x := range xs {
+            results = append(results, result.New(x.CollectCtx(ctx)))
+        }
+        return results
+    })
+}
@@ -0,0 +1,92 @@
+package future
+
+import (
+    "context"
+    "errors"
+    "math/rand"
+    "time"
+
+    "github.com/tawesoft/golib/v2/fun/promise"
+)
+
+// Backoff configures how [Retry] waits between attempts to compute a
+// promise. The delay before each retry grows exponentially.
+type Backoff struct {
+    // Attempts is the maximum number of times to compute the promise,
+    // including the first attempt. If zero or negative, there is no limit.
+    Attempts int
+
+    // Initial is the delay before the first retry. If zero, retries are
+    // attempted immediately.
+    Initial time.Duration
+
+    // Max, if positive, is the maximum delay before any retry.
+    Max time.Duration
+
+    // Multiplier scales the delay after each retry. If less than one,
+    // defaults to two i.e. the delay doubles after each retry.
+    Multiplier float64
+
+    // Jitter, between zero and one, randomly reduces each delay by up to that
+    // fraction of the delay. This avoids many clients retrying in lockstep.
+    // For example, a Jitter of 0.5 gives a random delay between 50% and
+    // 100% of the calculated delay. If zero, the delays are not randomised.
+    Jitter float64
+
+    // Retryable, if not nil, returns true iff an error returned by the
+    // promise should be retried. If nil, every error is retried.
+    Retryable func(error) bool
+}
+
+// jitter returns the delay d randomly reduced according to b.Jitter.
+func (b Backoff) jitter(d time.Duration) time.Duration {
+    j := b.Jitter
+    if j <= 0 { return d }
+    if j > 1 { j = 1 }
+    return d - time.Duration(rand.Float64() * j * float64(d))
+}
+
+// next returns the delay following delay d.
+func (b Backoff) next(d time.Duration) time.Duration {
+    m := b.Multiplier
+    if m < 1 { m = 2 }
+    d = time.Duration(float64(d) * m)
+    if (b.Max > 0) && ((d > b.Max) || (d < 0)) { d = b.Max }
+    return d
+}
+
+// Retry returns a promise that computes the input promise p, and, each time
+// it computes an error, waits according to the [Backoff] configuration b and
+// then computes p again. The returned promise computes the value of the first
+// successful attempt, or, once no more attempts are allowed, the error of the
+// last attempt.
+//
+// The input promise must be safe to compute more than once. For example,
+// promises created by [promise.FromResultFunc] or [promise.FromResultFuncCtx]
+// call their function again each time.
+//
+// If the context is cancelled, Retry stops waiting and computes the error of
+// the last attempt joined with the context error.
+func Retry[T any](p promise.P[T], b Backoff) promise.P[T] {
+    return promise.FromResultFuncCtx(func(ctx context.Context) (T, error) {
+        delay := b.Initial
+        for attempt := 1; ; attempt++ {
+            value, err := p.ComputeCtx(ctx)
+            if err == nil { return value, nil }
+
+            if (b.Attempts > 0) && (attempt >= b.Attempts) { return value, err }
+            if (b.Retryable != nil) && !b.Retryable(err) { return value, err }
+            if ctx.Err() != nil { return value, errors.Join(err, ctx.Err()) }
+
+            timer := time.NewTimer(b.jitter(delay))
+            select {
+                case <- ctx.Done():
+                    timer.Stop()
+                    return value, errors.Join(err, ctx.Err())
+                case <- timer.C:
+            }
+
+            delay = b.next(delay)
+        }
+    })
+}
@@ -0,0 +1,116 @@
+package maybe
+
+import (
+    "bytes"
+    "database/sql/driver"
+    "encoding/json"
+
+    "github.com/tawesoft/golib/v2/internal/codec"
+)
+
+// IsZero returns true iff the M has no value.
+//
+// For example, since Go 1.24, a struct field of type M tagged with the
+// encoding/json "omitzero" option is omitted from the JSON output when it has
+// no value.
+func (m M[V]) IsZero() bool {
+    return !m.Ok
+}
+
+// MarshalJSON implements the [json.Marshaler] interface. A M that has no value
+// is encoded as JSON null. Otherwise, the value is encoded as normal.
+func (m M[V]) MarshalJSON() ([]byte, error) {
+    if !m.Ok { return []byte("null"), nil }
+    return json.Marshal(m.Value)
+}
+
+// UnmarshalJSON implements the [json.Unmarshaler] interface. JSON null
+// decodes to a M that has no value. Otherwise, the JSON is decoded into a M
+// that contains a value.
+//
+// Note that, as a consequence, a M that contains a value that itself
+// encodes as JSON null (such as a nil pointer) decodes as a M with no value.
+func (m *M[V]) UnmarshalJSON(data []byte) error {
+    if bytes.Equal(bytes.TrimSpace(data), []byte("null")) {
+        *m = Nothing[V]()
+        return nil
+    }
+
+    var v V
+    if err := json.Unmarshal(data, &v); err != nil { return err }
+    *m = Some(v)
+    return nil
+}
+
+// MarshalText implements the [encoding.TextMarshaler] interface. A M that has
+// no value is encoded as empty text. Otherwise, if the value implements
+// [encoding.TextMarshaler], the result of its MarshalText method is used, or
+// if the value is a string, byte slice, number, or bool, it is encoded as
+// text in the obvious way. Other values return an error.
+func (m M[V]) MarshalText() ([]byte, error) {
+    if !m.Ok { return []byte{}, nil }
+    return codec.MarshalText(m.Value)
+}
+
+// UnmarshalText implements the [encoding.TextUnmarshaler] interface. Empty
+// text decodes to a M that has no value. Otherwise, the text is decoded into
+// a M that contains a value, in the same manner as [M.MarshalText].
+//
+// Note that, as a consequence, a M[string] that contains the empty string
+// decodes as a M with no value.
+func (m *M[V]) UnmarshalText(text []byte) error {
+    if len(text) == 0 {
+        *m = Nothing[V]()
+        return nil
+    }
+
+    var v V
+    if err := codec.UnmarshalText(&v, text); err != nil { return err }
+    *m = Some(v)
+    return nil
+}
+
+// Scan implements the [database/sql.Scanner] interface, so that a M can be
+// used to scan a nullable database column. SQL NULL scans to a M that has no
+// value. Otherwise, the value is converted in the same manner as
+// [database/sql.Rows.Scan].
+//
+// For example:
+//
+//     var name maybe.M[string]
+//     err := db.QueryRow("SELECT name FROM users WHERE id = ?", id).Scan(&name)
+func (m *M[V]) Scan(src any) error {
+    if src == nil {
+        *m = Nothing[V]()
+        return nil
+    }
+
+    var v V
+    if err := codec.Scan(&v, src); err != nil { return err }
+    *m = Some(v)
+    return nil
+}
+
+// Valuer returns a [database/sql/driver.Valuer] for the M, so that it can be
+// used as an argument to a database query. A M that has no value is stored as
+// SQL NULL.
+//
+// (M cannot implement the driver.Valuer interface itself, because its Value
+// field has the same name as the required method).
+//
+// For example:
+//
+//     var name maybe.M[string]
+//     _, err := db.Exec("UPDATE users SET name = ? WHERE id = ?", name.Valuer(), id)
+func (m M[V]) Valuer() driver.Valuer {
+    return valuer[V]{m}
+}
+
+type valuer[V any] struct {
+    m M[V]
+}
+
+func (v valuer[V]) Value() (driver.Value, error) {
+    if !v.m.Ok { return nil, nil }
+    return codec.Value(v.m.Value)
+}
@@ -0,0 +1,132 @@
+package maybe_test
+
+import (
+    "encoding/json"
+    "os"
+    "strconv"
+    "testing"
+    "time"
+
+    "github.com/stretchr/testify/assert"
+    "github.com/tawesoft/golib/v2/fun/maybe"
+)
+
+type encodingRecord struct {
+    Name maybe.M[string]
+    Age  maybe.M[int]
+    Tags maybe.M[[]string]
+    Seen maybe.M[time.Time]
+    Next maybe.M[*int]
+}
+
+func TestM_JSON(t *testing.T) {
+    golden, err := os.ReadFile("testdata/encoding.json")
+    assert.Nil(t, err)
+
+    records := []encodingRecord{
+        {},
+        {
+            Name: maybe.Some("Alice"),
+            Age:  maybe.Some(23),
+            Tags: maybe.Some([]string{"a", "b"}),
+            Seen: maybe.Some(time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)),
+        },
+        {
+            Name: maybe.Some(""),
+            Age:  maybe.Some(0),
+            Tags: maybe.Some([]string{}),
+        },
+    }
+
+    encoded, err := json.MarshalIndent(records, "", "    ")
+    assert.Nil(t, err)
+    assert.Equal(t, string(golden), string(encoded) + "\n")
+
+    var decoded []encodingRecord
+    assert.Nil(t, json.Unmarshal(golden, &decoded))
+    assert.Equal(t, records, decoded)
+
+    // null replaces an existing value
+    m := maybe.Some(5)
+    assert.Nil(t, json.Unmarshal([]byte(" null "), &m))
+    assert.Equal(t, maybe.Nothing[int](), m)
+
+    // a value that encodes as null decodes as nothing
+    encoded, err = json.Marshal(maybe.Some[*int](nil))
+    assert.Nil(t, err)
+    assert.Equal(t, "null", string(encoded))
+
+    // decoding errors are reported
+    assert.Error(t, json.Unmarshal([]byte(`"x"`), &m))
+}
+
+func TestM_Scan(t *testing.T) {
+    var s maybe.M[string]
+    assert.Nil(t, s.Scan("foo"))
+    assert.Equal(t, maybe.Some("foo"), s)
+    assert.Nil(t, s.Scan([]byte("bar")))
+    assert.Equal(t, maybe.Some("bar"), s)
+    assert.Nil(t, s.Scan(int64(5)))
+    assert.Equal(t, maybe.Some("5"), s)
+    assert.Nil(t, s.Scan(nil))
+    assert.Equal(t, maybe.Nothing[string](), s)
+
+    var i maybe.M[int8]
+    assert.Nil(t, i.Scan(int64(127)))
+    assert.Equal(t, maybe.Some(int8(127)), i)
+    assert.Nil(t, i.Scan([]byte("-128")))
+    assert.Equal(t, maybe.Some(int8(-128)), i)
+    assert.ErrorIs(t, i.Scan(int64(128)), strconv.ErrRange)
+    assert.Error(t, i.Scan(true))
+
+    var f maybe.M[float64]
+    assert.Nil(t, f.Scan(int64(3)))
+    assert.Equal(t, maybe.Some(3.0), f)
+
+    var ts maybe.M[time.Time]
+    now := time.Now()
+    assert.Nil(t, ts.Scan(now))
+    assert.Equal(t, maybe.Some(now), ts)
+}
+
+func TestM_Text(t *testing.T) {
+    text, err := maybe.Some(time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)).MarshalText()
+    assert.Nil(t, err)
+    assert.Equal(t, "2023-01-02T03:04:05Z", string(text))
+
+    text, err = maybe.Some(42).MarshalText()
+    assert.Nil(t, err)
+    assert.Equal(t, "42", string(text))
+
+    text, err = maybe.Nothing[int]().MarshalText()
+    assert.Nil(t, err)
+    assert.Equal(t, "", string(text))
+
+    _, err = maybe.Some(struct{}{}).MarshalText()
+    assert.Error(t, err)
+
+    var ts maybe.M[time.Time]
+    assert.Nil(t, ts.UnmarshalText([]byte("2023-01-02T03:04:05Z")))
+    assert.Equal(t, maybe.Some(time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)), ts)
+    assert.Nil(t, ts.UnmarshalText([]byte{}))
+    assert.Equal(t, maybe.Nothing[time.Time](), ts)
+
+    // as a map key
+    encoded, err := json.Marshal(map[maybe.M[int]]string{maybe.Some(1): "one"})
+    assert.Nil(t, err)
+    assert.Equal(t, `{"1":"one"}`, string(encoded))
+}
+
+func TestM_Valuer(t *testing.T) {
+    v, err := maybe.Some("foo").Valuer().Value()
+    assert.Nil(t, err)
+    assert.Equal(t, "foo", v)
+
+    v, err = maybe.Some(int8(5)).Valuer().Value()
+    assert.Nil(t, err)
+    assert.Equal(t, int64(5), v)
+
+    v, err = maybe.Nothing[string]().Valuer().Value()
+    assert.Nil(t, err)
+    assert.Nil(t, v)
+}
@@ -0,0 +1,51 @@
+package persistent_test
+
+import (
+    "fmt"
+    "sort"
+
+    "github.com/tawesoft/golib/v2/fun/persistent"
+    "github.com/tawesoft/golib/v2/iter"
+)
+
+func ExampleMap() {
+    type Config = persistent.Map[string, string]
+
+    v1 := Config{}.
+        Set("host", "localhost").
+        Set("port", "8080")
+
+    // v1 can be shared with other goroutines, as it never changes.
+    v2 := v1.Set("port", "9090")
+
+    port1, _ := v1.Get("port")
+    port2, _ := v2.Get("port")
+    fmt.Println(port1, port2)
+
+    // Output: 8080 9090
+}
+
+func ExampleVector_Transient() {
+    t := persistent.VectorOf("a", "b", "c").Transient()
+    t.Set(0, "A")
+    t.Pop()
+    t.Append("D")
+    v := t.Persistent()
+
+    fmt.Println(v.ToSlice())
+
+    // Output: [A b D]
+}
+
+func ExampleMapFromIter() {
+    m := persistent.MapFromIter(iter.FromMap(map[string]int{
+        "one": 1,
+        "two": 2,
+    }))
+
+    keys := iter.ToSlice(iter.Keys(m.Delete("two").Set("three", 3).Iter()))
+    sort.Strings(keys)
+    fmt.Println(m.Len(), keys)
+
+    // Output: 2 [one three]
+}
@@ -0,0 +1,97 @@
+package persistent
+
+import (
+    "encoding/binary"
+    "hash/maphash"
+    "math"
+    "reflect"
+)
+
+var seed = maphash.MakeSeed()
+
+// seed64 randomises the hash of integer keys.
+var seed64 = maphash.String(seed, "")
+
+// mix is the finaliser of the SplitMix64 generator, used to spread the bits
+// of an integer key.
+func mix(x uint64) uint64 {
+    x ^= seed64
+    x ^= x >> 30
+    x *= 0xbf58476d1ce4e5b9
+    x ^= x >> 27
+    x *= 0x94d049bb133111eb
+    x ^= x >> 31
+    return x
+}
+
+// hashOf returns a hash of a comparable key, such that keys that are equal
+// (with ==) have equal hashes.
+func hashOf[K comparable](k K) uint64 {
+    switch x := any(k).(type) {
+        case string:  return maphash.String(seed, x)
+        case int:     return mix(uint64(x))
+        case int8:    return mix(uint64(x))
+        case int16:   return mix(uint64(x))
+        case int32:   return mix(uint64(x))
+        case int64:   return mix(uint64(x))
+        case uint:    return mix(uint64(x))
+        case uint8:   return mix(uint64(x))
+        case uint16:  return mix(uint64(x))
+        case uint32:  return mix(uint64(x))
+        case uint64:  return mix(x)
+        case uintptr: return mix(uint64(x))
+    }
+
+    var h maphash.Hash
+    h.SetSeed(seed)
+    hashValue(&h, reflect.ValueOf(k))
+    return h.Sum64()
+}
+
+// hashValue writes a value of any comparable type to h.
+func hashValue(h *maphash.Hash, v reflect.Value) {
+    var buf [8]byte
+    u64 := func(x uint64) {
+        binary.LittleEndian.PutUint64(buf[:], x)
+        _, _ = h.Write(buf[:])
+    }
+    f64 := func(x float64) {
+        if x == 0 { x = 0 } // +0 == -0
+        u64(math.Float64bits(x))
+    }
+
+    switch v.Kind() {
+        case reflect.Invalid: // nil interface
+            u64(0)
+        case reflect.String:
+            _, _ = h.WriteString(v.String())
+        case reflect.Bool:
+            if v.Bool() { u64(1) } else { u64(0) }
+        case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+            u64(uint64(v.Int()))
+        case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+            u64(v.Uint())
+        case reflect.Float32, reflect.Float64:
+            f64(v.Float())
+        case reflect.Complex64, reflect.Complex128:
+            c := v.Complex()
+            f64(real(c))
+            f64(imag(c))
+        case reflect.Pointer, reflect.Chan, reflect.UnsafePointer:
+            u64(uint64(v.Pointer()))
+        case reflect.Interface:
+            hashValue(h, v.Elem())
+        case reflect.Array:
+            for i := 0; i < v.Len(); i++ {
+                hashValue(h, v.Index(i))
+            }
+        case reflect.Struct:
+            for i := 0; i < v.NumField(); i++ {
+                hashValue(h, v.Field(i))
+            }
+        default:
+            // not comparable, so cannot be a key (comparing a key that is an
+            // interface holding such a value panics anyway)
+            panic("persistent: hash of unhashable type " + v.Type().String())
+    }
+}
@@ -0,0 +1,345 @@
+package persistent
+
+import (
+    "math/bits"
+
+    "github.com/tawesoft/golib/v2/iter"
+)
+
+// maxShift is the shift of a node at which the bits of a hash are used up.
+// Such a node is a collision node: a list of entries with equal hashes.
+const maxShift = 64
+
+// hentry is an entry in a hnode. It is either a subtree, if child is not nil,
+// or a key and value.
+type hentry[K comparable, V any] struct {
+    hash  uint64
+    key   K
+    value V
+    child *hnode[K, V]
+}
+
+// hnode is a node in the hash array mapped trie of a Map. The bitmap records
+// which of the 32 possible entries exist, so that only those are stored, in
+// order.
+type hnode[K comparable, V any] struct {
+    edit    *owner
+    bitmap  uint32
+    entries []hentry[K, V]
+}
+
+// Map is an immutable, persistent map of keys to values, like a Go map
+// collection, implemented as a hash array mapped trie.
+//
+// Get, Set, and Delete are effectively constant time.
+//
+// Keys are hashed automatically. This is fastest for keys that are strings or
+// integers. Other key types are hashed (more slowly) by reflection.
+//
+// The zero value is an empty Map ready to use.
+type Map[K comparable, V any] struct {
+    count int
+    root  *hnode[K, V]
+}
+
+// MapFromIter returns a new [Map] of every key, value pair produced by an
+// iterator. If a key is produced more than once, the last value is kept.
+//
+// For example, to create a Map from a Go map collection, use
+// MapFromIter(iter.FromMap(m)).
+func MapFromIter[K comparable, V any](it iter.It[iter.Pair[K, V]]) Map[K, V] {
+    t := Map[K, V]{}.Transient()
+    for {
+        kv, ok := it()
+        if !ok { break }
+        t.Set(kv.Key, kv.Value)
+    }
+    return t.Persistent()
+}
+
+// Len returns the number of keys in the Map.
+func (m Map[K, V]) Len() int {
+    return m.count
+}
+
+// Get returns the value for key k and true, or the zero value and false if
+// the key is not in the Map.
+func (m Map[K, V]) Get(k K) (V, bool) {
+    return lookup(m.root, hashOf(k), k)
+}
+
+// Has returns true iff key k is in the Map.
+func (m Map[K, V]) Has(k K) bool {
+    _, ok := m.Get(k)
+    return ok
+}
+
+// Set returns a new Map where key k has the value v.
+func (m Map[K, V]) Set(k K, v V) Map[K, V] {
+    t := m.Transient()
+    t.Set(k, v)
+    return t.Persistent()
+}
+
+// Delete returns a new Map without the key k. If the key is not in the Map,
+// the Map is returned unchanged.
+func (m Map[K, V]) Delete(k K) Map[K, V] {
+    if !m.Has(k) { return m }
+    t := m.Transient()
+    t.Delete(k)
+    return t.Persistent()
+}
+
+// Iter returns an iterator that produces each key, value pair in the Map.
+// The order is unspecified.
+func (m Map[K, V]) Iter() iter.It[iter.Pair[K, V]] {
+    var stack [][]hentry[K, V]
+    if m.root != nil { stack = append(stack, m.root.entries) }
+
+    return func() (iter.Pair[K, V], bool) {
+        for len(stack) > 0 {
+            top := &stack[len(stack) - 1]
+            if len(*top) == 0 {
+                stack = stack[:len(stack) - 1]
+                continue
+            }
+
+            e := (*top)[0]
+            *top = (*top)[1:]
+            if e.child != nil {
+                stack = append(stack, e.child.entries)
+                continue
+            }
+
+            return iter.Pair[K, V]{Key: e.key, Value: e.value}, true
+        }
+        return iter.Pair[K, V]{}, false
+    }
+}
+
+// ToMap returns a new Go map collection of every key, value pair in the Map.
+func (m Map[K, V]) ToMap() map[K]V {
+    result := make(map[K]V, m.count)
+    iter.InsertToMap(result, nil, m.Iter())
+    return result
+}
+
+// Transient returns a new [TransientMap] initialised with the keys and
+// values of the Map. The Map is not modified.
+func (m Map[K, V]) Transient() *TransientMap[K, V] {
+    return &TransientMap[K, V]{
+        edit:  &owner{},
+        count: m.count,
+        root:  m.root,
+    }
+}
+
+// index returns the bit for a hash at the given shift, and the index of its
+// entry.
+func index(bitmap uint32, shift uint, hash uint64) (bit uint32, i int) {
+    bit = uint32(1) << ((hash >> shift) & mask)
+    return bit, bits.OnesCount32(bitmap & (bit - 1))
+}
+
+func lookup[K comparable, V any](n *hnode[K, V], hash uint64, k K) (V, bool) {
+    for shift := uint(0); n != nil; shift += bitsPerLevel {
+        if shift >= maxShift {
+            for _, e := range n.entries {
+                if e.key == k { return e.value, true }
+            }
+            break
+        }
+
+        bit, i := index(n.bitmap, shift, hash)
+        if n.bitmap & bit == 0 { break }
+
+        e := &n.entries[i]
+        if e.child != nil {
+            n = e.child
+            continue
+        }
+
+        if (e.hash == hash) && (e.key == k) { return e.value, true }
+        break
+    }
+
+    var zero V
+    return zero, false
+}
+
+// TransientMap is a mutable builder for a [Map]. Its methods modify the
+// TransientMap in place, which is faster than modifying a Map for many
+// modifications at once.
+//
+// Once done, call Persistent to get the resulting Map. After this, the
+// TransientMap must not be used again.
+type TransientMap[K comparable, V any] struct {
+    edit  *owner // nil once Persistent has been called
+    count int
+    root  *hnode[K, V]
+}
+
+func (t *TransientMap[K, V]) check() {
+    if t.edit == nil { panic(errTransient) }
+}
+
+// Persistent returns a [Map] of the keys and values in the TransientMap.
+// After this, the TransientMap must not be used again.
+func (t *TransientMap[K, V]) Persistent() Map[K, V] {
+    t.check()
+    t.edit = nil
+    return Map[K, V]{
+        count: t.count,
+        root:  t.root,
+    }
+}
+
+// Len returns the number of keys in the TransientMap.
+func (t *TransientMap[K, V]) Len() int {
+    t.check()
+    return t.count
+}
+
+// Get returns the value for key k and true, or the zero value and false if
+// the key is not in the TransientMap.
+func (t *TransientMap[K, V]) Get(k K) (V, bool) {
+    t.check()
+    return lookup(t.root, hashOf(k), k)
+}
+
+// Set sets the value of key k to v.
+func (t *TransientMap[K, V]) Set(k K, v V) {
+    t.check()
+    added := false
+    t.root = t.set(t.root, 0, hashOf(k), k, v, &added)
+    if added { t.count++ }
+}
+
+// Delete removes the key k, if it exists.
+func (t *TransientMap[K, V]) Delete(k K) {
+    t.check()
+    root, removed := t.delete(t.root, 0, hashOf(k), k)
+    if removed {
+        t.root = root
+        t.count--
+    }
+}
+
+// editable returns n, if owned by the transient, or an owned copy of n.
+func (t *TransientMap[K, V]) editable(n *hnode[K, V]) *hnode[K, V] {
+    if n == nil { return &hnode[K, V]{edit: t.edit} }
+    if n.edit == t.edit { return n }
+    c := &hnode[K, V]{
+        edit:    t.edit,
+        bitmap:  n.bitmap,
+        entries: make([]hentry[K, V], len(n.entries)),
+    }
+    copy(c.entries, n.entries)
+    return c
+}
+
+func (t *TransientMap[K, V]) set(
+    n *hnode[K, V],
+    shift uint,
+    hash uint64,
+    k K,
+    v V,
+    added *bool,
+) *hnode[K, V] {
+    n = t.editable(n)
+
+    if shift >= maxShift {
+        for i := range n.entries {
+            if n.entries[i].key != k { continue }
+            n.entries[i].value = v
+            return n
+        }
+        n.entries = append(n.entries, hentry[K, V]{hash: hash, key: k, value: v})
+        *added = true
+        return n
+    }
+
+    bit, i := index(n.bitmap, shift, hash)
+    if n.bitmap & bit == 0 {
+        n.entries = append(n.entries, hentry[K, V]{})
+        copy(n.entries[i+1:], n.entries[i:])
+        n.entries[i] = hentry[K, V]{hash: hash, key: k, value: v}
+        n.bitmap |= bit
+        *added = true
+        return n
+    }
+
+    e := &n.entries[i]
+    if e.child != nil {
+        e.child = t.set(e.child, shift + bitsPerLevel, hash, k, v, added)
+    } else if (e.hash == hash) && (e.key == k) {
+        e.value = v
+    } else {
+        // two different keys at the same position: move both to a subtree
+        var existing bool
+        child := t.set(nil, shift + bitsPerLevel, e.hash, e.key, e.value, &existing)
+        child = t.set(child, shift + bitsPerLevel, hash, k, v, added)
+        *e = hentry[K, V]{child: child}
+    }
+    return n
+}
+
+// delete returns n without key k, and true, or n unchanged and false if the
+// key does not exist. If this leaves n empty, the returned node is nil.
+func (t *TransientMap[K, V]) delete(
+    n *hnode[K, V],
+    shift uint,
+    hash uint64,
+    k K,
+) (*hnode[K, V], bool) {
+    if n == nil { return nil, false }
+
+    if shift >= maxShift {
+        for i := range n.entries {
+            if n.entries[i].key != k { continue }
+            n = t.editable(n)
+            n.remove(i)
+            return n.nilIfEmpty(), true
+        }
+        return n, false
+    }
+
+    bit, i := index(n.bitmap, shift, hash)
+    if n.bitmap & bit == 0 { return n, false }
+
+    e := n.entries[i]
+    if e.child != nil {
+        child, removed := t.delete(e.child, shift + bitsPerLevel, hash, k)
+        if !removed { return n, false }
+
+        n = t.editable(n)
+        if child == nil {
+            n.remove(i)
+            n.bitmap &^= bit
+        } else if (len(child.entries) == 1) && (child.entries[0].child == nil) {
+            // a subtree of one key is replaced by that key
+            n.entries[i] = child.entries[0]
+        } else {
+            n.entries[i].child = child
+        }
+        return n.nilIfEmpty(), true
+    }
+
+    if (e.hash != hash) || (e.key != k) { return n, false }
+
+    n = t.editable(n)
+    n.remove(i)
+    n.bitmap &^= bit
+    return n.nilIfEmpty(), true
+}
+
+func (n *hnode[K, V]) remove(i int) {
+    copy(n.entries[i:], n.entries[i+1:])
+    n.entries[len(n.entries) - 1] = hentry[K, V]{}
+    n.entries = n.entries[:len(n.entries) - 1]
+}
+
+func (n *hnode[K, V]) nilIfEmpty() *hnode[K, V] {
+    if len(n.entries) == 0 { return nil }
+    return n
+}
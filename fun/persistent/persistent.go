@@ -0,0 +1,45 @@
+// Package persistent implements immutable, persistent collections: a
+// [Vector], a [Map], and a [Set].
+//
+// Each "modification" of a persistent collection, such as [Vector.Append] or
+// [Map.Set], leaves the original unchanged and returns a new collection. The
+// new collection shares most of its structure with the original, so the cost
+// of a modification is proportional to the logarithm of the size of the
+// collection (with a large base, 32), rather than to its size, as it would be
+// to copy a slice or a map collection.
+//
+// Because they are never modified, persistent collections are safe to share
+// between goroutines without locking. For example, a configuration snapshot
+// may be published to many readers while a writer prepares the next one.
+//
+// To make many modifications at once, call Transient to get a mutable
+// builder (such as a [TransientVector]) that modifies its own nodes in place,
+// and then call its Persistent method once done. A transient is not safe for
+// concurrent use.
+package persistent
+
+import (
+    "errors"
+    "fmt"
+)
+
+const (
+    bitsPerLevel = 5
+    width        = 1 << bitsPerLevel // branching factor of each node
+    mask         = width - 1
+)
+
+// errTransient is the panic value for using a transient after its
+// Persistent method has been called.
+var errTransient = errors.New("persistent: transient used after calling Persistent")
+
+// owner identifies the transient that may modify a node in place. Nodes
+// created by a transient point to its owner. A transient only modifies nodes
+// that point to its own owner, and otherwise copies them first.
+//
+// (The field gives each owner a distinct address).
+type owner struct{ _ int }
+
+func outOfRange(i int, n int) error {
+    return fmt.Errorf("persistent: index %d out of range [0:%d]", i, n)
+}
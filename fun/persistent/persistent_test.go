@@ -0,0 +1,266 @@
+package persistent
+
+import (
+    "math/rand"
+    "sort"
+    "testing"
+
+    "github.com/tawesoft/golib/v2/iter"
+)
+
+func TestVector(t *testing.T) {
+    const n = 40_000
+    var v Vector[int]
+    var versions []Vector[int]
+    var expected []int
+
+    for i := 0; i < n; i++ {
+        if i % 997 == 0 { versions = append(versions, v) }
+        v = v.Append(i)
+        expected = append(expected, i)
+    }
+
+    check := func(name string, v Vector[int], expected []int) {
+        t.Helper()
+        if v.Len() != len(expected) {
+            t.Fatalf("%s: got length %d, expected %d", name, v.Len(), len(expected))
+        }
+        for i, x := range expected {
+            if got := v.Get(i); got != x {
+                t.Fatalf("%s: Get(%d): got %d, expected %d", name, i, got, x)
+            }
+        }
+        i := 0
+        for it := v.Iter(); ; i++ {
+            x, ok := it()
+            if !ok { break }
+            if x != expected[i] {
+                t.Fatalf("%s: Iter: at %d got %d, expected %d", name, i, x, expected[i])
+            }
+        }
+        if i != len(expected) {
+            t.Fatalf("%s: Iter: got %d values, expected %d", name, i, len(expected))
+        }
+    }
+
+    check("appended", v, expected)
+
+    // earlier versions are unchanged
+    for i, version := range versions {
+        check("version", version, expected[:i * 997])
+    }
+
+    // set
+    w := v
+    r := rand.New(rand.NewSource(1))
+    changed := append([]int(nil), expected...)
+    for i := 0; i < 1000; i++ {
+        idx := r.Intn(n)
+        w = w.Set(idx, -idx)
+        changed[idx] = -idx
+    }
+    check("set", w, changed)
+    check("original after set", v, expected)
+
+    // pop back down to empty
+    for i := n; i > 0; i-- {
+        v = v.Pop()
+        if v.Len() != i - 1 { t.Fatalf("pop: got length %d, expected %d", v.Len(), i - 1) }
+        if (i % 1013 == 0) || (i < 70) { check("pop", v, expected[:i - 1]) }
+    }
+    check("popped", v, nil)
+
+    // appending again after popping
+    v = v.Append(1).Append(2)
+    check("after pop", v, []int{1, 2})
+}
+
+func TestTransientVector(t *testing.T) {
+    base := VectorFromIter(iter.Take(5000, iter.Counter(0, 1)))
+
+    tr := base.Transient()
+    for i := 0; i < 5000; i++ {
+        tr.Set(i, i * 2)
+    }
+    for i := 0; i < 2000; i++ {
+        tr.Pop()
+    }
+    for i := 0; i < 100; i++ {
+        tr.Append(-i)
+    }
+    v := tr.Persistent()
+
+    if v.Len() != 3100 { t.Fatalf("got length %d", v.Len()) }
+    for i := 0; i < 3000; i++ {
+        if v.Get(i) != i * 2 { t.Fatalf("Get(%d): got %d", i, v.Get(i)) }
+    }
+    for i := 0; i < 100; i++ {
+        if v.Get(3000 + i) != -i { t.Fatalf("Get(%d): got %d", 3000 + i, v.Get(3000 + i)) }
+    }
+
+    // the original is unchanged
+    if base.Len() != 5000 { t.Fatalf("base: got length %d", base.Len()) }
+    for i := 0; i < 5000; i++ {
+        if base.Get(i) != i { t.Fatalf("base: Get(%d): got %d", i, base.Get(i)) }
+    }
+
+    func() {
+        defer func() {
+            if recover() == nil { t.Errorf("expected panic using transient after Persistent") }
+        }()
+        tr.Append(1)
+    }()
+}
+
+func checkMap[K comparable](t *testing.T, name string, m Map[K, int], expected map[K]int) {
+    t.Helper()
+    if m.Len() != len(expected) {
+        t.Fatalf("%s: got length %d, expected %d", name, m.Len(), len(expected))
+    }
+    for k, v := range expected {
+        if got, ok := m.Get(k); !ok || got != v {
+            t.Fatalf("%s: Get(%v): got %d, %t, expected %d", name, k, got, ok, v)
+        }
+    }
+    got := m.ToMap()
+    if len(got) != len(expected) {
+        t.Fatalf("%s: Iter: got %d keys, expected %d", name, len(got), len(expected))
+    }
+}
+
+func TestMap(t *testing.T) {
+    const n = 20_000
+    var m Map[int, int]
+    expected := make(map[int]int)
+    r := rand.New(rand.NewSource(1))
+
+    for i := 0; i < n; i++ {
+        k := r.Intn(n)
+        m = m.Set(k, i)
+        expected[k] = i
+    }
+    checkMap(t, "set", m, expected)
+
+    before := m
+    beforeExpected := make(map[int]int)
+    for k, v := range expected { beforeExpected[k] = v }
+
+    for i := 0; i < n; i++ {
+        k := r.Intn(n)
+        m = m.Delete(k)
+        delete(expected, k)
+        if _, ok := m.Get(k); ok { t.Fatalf("Get(%d) after Delete", k) }
+    }
+    checkMap(t, "delete", m, expected)
+    checkMap(t, "original after delete", before, beforeExpected)
+
+    for k := range expected {
+        m = m.Delete(k)
+    }
+    if (m.Len() != 0) || (m.root != nil) {
+        t.Errorf("expected empty map, got length %d", m.Len())
+    }
+}
+
+func TestMap_keys(t *testing.T) {
+    type key struct {
+        Name string
+        Ptr  *int
+        F    float64
+        I    any
+    }
+    var x int
+
+    m := Map[key, int]{}.
+        Set(key{"a", &x, 0, nil}, 1).
+        Set(key{"a", nil, 1.5, 2}, 2).
+        Set(key{"a", nil, 1.5, "2"}, 3)
+
+    tests := []struct {
+        k  key
+        v  int
+        ok bool
+    }{
+        {key{"a", &x, 0, nil}, 1, true},
+        {key{"a", &x, negativeZero(), nil}, 1, true},
+        {key{"a", nil, 1.5, 2}, 2, true},
+        {key{"a", nil, 1.5, "2"}, 3, true},
+        {key{"b", nil, 1.5, 2}, 0, false},
+    }
+    for i, tt := range tests {
+        v, ok := m.Get(tt.k)
+        if (v != tt.v) || (ok != tt.ok) {
+            t.Errorf("test %d: got %d, %t, expected %d, %t", i, v, ok, tt.v, tt.ok)
+        }
+    }
+}
+
+func negativeZero() float64 {
+    x := 0.0
+    return -x
+}
+
+// TestMap_collisions exercises collision nodes, where different keys have
+// the same hash.
+func TestMap_collisions(t *testing.T) {
+    tr := Map[int, int]{}.Transient()
+    for i := 0; i < 10; i++ {
+        var added bool
+        tr.root = tr.set(tr.root, 0, 12345, i, i * 10, &added)
+        if !added { t.Fatalf("expected key %d to be added", i) }
+        tr.count++
+    }
+    m := tr.Persistent()
+
+    for i := 0; i < 10; i++ {
+        if v, ok := lookup(m.root, 12345, i); !ok || v != i * 10 {
+            t.Fatalf("lookup(%d): got %d, %t", i, v, ok)
+        }
+    }
+    if _, ok := lookup(m.root, 12345, 10); ok {
+        t.Fatalf("lookup(10): expected missing")
+    }
+
+    var keys []int
+    for it := m.Iter(); ; {
+        kv, ok := it()
+        if !ok { break }
+        keys = append(keys, kv.Key)
+    }
+    sort.Ints(keys)
+    if len(keys) != 10 { t.Fatalf("Iter: got %v", keys) }
+
+    tr = m.Transient()
+    for i := 0; i < 9; i++ {
+        root, removed := tr.delete(tr.root, 0, 12345, i)
+        if !removed { t.Fatalf("expected key %d to be removed", i) }
+        tr.root = root
+    }
+
+    // the last key is pulled up out of the collision node
+    if (len(tr.root.entries) != 1) || (tr.root.entries[0].child != nil) {
+        t.Errorf("expected a single entry at the root")
+    }
+    if v, ok := lookup(tr.root, 12345, 9); !ok || v != 90 {
+        t.Fatalf("lookup(9): got %d, %t", v, ok)
+    }
+}
+
+func TestSet(t *testing.T) {
+    s := SetOf("a", "b", "c", "a")
+    if s.Len() != 3 { t.Fatalf("got length %d", s.Len()) }
+
+    s2 := s.Add("d").Delete("a")
+    if !s2.Has("d") || s2.Has("a") || (s2.Len() != 3) {
+        t.Errorf("unexpected set after Add and Delete")
+    }
+    if !s.Has("a") || s.Has("d") {
+        t.Errorf("original set was modified")
+    }
+
+    got := iter.ToSlice(s2.Iter())
+    sort.Strings(got)
+    if (len(got) != 3) || (got[0] != "b") || (got[1] != "c") || (got[2] != "d") {
+        t.Errorf("Iter: got %v", got)
+    }
+}
@@ -0,0 +1,104 @@
+package persistent
+
+import (
+    "github.com/tawesoft/golib/v2/iter"
+)
+
+// Set is an immutable, persistent set of unique values, implemented as a
+// [Map] with empty values.
+//
+// The zero value is an empty Set ready to use.
+type Set[X comparable] struct {
+    m Map[X, struct{}]
+}
+
+// SetOf returns a new [Set] of the arguments.
+func SetOf[X comparable](xs ... X) Set[X] {
+    t := Set[X]{}.Transient()
+    for _, x := range xs {
+        t.Add(x)
+    }
+    return t.Persistent()
+}
+
+// SetFromIter returns a new [Set] of every value produced by an iterator.
+func SetFromIter[X comparable](it iter.It[X]) Set[X] {
+    t := Set[X]{}.Transient()
+    for {
+        x, ok := it()
+        if !ok { break }
+        t.Add(x)
+    }
+    return t.Persistent()
+}
+
+// Len returns the number of values in the Set.
+func (s Set[X]) Len() int {
+    return s.m.Len()
+}
+
+// Has returns true iff x is in the Set.
+func (s Set[X]) Has(x X) bool {
+    return s.m.Has(x)
+}
+
+// Add returns a new Set that contains x.
+func (s Set[X]) Add(x X) Set[X] {
+    if s.m.Has(x) { return s }
+    return Set[X]{s.m.Set(x, struct{}{})}
+}
+
+// Delete returns a new Set that does not contain x. If x is not in the Set,
+// the Set is returned unchanged.
+func (s Set[X]) Delete(x X) Set[X] {
+    return Set[X]{s.m.Delete(x)}
+}
+
+// Iter returns an iterator that produces each value in the Set. The order is
+// unspecified.
+func (s Set[X]) Iter() iter.It[X] {
+    return iter.Keys(s.m.Iter())
+}
+
+// Transient returns a new [TransientSet] initialised with the values of the
+// Set. The Set is not modified.
+func (s Set[X]) Transient() *TransientSet[X] {
+    return &TransientSet[X]{s.m.Transient()}
+}
+
+// TransientSet is a mutable builder for a [Set]. Its methods modify the
+// TransientSet in place, which is faster than modifying a Set for many
+// modifications at once.
+//
+// Once done, call Persistent to get the resulting Set. After this, the
+// TransientSet must not be used again.
+type TransientSet[X comparable] struct {
+    m *TransientMap[X, struct{}]
+}
+
+// Persistent returns a [Set] of the values in the TransientSet. After this,
+// the TransientSet must not be used again.
+func (t *TransientSet[X]) Persistent() Set[X] {
+    return Set[X]{t.m.Persistent()}
+}
+
+// Len returns the number of values in the TransientSet.
+func (t *TransientSet[X]) Len() int {
+    return t.m.Len()
+}
+
+// Has returns true iff x is in the TransientSet.
+func (t *TransientSet[X]) Has(x X) bool {
+    _, ok := t.m.Get(x)
+    return ok
+}
+
+// Add adds x to the TransientSet, if not already present.
+func (t *TransientSet[X]) Add(x X) {
+    t.m.Set(x, struct{}{})
+}
+
+// Delete removes x from the TransientSet, if present.
+func (t *TransientSet[X]) Delete(x X) {
+    t.m.Delete(x)
+}
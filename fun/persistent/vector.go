@@ -0,0 +1,318 @@
+package persistent
+
+import (
+    "github.com/tawesoft/golib/v2/iter"
+)
+
+// vnode is a node in the trie of a Vector. Internal nodes have children,
+// and leaf nodes have values, each of length width.
+type vnode[X any] struct {
+    edit     *owner
+    children []*vnode[X]
+    values   []X
+}
+
+// Vector is an immutable, persistent, ordered sequence of values, like a
+// slice, implemented as a 32-way trie.
+//
+// Get and Set are effectively constant time, and Append and Pop are
+// amortised constant time, because the last (up to 32) values are kept
+// outside the trie.
+//
+// The zero value is an empty Vector ready to use.
+type Vector[X any] struct {
+    count int
+    shift uint      // of the root node
+    root  *vnode[X] // nil if every value is in the tail
+    tail  []X       // the last 1 to 32 values, or none if empty
+}
+
+// VectorOf returns a new [Vector] of the arguments, in order.
+func VectorOf[X any](xs ... X) Vector[X] {
+    t := Vector[X]{}.Transient()
+    for _, x := range xs {
+        t.Append(x)
+    }
+    return t.Persistent()
+}
+
+// VectorFromIter returns a new [Vector] of every value produced by an
+// iterator, in order.
+func VectorFromIter[X any](it iter.It[X]) Vector[X] {
+    t := Vector[X]{}.Transient()
+    for {
+        x, ok := it()
+        if !ok { break }
+        t.Append(x)
+    }
+    return t.Persistent()
+}
+
+// Len returns the number of values in the Vector.
+func (v Vector[X]) Len() int {
+    return v.count
+}
+
+// Get returns the value at index i. It panics if i is out of range.
+func (v Vector[X]) Get(i int) X {
+    if (i < 0) || (i >= v.count) { panic(outOfRange(i, v.count)) }
+    return chunkFor(v.root, v.shift, v.count - len(v.tail), v.tail, i)[i & mask]
+}
+
+// Set returns a new Vector with the value at index i replaced by x. It
+// panics if i is out of range.
+func (v Vector[X]) Set(i int, x X) Vector[X] {
+    t := v.Transient()
+    t.Set(i, x)
+    return t.Persistent()
+}
+
+// Append returns a new Vector with x added to the end.
+func (v Vector[X]) Append(x X) Vector[X] {
+    t := v.Transient()
+    t.Append(x)
+    return t.Persistent()
+}
+
+// Pop returns a new Vector with the last value removed. It panics if the
+// Vector is empty.
+func (v Vector[X]) Pop() Vector[X] {
+    t := v.Transient()
+    t.Pop()
+    return t.Persistent()
+}
+
+// Iter returns an iterator that produces each value in the Vector, in order.
+func (v Vector[X]) Iter() iter.It[X] {
+    i := 0
+    var chunk []X
+    tailOffset := v.count - len(v.tail)
+    return func() (X, bool) {
+        if i >= v.count {
+            var zero X
+            return zero, false
+        }
+        if i & mask == 0 {
+            chunk = chunkFor(v.root, v.shift, tailOffset, v.tail, i)
+        }
+        x := chunk[i & mask]
+        i++
+        return x, true
+    }
+}
+
+// ToSlice returns a new slice of every value in the Vector, in order.
+func (v Vector[X]) ToSlice() []X {
+    result := make([]X, 0, v.count)
+    return iter.AppendToSlice(result, v.Iter())
+}
+
+// Transient returns a new [TransientVector] initialised with the values of
+// the Vector. The Vector is not modified.
+func (v Vector[X]) Transient() *TransientVector[X] {
+    tail := make([]X, len(v.tail), width)
+    copy(tail, v.tail)
+    return &TransientVector[X]{
+        edit:  &owner{},
+        count: v.count,
+        shift: v.shift,
+        root:  v.root,
+        tail:  tail,
+    }
+}
+
+// chunkFor returns the leaf values, or the tail, that contain index i.
+func chunkFor[X any](root *vnode[X], shift uint, tailOffset int, tail []X, i int) []X {
+    if i >= tailOffset { return tail }
+    n := root
+    for level := shift; level > 0; level -= bitsPerLevel {
+        n = n.children[(i >> level) & mask]
+    }
+    return n.values
+}
+
+// TransientVector is a mutable builder for a [Vector]. Its methods modify
+// the TransientVector in place, which is faster than modifying a Vector for
+// many modifications at once.
+//
+// Once done, call Persistent to get the resulting Vector. After this, the
+// TransientVector must not be used again.
+type TransientVector[X any] struct {
+    edit  *owner // nil once Persistent has been called
+    count int
+    shift uint
+    root  *vnode[X]
+    tail  []X // always owned by the transient, with capacity width
+}
+
+func (t *TransientVector[X]) check() {
+    if t.edit == nil { panic(errTransient) }
+}
+
+// Persistent returns a [Vector] of the values in the TransientVector. After
+// this, the TransientVector must not be used again.
+func (t *TransientVector[X]) Persistent() Vector[X] {
+    t.check()
+    t.edit = nil
+    return Vector[X]{
+        count: t.count,
+        shift: t.shift,
+        root:  t.root,
+        tail:  t.tail,
+    }
+}
+
+// Len returns the number of values in the TransientVector.
+func (t *TransientVector[X]) Len() int {
+    t.check()
+    return t.count
+}
+
+// Get returns the value at index i. It panics if i is out of range.
+func (t *TransientVector[X]) Get(i int) X {
+    t.check()
+    if (i < 0) || (i >= t.count) { panic(outOfRange(i, t.count)) }
+    return chunkFor(t.root, t.shift, t.count - len(t.tail), t.tail, i)[i & mask]
+}
+
+// Set replaces the value at index i with x. It panics if i is out of range.
+func (t *TransientVector[X]) Set(i int, x X) {
+    t.check()
+    if (i < 0) || (i >= t.count) { panic(outOfRange(i, t.count)) }
+
+    tailOffset := t.count - len(t.tail)
+    if i >= tailOffset {
+        t.tail[i - tailOffset] = x
+        return
+    }
+
+    t.root = t.assoc(t.shift, t.root, i, x)
+}
+
+// Append adds x to the end.
+func (t *TransientVector[X]) Append(x X) {
+    t.check()
+
+    if len(t.tail) < width {
+        t.tail = append(t.tail, x)
+        t.count++
+        return
+    }
+
+    // the tail is full, so push it into the trie as a leaf
+    leaf := &vnode[X]{edit: t.edit, values: t.tail}
+    if t.root == nil {
+        t.root = t.newInternal()
+        t.root.children[0] = leaf
+        t.shift = bitsPerLevel
+    } else if (t.count >> bitsPerLevel) > (1 << t.shift) {
+        // the trie is full, so add a new root level
+        root := t.newInternal()
+        root.children[0] = t.root
+        root.children[1] = t.newPath(t.shift, leaf)
+        t.root = root
+        t.shift += bitsPerLevel
+    } else {
+        t.root = t.pushTail(t.shift, t.root, leaf)
+    }
+
+    t.tail = make([]X, 1, width)
+    t.tail[0] = x
+    t.count++
+}
+
+// Pop removes the last value. It panics if the TransientVector is empty.
+func (t *TransientVector[X]) Pop() {
+    t.check()
+    if t.count == 0 { panic(outOfRange(-1, 0)) }
+
+    if (len(t.tail) > 1) || (t.root == nil) {
+        var zero X
+        t.tail[len(t.tail) - 1] = zero
+        t.tail = t.tail[:len(t.tail) - 1]
+        t.count--
+        return
+    }
+
+    // the tail becomes empty, so the last leaf in the trie becomes the tail
+    tail := make([]X, width)
+    copy(tail, chunkFor(t.root, t.shift, t.count - 1, nil, t.count - 2))
+
+    root, shift := t.popTail(t.shift, t.root), t.shift
+    if root == nil {
+        shift = 0
+    } else if (shift > bitsPerLevel) && (root.children[1] == nil) {
+        root = root.children[0]
+        shift -= bitsPerLevel
+    }
+
+    t.root, t.shift, t.tail = root, shift, tail
+    t.count--
+}
+
+func (t *TransientVector[X]) newInternal() *vnode[X] {
+    return &vnode[X]{edit: t.edit, children: make([]*vnode[X], width)}
+}
+
+// editable returns n, if owned by the transient, or an owned copy of n.
+func (t *TransientVector[X]) editable(n *vnode[X]) *vnode[X] {
+    if n.edit == t.edit { return n }
+    c := &vnode[X]{edit: t.edit}
+    if n.children != nil {
+        c.children = make([]*vnode[X], width)
+        copy(c.children, n.children)
+    } else {
+        c.values = make([]X, width)
+        copy(c.values, n.values)
+    }
+    return c
+}
+
+func (t *TransientVector[X]) assoc(level uint, n *vnode[X], i int, x X) *vnode[X] {
+    n = t.editable(n)
+    if level == 0 {
+        n.values[i & mask] = x
+    } else {
+        sub := (i >> level) & mask
+        n.children[sub] = t.assoc(level - bitsPerLevel, n.children[sub], i, x)
+    }
+    return n
+}
+
+// newPath returns a chain of new internal nodes from level down to leaf.
+func (t *TransientVector[X]) newPath(level uint, leaf *vnode[X]) *vnode[X] {
+    if level == 0 { return leaf }
+    n := t.newInternal()
+    n.children[0] = t.newPath(level - bitsPerLevel, leaf)
+    return n
+}
+
+func (t *TransientVector[X]) pushTail(level uint, parent *vnode[X], leaf *vnode[X]) *vnode[X] {
+    n := t.editable(parent)
+    sub := ((t.count - 1) >> level) & mask
+    if level == bitsPerLevel {
+        n.children[sub] = leaf
+    } else if child := n.children[sub]; child != nil {
+        n.children[sub] = t.pushTail(level - bitsPerLevel, child, leaf)
+    } else {
+        n.children[sub] = t.newPath(level - bitsPerLevel, leaf)
+    }
+    return n
+}
+
+// popTail returns n without its last leaf, or nil if that leaves n empty.
+func (t *TransientVector[X]) popTail(level uint, n *vnode[X]) *vnode[X] {
+    sub := ((t.count - 2) >> level) & mask
+    if level > bitsPerLevel {
+        child := t.popTail(level - bitsPerLevel, n.children[sub])
+        if (child == nil) && (sub == 0) { return nil }
+        n = t.editable(n)
+        n.children[sub] = child
+        return n
+    } else if sub == 0 {
+        return nil
+    }
+    n = t.editable(n)
+    n.children[sub] = nil
+    return n
+}
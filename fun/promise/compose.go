@@ -0,0 +1,212 @@
+package promise
+
+import (
+    "context"
+    "sync"
+
+    "github.com/tawesoft/golib/v2/tuple"
+)
+
+// Then returns a new promise to compute the promise returned by function f
+// for the result of promise p. Unlike [Chain], the second step is itself a
+// promise (such as a [FuncCtx]), so it receives the context.
+func Then[X any, Y any](p P[X], f func(X) P[Y]) P[Y] {
+    return FromResultFuncCtx[Y](func(ctx context.Context) (Y, error) {
+        v, err := p.ComputeCtx(ctx)
+        if err != nil {
+            var zero Y
+            return zero, err
+        }
+        return f(v).ComputeCtx(ctx)
+    })
+}
+
+// ThenCtx is like [Chain], but function f also receives the context used to
+// compute the returned promise.
+func ThenCtx[X any, Y any](p P[X], f func(context.Context, X) (Y, error)) P[Y] {
+    return FromResultFuncCtx[Y](func(ctx context.Context) (Y, error) {
+        v, err := p.ComputeCtx(ctx)
+        if err != nil {
+            var zero Y
+            return zero, err
+        }
+        return f(ctx, v)
+    })
+}
+
+// Recover returns a new promise to compute promise p, but where, if p
+// computes an error, function f is called with that error. The returned
+// promise then computes the result of f instead. For example, f may map
+// certain errors to a default value, and return any other error unchanged.
+func Recover[X any](p P[X], f func(error) (X, error)) P[X] {
+    return FromResultFuncCtx[X](func(ctx context.Context) (X, error) {
+        v, err := p.ComputeCtx(ctx)
+        if err != nil { return f(err) }
+        return v, nil
+    })
+}
+
+type memo[X any] struct {
+    mu      sync.Mutex
+    p       P[X]
+    done    bool
+    value   X
+    err     error
+    running chan struct{} // non-nil, and closed when done, while computing
+}
+
+// Memoize returns a new promise that computes promise p at most once, and
+// returns the same result each time it is computed. Unlike most promises, the
+// returned promise may be computed any number of times, and is safe for
+// concurrent use. If computed concurrently, only one goroutine computes p,
+// and the others wait for its result.
+//
+// This means that a memoized promise may be shared as an input to several
+// other promises, such as in a graph of promises composed with [Zip2], and
+// still only be computed once.
+//
+// As a special case, if p returns an error while the context used to compute
+// it has been cancelled, the result is not stored, so that a later
+// computation, with a different context, may try again. A goroutine waiting
+// on another goroutine's computation stops waiting, with a context error,
+// when its own context is cancelled.
+func Memoize[X any](p P[X]) P[X] {
+    return &memo[X]{p: p}
+}
+
+func (m *memo[X]) Compute() (X, error) {
+    return m.ComputeCtx(context.TODO())
+}
+
+func (m *memo[X]) ComputeCtx(ctx context.Context) (X, error) {
+    for {
+        m.mu.Lock()
+
+        if m.done {
+            value, err := m.value, m.err
+            m.mu.Unlock()
+            return value, err
+        }
+
+        if m.running == nil {
+            running := make(chan struct{})
+            m.running = running
+            p := m.p
+            m.mu.Unlock()
+            return m.compute(ctx, p, running)
+        }
+
+        running := m.running
+        m.mu.Unlock()
+
+        select {
+            case <- running:
+                continue
+            case <- ctx.Done():
+                var zero X
+                return zero, ctx.Err()
+        }
+    }
+}
+
+func (m *memo[X]) compute(ctx context.Context, p P[X], running chan struct{}) (value X, err error) {
+    done := false
+    defer func() {
+        // even if p panics, unblock any waiting goroutines
+        m.mu.Lock()
+        m.running = nil
+        if done {
+            m.done = true
+            m.value, m.err = value, err
+            m.p = nil // allow garbage collection
+        }
+        m.mu.Unlock()
+        close(running)
+    }()
+
+    value, err = p.ComputeCtx(ctx)
+    done = (err == nil) || (ctx.Err() == nil)
+    return value, err
+}
+
+// all calls each function concurrently, each in a new goroutine, and waits
+// for every function to return. If any function returns an error, the context
+// passed to each function is cancelled, and all returns that first error.
+func all(ctx context.Context, fs ... func(context.Context) error) error {
+    ctx, cancel := context.WithCancel(ctx)
+    defer cancel()
+
+    var wg sync.WaitGroup
+    var once sync.Once
+    var first error
+
+    wg.Add(len(fs))
+    for _, f := range fs {
+        go func(f func(context.Context) error) {
+            defer wg.Done()
+            if err := f(ctx); err != nil {
+                once.Do(func() {
+                    first = err
+                    cancel()
+                })
+            }
+        }(f)
+    }
+
+    wg.Wait()
+    return first
+}
+
+// Zip2 returns a new promise to compute promises a and b, concurrently, each
+// in a new goroutine, and combine their values into a [tuple.T2]. If any
+// input promise computes an error, the context used to compute the others is
+// cancelled, and the returned promise computes that first error.
+//
+// The input promises must be safe to compute concurrently with each other.
+// If an input promise is shared, for example where the same promise is used
+// as an input to both a and b, use [Memoize].
+//
+// Because promises are lazy, and Zip2 computes its inputs concurrently, Zip2
+// can be used to build a graph of computations where independent branches
+// are computed concurrently. The computation of the entire graph can itself
+// be started asynchronously e.g. with future.NewAsync.
+func Zip2[A any, B any](a P[A], b P[B]) P[tuple.T2[A, B]] {
+    return FromResultFuncCtx(func(ctx context.Context) (tuple.T2[A, B], error) {
+        var t tuple.T2[A, B]
+        err := all(ctx,
+            func(ctx context.Context) (err error) { t.A, err = a.ComputeCtx(ctx); return },
+            func(ctx context.Context) (err error) { t.B, err = b.ComputeCtx(ctx); return },
+        )
+        if err != nil { return tuple.T2[A, B]{}, err }
+        return t, nil
+    })
+}
+
+// Zip3 is like [Zip2], but for three promises combined into a [tuple.T3].
+func Zip3[A any, B any, C any](a P[A], b P[B], c P[C]) P[tuple.T3[A, B, C]] {
+    return FromResultFuncCtx(func(ctx context.Context) (tuple.T3[A, B, C], error) {
+        var t tuple.T3[A, B, C]
+        err := all(ctx,
+            func(ctx context.Context) (err error) { t.A, err = a.ComputeCtx(ctx); return },
+            func(ctx context.Context) (err error) { t.B, err = b.ComputeCtx(ctx); return },
+            func(ctx context.Context) (err error) { t.C, err = c.ComputeCtx(ctx); return },
+        )
+        if err != nil { return tuple.T3[A, B, C]{}, err }
+        return t, nil
+    })
+}
+
+// Zip4 is like [Zip2], but for four promises combined into a [tuple.T4].
+func Zip4[A any, B any, C any, D any](a P[A], b P[B], c P[C], d P[D]) P[tuple.T4[A, B, C, D]] {
+    return FromResultFuncCtx(func(ctx context.Context) (tuple.T4[A, B, C, D], error) {
+        var t tuple.T4[A, B, C, D]
+        err := all(ctx,
+            func(ctx context.Context) (err error) { t.A, err = a.ComputeCtx(ctx); return },
+            func(ctx context.Context) (err error) { t.B, err = b.ComputeCtx(ctx); return },
+            func(ctx context.Context) (err error) { t.C, err = c.ComputeCtx(ctx); return },
+            func(ctx context.Context) (err error) { t.D, err = d.ComputeCtx(ctx); return },
+        )
+        if err != nil { return tuple.T4[A, B, C, D]{}, err }
+        return t, nil
+    })
+}
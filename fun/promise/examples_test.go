@@ -0,0 +1,65 @@
+package promise_test
+
+import (
+    "context"
+    "errors"
+    "fmt"
+    "os"
+    "sync/atomic"
+
+    "github.com/tawesoft/golib/v2/fun/future"
+    "github.com/tawesoft/golib/v2/fun/promise"
+    "github.com/tawesoft/golib/v2/tuple"
+)
+
+func ExampleMemoize() {
+    // A graph of computations shaped like a diamond: config is needed by
+    // both users and groups, which are computed concurrently, and then
+    // combined into a report.
+
+    var loads atomic.Int32
+    config := promise.Memoize(promise.FromFunc(func() string {
+        loads.Add(1)
+        return "example.org"
+    }))
+
+    users := promise.Chain(config, func(host string) ([]string, error) {
+        return []string{"alice@" + host, "bob@" + host}, nil
+    })
+    groups := promise.ThenCtx(config, func(ctx context.Context, host string) (int, error) {
+        return len(host), ctx.Err()
+    })
+    report := promise.Chain(promise.Zip2(users, groups),
+        func(t tuple.T2[[]string, int]) (string, error) {
+            return fmt.Sprintf("%d users, %d groups", len(t.A), t.B), nil
+        },
+    )
+
+    // nothing has been computed yet. Start computing in the background...
+    f := future.NewAsync(context.Background(), report)
+    defer f.Stop()
+
+    fmt.Println(f.Collect())
+    fmt.Printf("config loaded %d time(s)\n", loads.Load())
+
+    // Output:
+    // 2 users, 11 groups <nil>
+    // config loaded 1 time(s)
+}
+
+func ExampleRecover() {
+    readConfig := promise.FromResultFunc(func() ([]byte, error) {
+        return os.ReadFile("testdata/does-not-exist.conf")
+    })
+
+    withDefault := promise.Recover(readConfig, func(err error) ([]byte, error) {
+        if errors.Is(err, os.ErrNotExist) { return []byte("default"), nil }
+        return nil, err
+    })
+
+    config, err := withDefault.Compute()
+    fmt.Println(string(config), err)
+
+    // Output:
+    // default <nil>
+}
@@ -0,0 +1,79 @@
+package result
+
+import (
+    "database/sql/driver"
+    "encoding/json"
+
+    "github.com/tawesoft/golib/v2/internal/codec"
+)
+
+// MarshalJSON implements the [json.Marshaler] interface. A R that is not an
+// error is encoded as its value. Encoding a R that is an error fails with
+// that error.
+func (r R[V]) MarshalJSON() ([]byte, error) {
+    if r.Error != nil { return nil, r.Error }
+    return json.Marshal(r.Value)
+}
+
+// UnmarshalJSON implements the [json.Unmarshaler] interface. The JSON is
+// decoded into a R that contains a value and is not an error. If decoding
+// fails, the R is left unchanged and the decoding error is returned.
+func (r *R[V]) UnmarshalJSON(data []byte) error {
+    var v V
+    if err := json.Unmarshal(data, &v); err != nil { return err }
+    *r = Some(v)
+    return nil
+}
+
+// MarshalText implements the [encoding.TextMarshaler] interface. Encoding a R
+// that is an error fails with that error. Otherwise, if the value implements
+// [encoding.TextMarshaler], the result of its MarshalText method is used, or
+// if the value is a string, byte slice, number, or bool, it is encoded as
+// text in the obvious way. Other values return an error.
+func (r R[V]) MarshalText() ([]byte, error) {
+    if r.Error != nil { return nil, r.Error }
+    return codec.MarshalText(r.Value)
+}
+
+// UnmarshalText implements the [encoding.TextUnmarshaler] interface. The text
+// is decoded, in the same manner as [R.MarshalText], into a R that contains a
+// value and is not an error. If decoding fails, the R is left unchanged and
+// the decoding error is returned.
+func (r *R[V]) UnmarshalText(text []byte) error {
+    var v V
+    if err := codec.UnmarshalText(&v, text); err != nil { return err }
+    *r = Some(v)
+    return nil
+}
+
+// Scan implements the [database/sql.Scanner] interface. The database value is
+// converted, in the same manner as [database/sql.Rows.Scan], into a R that
+// contains a value and is not an error. If conversion fails, the R is left
+// unchanged and the conversion error is returned.
+//
+// To scan a nullable column, see maybe.M instead.
+func (r *R[V]) Scan(src any) error {
+    var v V
+    if err := codec.Scan(&v, src); err != nil { return err }
+    *r = Some(v)
+    return nil
+}
+
+// Valuer returns a [database/sql/driver.Valuer] for the R, so that it can be
+// used as an argument to a database query. If the R is an error, the Valuer
+// returns that error.
+//
+// (R cannot implement the driver.Valuer interface itself, because its Value
+// field has the same name as the required method).
+func (r R[V]) Valuer() driver.Valuer {
+    return valuer[V]{r}
+}
+
+type valuer[V any] struct {
+    r R[V]
+}
+
+func (v valuer[V]) Value() (driver.Value, error) {
+    if v.r.Error != nil { return nil, v.r.Error }
+    return codec.Value(v.r.Value)
+}
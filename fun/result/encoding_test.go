@@ -0,0 +1,86 @@
+package result_test
+
+import (
+    "encoding/json"
+    "errors"
+    "os"
+    "testing"
+
+    "github.com/stretchr/testify/assert"
+    "github.com/tawesoft/golib/v2/fun/result"
+)
+
+type encodingRecord struct {
+    Name result.R[string]
+    Age  result.R[int]
+    Tags result.R[[]string]
+}
+
+func TestR_JSON(t *testing.T) {
+    golden, err := os.ReadFile("testdata/encoding.json")
+    assert.Nil(t, err)
+
+    records := []encodingRecord{
+        {
+            Name: result.Some("Alice"),
+            Age:  result.Some(23),
+            Tags: result.Some([]string{"a", "b"}),
+        },
+        {
+            Name: result.Some(""),
+            Age:  result.Some(0),
+            Tags: result.Some[[]string](nil),
+        },
+    }
+
+    encoded, err := json.MarshalIndent(records, "", "    ")
+    assert.Nil(t, err)
+    assert.Equal(t, string(golden), string(encoded) + "\n")
+
+    var decoded []encodingRecord
+    assert.Nil(t, json.Unmarshal(golden, &decoded))
+    assert.Equal(t, records, decoded)
+
+    // errors are not encoded
+    errTest := errors.New("test error")
+    _, err = json.Marshal(encodingRecord{Name: result.Error[string](errTest)})
+    assert.ErrorIs(t, err, errTest)
+
+    // decoding errors leave the R unchanged
+    r := result.Some(5)
+    assert.Error(t, json.Unmarshal([]byte(`"x"`), &r))
+    assert.Equal(t, result.Some(5), r)
+}
+
+func TestR_Scan(t *testing.T) {
+    var r result.R[int]
+    assert.Nil(t, r.Scan(int64(5)))
+    assert.Equal(t, result.Some(5), r)
+    assert.Error(t, r.Scan(nil))
+    assert.Equal(t, result.Some(5), r)
+}
+
+func TestR_Text(t *testing.T) {
+    text, err := result.Some(42).MarshalText()
+    assert.Nil(t, err)
+    assert.Equal(t, "42", string(text))
+
+    errTest := errors.New("test error")
+    _, err = result.Error[int](errTest).MarshalText()
+    assert.ErrorIs(t, err, errTest)
+
+    var r result.R[int]
+    assert.Nil(t, r.UnmarshalText([]byte("42")))
+    assert.Equal(t, result.Some(42), r)
+    assert.Error(t, r.UnmarshalText([]byte("x")))
+}
+
+func TestR_Valuer(t *testing.T) {
+    v, err := result.Some("foo").Valuer().Value()
+    assert.Nil(t, err)
+    assert.Equal(t, "foo", v)
+
+    errTest := errors.New("test error")
+    _, err = result.Error[string](errTest).Valuer().Value()
+    assert.ErrorIs(t, err, errTest)
+}
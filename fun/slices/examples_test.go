@@ -0,0 +1,88 @@
+package slices_test
+
+import (
+    "fmt"
+    "strings"
+
+    "github.com/tawesoft/golib/v2/fun/slices"
+)
+
+func ExamplePartition() {
+    even, odd := slices.Partition(
+        func(x int) bool { return x % 2 == 0 },
+        []int{1, 2, 3, 4, 5},
+    )
+    fmt.Println(even, odd)
+
+    // Output: [2 4] [1 3 5]
+}
+
+func ExampleGroupBy() {
+    groups := slices.GroupBy(
+        func(s string) int { return len(s) },
+        []string{"a", "bb", "cc", "d", "eee"},
+    )
+    fmt.Println(groups[1], groups[2], groups[3])
+
+    // Output: [a d] [bb cc] [eee]
+}
+
+func ExampleChunk() {
+    fmt.Println(slices.Chunk(2, []int{1, 2, 3, 4, 5}))
+    fmt.Println(slices.Window(3, []int{1, 2, 3, 4, 5}))
+
+    // Output:
+    // [[1 2] [3 4] [5]]
+    // [[1 2 3] [2 3 4] [3 4 5]]
+}
+
+func ExampleZipWith() {
+    label := func(s string, i int) string { return fmt.Sprintf("%s%d", s, i) }
+    fmt.Println(slices.ZipWith(label, []string{"a", "b", "c"}, []int{1, 2}))
+
+    // Output: [a1 b2]
+}
+
+func ExampleZip() {
+    pairs := slices.Zip([]string{"a", "b", "c"}, []int{1, 2})
+    fmt.Println(pairs)
+
+    letters, numbers := slices.Unzip(pairs)
+    fmt.Println(letters, numbers)
+
+    // Output:
+    // [{a 1} {b 2}]
+    // [a b] [1 2]
+}
+
+func ExampleScan() {
+    add := func(total int, x int) int { return total + x }
+    fmt.Println(slices.Scan(0, add, []int{1, 2, 3, 4}))
+
+    // Output: [1 3 6 10]
+}
+
+func ExampleUniq() {
+    words := strings.Fields("the cat sat on the mat the end")
+    fmt.Println(slices.Uniq(words))
+    fmt.Println(slices.Frequencies(words)["the"])
+    fmt.Println(slices.Flatten([][]string{{"a", "b"}, nil, {"c"}}))
+
+    // Output:
+    // [the cat sat on mat end]
+    // 3
+    // [a b c]
+}
+
+func ExampleParallelMap() {
+    square := func(x int) int { return x * x }
+    isOdd := func(x int) bool { return x % 2 == 1 }
+    xs := []int{1, 2, 3, 4, 5, 6, 7, 8, 9}
+
+    fmt.Println(slices.ParallelMap(2, square, xs))
+    fmt.Println(slices.ParallelFilter(0, isOdd, xs))
+
+    // Output:
+    // [1 4 9 16 25 36 49 64 81]
+    // [1 3 5 7 9]
+}
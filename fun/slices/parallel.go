@@ -0,0 +1,83 @@
+package slices
+
+import (
+    "runtime"
+    "sync"
+)
+
+// parallel calls function f, concurrently, on each chunk of the indexes
+// [0, n) with the given chunk size, using up to GOMAXPROCS goroutines, and
+// waits for every call to return. If the chunk size is zero or negative, the
+// work is divided evenly between the goroutines.
+func parallel(chunkSize int, n int, f func(start int, end int)) {
+    if n == 0 { return }
+
+    workers := runtime.GOMAXPROCS(0)
+    if chunkSize <= 0 { chunkSize = (n + workers - 1) / workers }
+    chunks := (n + chunkSize - 1) / chunkSize
+    if workers > chunks { workers = chunks }
+
+    var wg sync.WaitGroup
+    next := make(chan int, chunks)
+    for i := 0; i < chunks; i++ {
+        next <- i
+    }
+    close(next)
+
+    wg.Add(workers)
+    for i := 0; i < workers; i++ {
+        go func() {
+            defer wg.Done()
+            for chunk := range next {
+                start := chunk * chunkSize
+                end := start + chunkSize
+                if end > n { end = n }
+                f(start, end)
+            }
+        }()
+    }
+    wg.Wait()
+}
+
+// ParallelMap is like [Map], but splits the input slice into chunks of the
+// given size, and applies function f to each chunk concurrently, using up to
+// GOMAXPROCS goroutines. The result is in the same order as the input.
+//
+// If the chunk size is zero or negative, the input is divided evenly between
+// the goroutines. Otherwise, a smaller chunk size balances uneven work
+// better, and a larger chunk size has less overhead.
+//
+// Function f must be safe to call concurrently.
+func ParallelMap[X any, Y any](chunkSize int, f func(X) Y, xs []X) []Y {
+    if xs == nil { return nil }
+    result := make([]Y, len(xs))
+    parallel(chunkSize, len(xs), func(start int, end int) {
+        for i := start; i < end; i++ {
+            result[i] = f(xs[i])
+        }
+    })
+    return result
+}
+
+// ParallelFilter is like [Filter], but splits the input slice into chunks of
+// the given size, and applies function f to each chunk concurrently, as
+// described by [ParallelMap]. The result is in the same order as the input.
+//
+// Function f must be safe to call concurrently.
+func ParallelFilter[X any](chunkSize int, f func(X) bool, xs []X) []X {
+    if xs == nil { return nil }
+
+    keep := make([]bool, len(xs))
+    parallel(chunkSize, len(xs), func(start int, end int) {
+        for i := start; i < end; i++ {
+            keep[i] = f(xs[i])
+        }
+    })
+
+    var result []X
+    for i, x := range xs {
+        if !keep[i] { continue }
+        result = append(result, x)
+    }
+    return result
+}
@@ -1,6 +1,10 @@
 // Package slices provides generic higher-order functions over slices of values.
 package slices
 
+import (
+    "github.com/tawesoft/golib/v2/tuple"
+)
+
 // FromArgs returns the slice of the variadic arguments list.
 func FromArgs[X any](xs ... X) []X {
     return xs
@@ -88,3 +92,150 @@ func Reducer[X any](initial X, f func(X, X) X) func(xs []X) X {
         return Reduce[X](initial, f, xs)
     }
 }
+
+// Partition applies function "f : X => bool" to each value X of the input
+// slice, and returns a new slice of each X for which f(X) is true, and a new
+// slice of each X for which f(X) is false. Both retain the input order.
+func Partition[X any](f func(X) bool, xs []X) (yes []X, no []X) {
+    for _, x := range xs {
+        if f(x) {
+            yes = append(yes, x)
+        } else {
+            no = append(no, x)
+        }
+    }
+    return yes, no
+}
+
+// GroupBy applies function "f : X => K" to each value X of the input slice,
+// and returns a map collection of each key K to a new slice of each X for
+// which f(X) == K, in input order.
+func GroupBy[X any, K comparable](f func(X) K, xs []X) map[K][]X {
+    result := make(map[K][]X)
+    for _, x := range xs {
+        k := f(x)
+        result[k] = append(result[k], x)
+    }
+    return result
+}
+
+// Chunk returns a slice of consecutive sub-slices of the input slice, each of
+// length size, except the last, which may be shorter. It panics if size is
+// less than one.
+//
+// Each sub-slice shares the backing array of the input slice, but has a
+// capacity equal to its length, so appending to a sub-slice never overwrites
+// the next.
+func Chunk[X any](size int, xs []X) [][]X {
+    if size < 1 { panic("slices.Chunk: size must be at least one") }
+    result := make([][]X, 0, (len(xs) + size - 1) / size)
+    for start := 0; start < len(xs); start += size {
+        end := start + size
+        if end > len(xs) { end = len(xs) }
+        result = append(result, xs[start:end:end])
+    }
+    return result
+}
+
+// Window returns a slice of every sub-slice of the input slice of length
+// size, in order of their starting index, such that each "sliding window"
+// overlaps the previous by all but one value. If the input slice is shorter
+// than size, the result is empty. It panics if size is less than one.
+//
+// Each sub-slice shares the backing array of the input slice, but has a
+// capacity equal to its length, so appending to a sub-slice never overwrites
+// the next.
+func Window[X any](size int, xs []X) [][]X {
+    if size < 1 { panic("slices.Window: size must be at least one") }
+    if len(xs) < size { return [][]X{} }
+    result := make([][]X, 0, len(xs) - size + 1)
+    for start := 0; start + size <= len(xs); start++ {
+        end := start + size
+        result = append(result, xs[start:end:end])
+    }
+    return result
+}
+
+// ZipWith applies function "f : (X, Y) => Z" to each pair of values at the
+// same index in the two input slices, and returns a new slice of each output
+// in sequence. The length of the result is the length of the shorter input.
+func ZipWith[X any, Y any, Z any](f func(X, Y) Z, xs []X, ys []Y) []Z {
+    n := len(xs)
+    if len(ys) < n { n = len(ys) }
+    result := make([]Z, 0, n)
+    for i := 0; i < n; i++ {
+        result = append(result, f(xs[i], ys[i]))
+    }
+    return result
+}
+
+// Zip returns a new slice of each pair of values at the same index in the
+// two input slices, as a [tuple.T2]. The length of the result is the length
+// of the shorter input. This is the same as [tuple.Zip2].
+func Zip[X any, Y any](xs []X, ys []Y) []tuple.T2[X, Y] {
+    return tuple.Zip2(xs, ys)
+}
+
+// Unzip is the inverse of [Zip]. It returns a new slice of the first value,
+// and a new slice of the second value, of each [tuple.T2] in the input slice.
+// This is the same as [tuple.Unzip2].
+func Unzip[X any, Y any](ts []tuple.T2[X, Y]) ([]X, []Y) {
+    return tuple.Unzip2(ts)
+}
+
+// Scan is like [Reduce], but returns a new slice of every intermediate
+// return value of f, in sequence (a "running total"). Unlike Reduce, the
+// accumulated value may have a different type to the values of the input
+// slice.
+//
+// The initial value is not included in the result, so the result has the
+// same length as the input slice.
+func Scan[X any, Y any](initial Y, f func(Y, X) Y, xs []X) []Y {
+    result := make([]Y, 0, len(xs))
+    acc := initial
+    for _, x := range xs {
+        acc = f(acc, x)
+        result = append(result, acc)
+    }
+    return result
+}
+
+// Flatten returns a new slice of each value of each input slice, in
+// sequence, concatenated into a single slice.
+func Flatten[X any](xss [][]X) []X {
+    n := 0
+    for _, xs := range xss {
+        n += len(xs)
+    }
+    result := make([]X, 0, n)
+    for _, xs := range xss {
+        result = append(result, xs...)
+    }
+    return result
+}
+
+// Uniq returns a new slice of the unique values of the input slice, keeping
+// the first of any repeated value. The order of the input is otherwise
+// retained i.e. it is a stable algorithm.
+//
+// Unlike the Unix command uniq, repeated values need not be adjacent.
+func Uniq[X comparable](xs []X) []X {
+    seen := make(map[X]struct{}, len(xs))
+    result := make([]X, 0)
+    for _, x := range xs {
+        if _, ok := seen[x]; ok { continue }
+        seen[x] = struct{}{}
+        result = append(result, x)
+    }
+    return result
+}
+
+// Frequencies returns a map collection of each unique value of the input
+// slice to the number of times it appears.
+func Frequencies[X comparable](xs []X) map[X]int {
+    result := make(map[X]int)
+    for _, x := range xs {
+        result[x]++
+    }
+    return result
+}
@@ -0,0 +1,224 @@
+// Package codec implements conversions shared by the encoding support of
+// generic container types, such as maybe.M and result.R.
+package codec
+
+import (
+    "database/sql"
+    "database/sql/driver"
+    "encoding"
+    "fmt"
+    "reflect"
+    "strconv"
+    "time"
+)
+
+// Value converts v to a [driver.Value]. If v implements [driver.Valuer], its
+// Value method is used.
+func Value(v any) (driver.Value, error) {
+    return driver.DefaultParameterConverter.ConvertValue(v)
+}
+
+// Scan stores src, a value returned by a database driver, in the value
+// pointed to by dest, in the manner of [sql.Rows.Scan]. If dest implements
+// [sql.Scanner], its Scan method is used. Numbers are converted between types
+// only where the value is in range for the destination type.
+func Scan(dest any, src any) error {
+    if s, ok := dest.(sql.Scanner); ok {
+        return s.Scan(src)
+    }
+
+    switch d := dest.(type) {
+        case *any:
+            *d = src
+            return nil
+        case *[]byte:
+            switch s := src.(type) {
+                case []byte: *d = append([]byte(nil), s...); return nil
+                case string: *d = []byte(s); return nil
+            }
+        case *time.Time:
+            if s, ok := src.(time.Time); ok {
+                *d = s
+                return nil
+            }
+    }
+
+    if src == nil {
+        return fmt.Errorf("unsupported Scan, storing NULL into type %T", dest)
+    }
+
+    dv := reflect.ValueOf(dest)
+    if (dv.Kind() != reflect.Pointer) || dv.IsNil() {
+        return fmt.Errorf("unsupported Scan, destination %T is not a non-nil pointer", dest)
+    }
+    dv = dv.Elem()
+
+    sv := reflect.ValueOf(src)
+    if sv.Type().AssignableTo(dv.Type()) {
+        dv.Set(sv)
+        return nil
+    }
+
+    switch s := src.(type) {
+        case []byte:
+            return setText(dest, dv, string(s))
+        case string:
+            return setText(dest, dv, s)
+    }
+
+    if dv.Kind() == reflect.String {
+        switch sv.Kind() {
+            case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+                dv.SetString(strconv.FormatInt(sv.Int(), 10))
+                return nil
+            case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+                dv.SetString(strconv.FormatUint(sv.Uint(), 10))
+                return nil
+            case reflect.Float32, reflect.Float64:
+                dv.SetString(strconv.FormatFloat(sv.Float(), 'g', -1, sv.Type().Bits()))
+                return nil
+            case reflect.Bool:
+                dv.SetString(strconv.FormatBool(sv.Bool()))
+                return nil
+        }
+    }
+
+    if err := setNumber(dv, sv); err == nil {
+        return nil
+    } else if err != errUnsupported {
+        return fmt.Errorf("converting driver.Value type %T (%v) to a %s: %w",
+            src, src, dv.Kind(), err)
+    }
+
+    return fmt.Errorf("unsupported Scan, storing driver.Value type %T into type %T", src, dest)
+}
+
+// MarshalText encodes v as text. If v implements [encoding.TextMarshaler],
+// its MarshalText method is used. Otherwise, v must be a string, byte slice,
+// number, or bool.
+func MarshalText(v any) ([]byte, error) {
+    if t, ok := v.(encoding.TextMarshaler); ok {
+        return t.MarshalText()
+    }
+
+    rv := reflect.ValueOf(v)
+    switch rv.Kind() {
+        case reflect.String:
+            return []byte(rv.String()), nil
+        case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+            return strconv.AppendInt(nil, rv.Int(), 10), nil
+        case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+            return strconv.AppendUint(nil, rv.Uint(), 10), nil
+        case reflect.Float32, reflect.Float64:
+            return strconv.AppendFloat(nil, rv.Float(), 'g', -1, rv.Type().Bits()), nil
+        case reflect.Bool:
+            return strconv.AppendBool(nil, rv.Bool()), nil
+        case reflect.Slice:
+            if rv.Type().Elem().Kind() == reflect.Uint8 {
+                return append([]byte(nil), rv.Bytes()...), nil
+            }
+    }
+
+    return nil, fmt.Errorf("unsupported MarshalText for type %T", v)
+}
+
+// UnmarshalText decodes text into the value pointed to by dest. If dest
+// implements [encoding.TextUnmarshaler], its UnmarshalText method is used.
+// Otherwise, dest must point to a string, byte slice, number, or bool.
+func UnmarshalText(dest any, text []byte) error {
+    if t, ok := dest.(encoding.TextUnmarshaler); ok {
+        return t.UnmarshalText(text)
+    }
+
+    dv := reflect.ValueOf(dest)
+    if (dv.Kind() != reflect.Pointer) || dv.IsNil() {
+        return fmt.Errorf("unsupported UnmarshalText, destination %T is not a non-nil pointer", dest)
+    }
+
+    return setText(dest, dv.Elem(), string(text))
+}
+
+var errUnsupported = fmt.Errorf("unsupported conversion")
+
+// setText parses s into dv, which is the value pointed to by dest.
+func setText(dest any, dv reflect.Value, s string) error {
+    var err error
+
+    switch dv.Kind() {
+        case reflect.String:
+            dv.SetString(s)
+        case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+            var i int64
+            i, err = strconv.ParseInt(s, 10, dv.Type().Bits())
+            if err == nil { dv.SetInt(i) }
+        case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+            var u uint64
+            u, err = strconv.ParseUint(s, 10, dv.Type().Bits())
+            if err == nil { dv.SetUint(u) }
+        case reflect.Float32, reflect.Float64:
+            var f float64
+            f, err = strconv.ParseFloat(s, dv.Type().Bits())
+            if err == nil { dv.SetFloat(f) }
+        case reflect.Bool:
+            var b bool
+            b, err = strconv.ParseBool(s)
+            if err == nil { dv.SetBool(b) }
+        case reflect.Slice:
+            if dv.Type().Elem().Kind() != reflect.Uint8 {
+                return fmt.Errorf("unsupported conversion from text into type %T", dest)
+            }
+            dv.SetBytes([]byte(s))
+        default:
+            return fmt.Errorf("unsupported conversion from text into type %T", dest)
+    }
+
+    if err != nil {
+        return fmt.Errorf("converting %q to a %s: %w", s, dv.Kind(), err)
+    }
+    return nil
+}
+
+// setNumber converts between numeric kinds, only where the value is in range
+// for the destination type.
+func setNumber(dv reflect.Value, sv reflect.Value) error {
+    switch dv.Kind() {
+        case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+            switch sv.Kind() {
+                case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+                    if dv.OverflowInt(sv.Int()) { return strconv.ErrRange }
+                    dv.SetInt(sv.Int())
+                    return nil
+                case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+                    u := sv.Uint()
+                    if (u > (1 << 63 - 1)) || dv.OverflowInt(int64(u)) { return strconv.ErrRange }
+                    dv.SetInt(int64(u))
+                    return nil
+            }
+        case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+            switch sv.Kind() {
+                case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+                    i := sv.Int()
+                    if (i < 0) || dv.OverflowUint(uint64(i)) { return strconv.ErrRange }
+                    dv.SetUint(uint64(i))
+                    return nil
+                case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+                    if dv.OverflowUint(sv.Uint()) { return strconv.ErrRange }
+                    dv.SetUint(sv.Uint())
+                    return nil
+            }
+        case reflect.Float32, reflect.Float64:
+            switch sv.Kind() {
+                case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+                    dv.SetFloat(float64(sv.Int()))
+                    return nil
+                case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+                    dv.SetFloat(float64(sv.Uint()))
+                    return nil
+                case reflect.Float32, reflect.Float64:
+                    if dv.OverflowFloat(sv.Float()) { return strconv.ErrRange }
+                    dv.SetFloat(sv.Float())
+                    return nil
+            }
+    }
+    return errUnsupported
+}
@@ -0,0 +1,269 @@
+package dbus
+
+import (
+    "bufio"
+    "context"
+    "encoding/hex"
+    "errors"
+    "fmt"
+    "io"
+    "net"
+    "net/url"
+    "os"
+    "strconv"
+    "strings"
+    "sync"
+    "sync/atomic"
+    "time"
+)
+
+// SessionBusAddress returns the address of the session bus, from the
+// DBUS_SESSION_BUS_ADDRESS environment variable, or an empty string.
+func SessionBusAddress() string {
+    return os.Getenv("DBUS_SESSION_BUS_ADDRESS")
+}
+
+// Conn is a connection to a message bus.
+type Conn struct {
+    conn   net.Conn
+    r      *bufio.Reader
+    wmu    sync.Mutex
+    serial atomic.Uint32
+    name   string
+    done   chan struct{}
+
+    mu          sync.Mutex
+    err         error // why the connection closed
+    pending     map[uint32]chan *Message
+    handlers    map[int]func(*Message)
+    nextHandler int
+}
+
+// Dial connects to a message bus, authenticates, and registers with the bus.
+// The address is a D-Bus server address, such as returned by
+// [SessionBusAddress]. Only "unix" transports are supported.
+func Dial(ctx context.Context, address string) (*Conn, error) {
+    var errs []error
+    for _, a := range strings.Split(address, ";") {
+        if a == "" { continue }
+        c, err := dial(ctx, a)
+        if err == nil { return c, nil }
+        errs = append(errs, err)
+    }
+    if len(errs) == 0 { return nil, errors.New("dbus: no address") }
+    return nil, errors.Join(errs...)
+}
+
+func dial(ctx context.Context, address string) (*Conn, error) {
+    transport, params, ok := strings.Cut(address, ":")
+    if !ok || (transport != "unix") {
+        return nil, fmt.Errorf("dbus: unsupported address %q", address)
+    }
+
+    var path string
+    for _, kv := range strings.Split(params, ",") {
+        k, v, _ := strings.Cut(kv, "=")
+        v, err := url.PathUnescape(v)
+        if err != nil { return nil, fmt.Errorf("dbus: invalid address %q: %w", address, err) }
+        switch k {
+            case "path":     path = v
+            case "abstract": path = "@" + v
+        }
+    }
+    if path == "" { return nil, fmt.Errorf("dbus: unsupported address %q", address) }
+
+    var d net.Dialer
+    nc, err := d.DialContext(ctx, "unix", path)
+    if err != nil { return nil, fmt.Errorf("dbus: %w", err) }
+
+    c := &Conn{
+        conn:     nc,
+        r:        bufio.NewReader(nc),
+        done:     make(chan struct{}),
+        pending:  make(map[uint32]chan *Message),
+        handlers: make(map[int]func(*Message)),
+    }
+
+    if deadline, ok := ctx.Deadline(); ok {
+        _ = nc.SetDeadline(deadline)
+    }
+    if err := c.auth(); err != nil {
+        nc.Close()
+        return nil, err
+    }
+    _ = nc.SetDeadline(time.Time{})
+
+    go c.read()
+
+    reply, err := c.Call(ctx, "org.freedesktop.DBus", "/org/freedesktop/DBus",
+        "org.freedesktop.DBus", "Hello")
+    if err != nil {
+        c.Close()
+        return nil, err
+    }
+    if len(reply) > 0 { c.name, _ = reply[0].(string) }
+
+    return c, nil
+}
+
+// auth performs the SASL EXTERNAL authentication handshake.
+func (c *Conn) auth() error {
+    uid := hex.EncodeToString([]byte(strconv.Itoa(os.Getuid())))
+    if _, err := io.WriteString(c.conn, "\x00AUTH EXTERNAL "+uid+"\r\n"); err != nil {
+        return fmt.Errorf("dbus: auth: %w", err)
+    }
+
+    line, err := c.r.ReadString('\n')
+    if err != nil { return fmt.Errorf("dbus: auth: %w", err) }
+    if !strings.HasPrefix(line, "OK ") {
+        return fmt.Errorf("dbus: auth rejected: %q", strings.TrimSpace(line))
+    }
+
+    if _, err := io.WriteString(c.conn, "BEGIN\r\n"); err != nil {
+        return fmt.Errorf("dbus: auth: %w", err)
+    }
+    return nil
+}
+
+// Name returns the unique name assigned to the connection by the bus e.g.
+// ":1.42".
+func (c *Conn) Name() string {
+    return c.name
+}
+
+// Done returns a channel that is closed when the connection is closed.
+func (c *Conn) Done() <-chan struct{} {
+    return c.done
+}
+
+// Close closes the connection.
+func (c *Conn) Close() error {
+    return c.conn.Close()
+}
+
+func (c *Conn) read() {
+    var err error
+    for {
+        var m *Message
+        m, err = ReadMessage(c.r)
+        if err != nil { break }
+
+        switch m.Type {
+            case MethodReturn, ErrorReply:
+                c.mu.Lock()
+                ch, ok := c.pending[m.ReplySerial]
+                delete(c.pending, m.ReplySerial)
+                c.mu.Unlock()
+                if ok { ch <- m }
+            case Signal:
+                c.mu.Lock()
+                handlers := make([]func(*Message), 0, len(c.handlers))
+                for _, h := range c.handlers {
+                    handlers = append(handlers, h)
+                }
+                c.mu.Unlock()
+                for _, h := range handlers {
+                    h(m)
+                }
+        }
+    }
+
+    c.conn.Close()
+    c.mu.Lock()
+    c.err = err
+    for serial, ch := range c.pending {
+        close(ch)
+        delete(c.pending, serial)
+    }
+    c.mu.Unlock()
+    close(c.done)
+}
+
+// Call calls a method, and waits for a reply. If the reply is an error, the
+// returned error is an [*Error].
+func (c *Conn) Call(
+    ctx context.Context,
+    destination string,
+    path ObjectPath,
+    iface string,
+    member string,
+    args ... any,
+) ([]any, error) {
+    m := &Message{
+        Type:        MethodCall,
+        Path:        path,
+        Interface:   iface,
+        Member:      member,
+        Destination: destination,
+        Body:        args,
+    }
+
+    // register before sending, in case the reply is very quick
+    ch := make(chan *Message, 1)
+    m.Serial = c.serial.Add(1)
+    c.mu.Lock()
+    if c.err != nil {
+        c.mu.Unlock()
+        return nil, fmt.Errorf("dbus: connection closed: %w", c.err)
+    }
+    c.pending[m.Serial] = ch
+    c.mu.Unlock()
+
+    unregister := func() {
+        c.mu.Lock()
+        delete(c.pending, m.Serial)
+        c.mu.Unlock()
+    }
+
+    buf, err := m.Marshal()
+    if err != nil {
+        unregister()
+        return nil, err
+    }
+
+    c.wmu.Lock()
+    _, err = c.conn.Write(buf)
+    c.wmu.Unlock()
+    if err != nil {
+        unregister()
+        return nil, fmt.Errorf("dbus: %w", err)
+    }
+
+    select {
+        case reply, ok := <-ch:
+            if !ok { return nil, errors.New("dbus: connection closed") }
+            if reply.Type == ErrorReply {
+                e := &Error{Name: reply.ErrorName}
+                if len(reply.Body) > 0 { e.Message, _ = reply.Body[0].(string) }
+                return nil, e
+            }
+            return reply.Body, nil
+        case <-ctx.Done():
+            unregister()
+            return nil, ctx.Err()
+    }
+}
+
+// AddMatch asks the bus to deliver signals that match a match rule e.g.
+// "type='signal',interface='org.example.Foo'".
+func (c *Conn) AddMatch(ctx context.Context, rule string) error {
+    _, err := c.Call(ctx, "org.freedesktop.DBus", "/org/freedesktop/DBus",
+        "org.freedesktop.DBus", "AddMatch", rule)
+    return err
+}
+
+// Handle registers a function that is called, from the goroutine reading
+// from the connection, with each signal received. The function must not
+// block. The returned function unregisters the handler.
+func (c *Conn) Handle(f func(*Message)) (remove func()) {
+    c.mu.Lock()
+    defer c.mu.Unlock()
+    id := c.nextHandler
+    c.nextHandler++
+    c.handlers[id] = f
+    return func() {
+        c.mu.Lock()
+        defer c.mu.Unlock()
+        delete(c.handlers, id)
+    }
+}
@@ -0,0 +1,127 @@
+package dbus_test
+
+import (
+    "bytes"
+    "context"
+    "testing"
+    "time"
+
+    "github.com/stretchr/testify/assert"
+    "github.com/tawesoft/golib/v2/internal/dbus"
+    "github.com/tawesoft/golib/v2/internal/dbus/dbustest"
+)
+
+func TestMessage(t *testing.T) {
+    type pattern struct {
+        Kind    uint32
+        Pattern string
+    }
+    type filter struct {
+        Name     string
+        Patterns []pattern
+    }
+
+    m := &dbus.Message{
+        Type:        dbus.MethodCall,
+        Serial:      7,
+        Path:        "/org/example",
+        Interface:   "org.example.Foo",
+        Member:      "Bar",
+        Destination: "org.example",
+        Body: []any{
+            "hello",
+            byte(1),
+            uint32(2),
+            int64(-3),
+            1.5,
+            true,
+            []string{"a", "b"},
+            map[string]dbus.Variant{
+                "bytes":   {Value: []byte("dir\x00")},
+                "filters": {Value: []filter{{"Text", []pattern{{0, "*.txt"}}}}},
+                "path":    {Value: dbus.ObjectPath("/a/b")},
+            },
+        },
+    }
+
+    buf, err := m.Marshal()
+    assert.Nil(t, err)
+
+    got, err := dbus.ReadMessage(bytes.NewReader(buf))
+    assert.Nil(t, err)
+    assert.Equal(t, m.Serial, got.Serial)
+    assert.Equal(t, m.Path, got.Path)
+    assert.Equal(t, m.Interface, got.Interface)
+    assert.Equal(t, m.Member, got.Member)
+    assert.Equal(t, m.Destination, got.Destination)
+    assert.Equal(t, []any{
+        "hello",
+        byte(1),
+        uint32(2),
+        int64(-3),
+        1.5,
+        true,
+        []any{"a", "b"},
+        map[any]any{
+            "bytes":   dbus.Variant{Value: []byte("dir\x00")},
+            "filters": dbus.Variant{Value: []any{[]any{"Text", []any{[]any{uint32(0), "*.txt"}}}}},
+            "path":    dbus.Variant{Value: dbus.ObjectPath("/a/b")},
+        },
+    }, got.Body)
+
+    _, err = dbus.ReadMessage(bytes.NewReader(buf[:len(buf)-1]))
+    assert.Error(t, err)
+}
+
+func TestConn(t *testing.T) {
+    bus, err := dbustest.New(func(call *dbus.Message) ([]any, []*dbus.Message, error) {
+        switch call.Member {
+            case "Echo":
+                return call.Body, []*dbus.Message{{
+                    Path:      "/org/example",
+                    Interface: "org.example.Foo",
+                    Member:    "Echoed",
+                    Body:      call.Body,
+                }}, nil
+            default:
+                return nil, nil, &dbus.Error{Name: "org.example.Error.Unknown", Message: "no"}
+        }
+    })
+    if !assert.Nil(t, err) { return }
+    defer bus.Close()
+
+    ctx, cancel := context.WithTimeout(context.Background(), 5 * time.Second)
+    defer cancel()
+
+    c, err := dbus.Dial(ctx, "unix:path=/does/not/exist;"+bus.Address)
+    if !assert.Nil(t, err) { return }
+    defer c.Close()
+    assert.Equal(t, ":1.1", c.Name())
+
+    signals := make(chan *dbus.Message, 1)
+    remove := c.Handle(func(m *dbus.Message) { signals <- m })
+    defer remove()
+    assert.Nil(t, c.AddMatch(ctx, "type='signal'"))
+
+    reply, err := c.Call(ctx, "org.example", "/org/example", "org.example.Foo", "Echo", "hi", uint32(1))
+    assert.Nil(t, err)
+    assert.Equal(t, []any{"hi", uint32(1)}, reply)
+
+    select {
+        case s := <-signals:
+            assert.Equal(t, "Echoed", s.Member)
+            assert.Equal(t, []any{"hi", uint32(1)}, s.Body)
+        case <-ctx.Done():
+            t.Fatal("timeout waiting for signal")
+    }
+
+    _, err = c.Call(ctx, "org.example", "/org/example", "org.example.Foo", "Other")
+    assert.Equal(t, &dbus.Error{Name: "org.example.Error.Unknown", Message: "no"}, err)
+
+    bus.Close()
+    select {
+        case <-c.Done():
+        case <-ctx.Done():
+            t.Fatal("timeout waiting for close")
+    }
+}
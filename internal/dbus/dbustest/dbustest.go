@@ -0,0 +1,188 @@
+// Package dbustest implements a stand-in D-Bus message bus for testing
+// clients, where the services on the bus are implemented by a Go function.
+package dbustest
+
+import (
+    "bufio"
+    "errors"
+    "net"
+    "os"
+    "path/filepath"
+    "strconv"
+    "strings"
+    "sync"
+
+    "github.com/tawesoft/golib/v2/internal/dbus"
+)
+
+// Handler implements every service on a [Bus]. It is called with each
+// method call, other than those to the bus itself, and returns a reply body
+// and any signals to send after the reply. If err is an [*dbus.Error], it is
+// sent as an error reply, otherwise any other error is sent as the error
+// "org.freedesktop.DBus.Error.Failed".
+type Handler func(call *dbus.Message) (reply []any, signals []*dbus.Message, err error)
+
+// Bus is a stand-in message bus listening on a Unix socket. Unlike a real
+// bus, every signal is delivered to every client, regardless of match rules.
+type Bus struct {
+    // Address is the D-Bus server address of the bus, for example to use
+    // as the value of the DBUS_SESSION_BUS_ADDRESS environment variable.
+    Address string
+
+    dir     string
+    ln      net.Listener
+    handler Handler
+    wg      sync.WaitGroup
+
+    mu      sync.Mutex
+    clients int
+    conns   map[net.Conn]struct{}
+}
+
+// New starts a new Bus.
+func New(handler Handler) (*Bus, error) {
+    dir, err := os.MkdirTemp("", "dbustest")
+    if err != nil { return nil, err }
+
+    path := filepath.Join(dir, "bus")
+    ln, err := net.Listen("unix", path)
+    if err != nil {
+        os.RemoveAll(dir)
+        return nil, err
+    }
+
+    b := &Bus{
+        Address: "unix:path="+path,
+        dir:     dir,
+        ln:      ln,
+        handler: handler,
+        conns:   make(map[net.Conn]struct{}),
+    }
+
+    b.wg.Add(1)
+    go b.accept()
+    return b, nil
+}
+
+// Close stops the Bus, and closes every connection.
+func (b *Bus) Close() error {
+    err := b.ln.Close()
+    b.mu.Lock()
+    for c := range b.conns {
+        c.Close()
+    }
+    b.mu.Unlock()
+    b.wg.Wait()
+    os.RemoveAll(b.dir)
+    return err
+}
+
+// Conns returns the number of open client connections.
+func (b *Bus) Conns() int {
+    b.mu.Lock()
+    defer b.mu.Unlock()
+    return len(b.conns)
+}
+
+func (b *Bus) accept() {
+    defer b.wg.Done()
+    for {
+        c, err := b.ln.Accept()
+        if err != nil { return }
+
+        b.mu.Lock()
+        b.clients++
+        name := ":1." + strconv.Itoa(b.clients)
+        b.conns[c] = struct{}{}
+        b.mu.Unlock()
+
+        b.wg.Add(1)
+        go func() {
+            defer b.wg.Done()
+            b.serve(c, name)
+            b.mu.Lock()
+            delete(b.conns, c)
+            b.mu.Unlock()
+            c.Close()
+        }()
+    }
+}
+
+func (b *Bus) serve(c net.Conn, name string) {
+    r := bufio.NewReader(c)
+
+    // SASL handshake: accept anything
+    nul, err := r.ReadByte()
+    if (err != nil) || (nul != 0) { return }
+    for {
+        line, err := r.ReadString('\n')
+        if err != nil { return }
+        line = strings.TrimSpace(line)
+        if strings.HasPrefix(line, "AUTH") {
+            if _, err := c.Write([]byte("OK 0123456789abcdef0123456789abcdef\r\n")); err != nil { return }
+        } else if line == "BEGIN" {
+            break
+        }
+    }
+
+    var serial uint32
+    send := func(m *dbus.Message) error {
+        serial++
+        m.Serial = serial
+        m.Sender = "org.freedesktop.DBus"
+        m.Destination = name
+        buf, err := m.Marshal()
+        if err != nil { return err }
+        _, err = c.Write(buf)
+        return err
+    }
+
+    for {
+        call, err := dbus.ReadMessage(r)
+        if err != nil { return }
+        if call.Type != dbus.MethodCall { continue }
+        call.Sender = name
+
+        var reply []any
+        var signals []*dbus.Message
+        if call.Destination == "org.freedesktop.DBus" {
+            switch call.Member {
+                case "Hello": reply = []any{name}
+                case "AddMatch", "RemoveMatch":
+                default:
+                    err = &dbus.Error{Name: "org.freedesktop.DBus.Error.UnknownMethod"}
+            }
+        } else {
+            reply, signals, err = b.handler(call)
+        }
+
+        if call.Flags & dbus.FlagNoReplyExpected != 0 {
+            // no reply
+        } else if err != nil {
+            var e *dbus.Error
+            if !errors.As(err, &e) {
+                e = &dbus.Error{Name: "org.freedesktop.DBus.Error.Failed", Message: err.Error()}
+            }
+            body := []any{}
+            if e.Message != "" { body = append(body, e.Message) }
+            err = send(&dbus.Message{
+                Type:        dbus.ErrorReply,
+                ErrorName:   e.Name,
+                ReplySerial: call.Serial,
+                Body:        body,
+            })
+        } else {
+            err = send(&dbus.Message{
+                Type:        dbus.MethodReturn,
+                ReplySerial: call.Serial,
+                Body:        reply,
+            })
+        }
+        if err != nil { return }
+
+        for _, s := range signals {
+            s.Type = dbus.Signal
+            if err := send(s); err != nil { return }
+        }
+    }
+}
@@ -0,0 +1,536 @@
+// Package dbus implements a minimal D-Bus client, sufficient for calling
+// methods on, and receiving signals from, services on a session bus, without
+// cgo or any external dependencies.
+//
+// Go values are encoded as D-Bus values by their type: uint8 as BYTE, bool
+// as BOOLEAN, int16, uint16, int32, uint32, int64, uint64 and float64 as the
+// integer and DOUBLE types of the same size, string as STRING, [ObjectPath]
+// as OBJECT_PATH, [Signature] as SIGNATURE, [Variant] as VARIANT, slices as
+// ARRAY, maps as an ARRAY of DICT_ENTRY, and structs (of exported fields) as
+// STRUCT.
+//
+// Decoded values use the same types, except that an ARRAY of BYTE decodes
+// as a []byte, any other ARRAY decodes as a []any, an ARRAY of DICT_ENTRY
+// decodes as a map[any]any, and a STRUCT decodes as a []any.
+package dbus
+
+import (
+    "encoding/binary"
+    "errors"
+    "fmt"
+    "io"
+    "math"
+    "reflect"
+    "sort"
+)
+
+// ObjectPath is a D-Bus object path e.g. "/org/freedesktop/DBus".
+type ObjectPath string
+
+// Signature is a D-Bus type signature e.g. "a{sv}".
+type Signature string
+
+// Variant is a D-Bus value of any type.
+type Variant struct {
+    Value any
+}
+
+// MessageType is the type of a D-Bus [Message].
+type MessageType byte
+
+const (
+    MethodCall   MessageType = 1
+    MethodReturn MessageType = 2
+    ErrorReply   MessageType = 3
+    Signal       MessageType = 4
+)
+
+// FlagNoReplyExpected is a [Message] flag for a method call that does not
+// expect a reply.
+const FlagNoReplyExpected byte = 0x1
+
+// maxMessageSize is the maximum size of a message allowed by the
+// specification.
+const maxMessageSize = 128 * 1024 * 1024
+
+// Message is a D-Bus message.
+type Message struct {
+    Type        MessageType
+    Flags       byte
+    Serial      uint32
+    Path        ObjectPath
+    Interface   string
+    Member      string
+    ErrorName   string
+    ReplySerial uint32
+    Destination string
+    Sender      string
+    Body        []any
+}
+
+// Error is a D-Bus error reply.
+type Error struct {
+    Name    string // e.g. "org.freedesktop.DBus.Error.ServiceUnknown"
+    Message string
+}
+
+func (e *Error) Error() string {
+    if e.Message == "" { return "dbus: "+e.Name }
+    return fmt.Sprintf("dbus: %s: %s", e.Name, e.Message)
+}
+
+var (
+    objectPathType = reflect.TypeOf(ObjectPath(""))
+    signatureType  = reflect.TypeOf(Signature(""))
+    variantType    = reflect.TypeOf(Variant{})
+)
+
+// signatureOf returns the D-Bus signature of a Go type.
+func signatureOf(t reflect.Type) (string, error) {
+    switch t {
+        case objectPathType: return "o", nil
+        case signatureType:  return "g", nil
+        case variantType:    return "v", nil
+    }
+
+    switch t.Kind() {
+        case reflect.Uint8:   return "y", nil
+        case reflect.Bool:    return "b", nil
+        case reflect.Int16:   return "n", nil
+        case reflect.Uint16:  return "q", nil
+        case reflect.Int32:   return "i", nil
+        case reflect.Uint32:  return "u", nil
+        case reflect.Int64:   return "x", nil
+        case reflect.Uint64:  return "t", nil
+        case reflect.Float64: return "d", nil
+        case reflect.String:  return "s", nil
+        case reflect.Slice:
+            elem, err := signatureOf(t.Elem())
+            if err != nil { return "", err }
+            return "a"+elem, nil
+        case reflect.Map:
+            k, err := signatureOf(t.Key())
+            if err != nil { return "", err }
+            v, err := signatureOf(t.Elem())
+            if err != nil { return "", err }
+            return "a{"+k+v+"}", nil
+        case reflect.Struct:
+            sig := "("
+            for i := 0; i < t.NumField(); i++ {
+                if !t.Field(i).IsExported() { continue }
+                s, err := signatureOf(t.Field(i).Type)
+                if err != nil { return "", err }
+                sig += s
+            }
+            return sig+")", nil
+    }
+
+    return "", fmt.Errorf("dbus: unsupported type %s", t)
+}
+
+// alignment returns the alignment of the type with the given signature.
+func alignment(sig string) int {
+    switch sig[0] {
+        case 'n', 'q':
+            return 2
+        case 'b', 'i', 'u', 's', 'o', 'a', 'h':
+            return 4
+        case 'x', 't', 'd', '(', '{':
+            return 8
+        default: // 'y', 'g', 'v'
+            return 1
+    }
+}
+
+// next splits the first complete type from a signature.
+func next(sig string) (string, string, error) {
+    if sig == "" { return "", "", errors.New("dbus: empty signature") }
+
+    switch sig[0] {
+        case 'a':
+            elem, rest, err := next(sig[1:])
+            if err != nil { return "", "", err }
+            return "a"+elem, rest, nil
+        case '(', '{':
+            closing := byte(')')
+            if sig[0] == '{' { closing = '}' }
+            i := 1
+            for i < len(sig) && sig[i] != closing {
+                _, rest, err := next(sig[i:])
+                if err != nil { return "", "", err }
+                i = len(sig) - len(rest)
+            }
+            if i >= len(sig) { return "", "", fmt.Errorf("dbus: unterminated signature %q", sig) }
+            return sig[:i+1], sig[i+1:], nil
+        case 'y', 'b', 'n', 'q', 'i', 'u', 'x', 't', 'd', 's', 'o', 'g', 'v', 'h':
+            return sig[:1], sig[1:], nil
+    }
+
+    return "", "", fmt.Errorf("dbus: invalid signature %q", sig)
+}
+
+// encoder encodes values, always in little-endian byte order.
+type encoder struct {
+    buf []byte
+}
+
+func (e *encoder) align(n int) {
+    for len(e.buf) % n != 0 {
+        e.buf = append(e.buf, 0)
+    }
+}
+
+func (e *encoder) uint32(x uint32) {
+    e.align(4)
+    e.buf = binary.LittleEndian.AppendUint32(e.buf, x)
+}
+
+func (e *encoder) value(v reflect.Value) error {
+    switch v.Type() {
+        case signatureType:
+            s := v.String()
+            if len(s) > 255 { return errors.New("dbus: signature too long") }
+            e.buf = append(e.buf, byte(len(s)))
+            e.buf = append(e.buf, s...)
+            e.buf = append(e.buf, 0)
+            return nil
+        case variantType:
+            inner := v.Field(0)
+            if inner.IsNil() { return errors.New("dbus: nil Variant") }
+            inner = inner.Elem()
+            sig, err := signatureOf(inner.Type())
+            if err != nil { return err }
+            if err := e.value(reflect.ValueOf(Signature(sig))); err != nil { return err }
+            return e.value(inner)
+    }
+
+    switch v.Kind() {
+        case reflect.Uint8:
+            e.buf = append(e.buf, byte(v.Uint()))
+        case reflect.Bool:
+            var x uint32
+            if v.Bool() { x = 1 }
+            e.uint32(x)
+        case reflect.Int16, reflect.Uint16:
+            e.align(2)
+            if v.Kind() == reflect.Int16 {
+                e.buf = binary.LittleEndian.AppendUint16(e.buf, uint16(v.Int()))
+            } else {
+                e.buf = binary.LittleEndian.AppendUint16(e.buf, uint16(v.Uint()))
+            }
+        case reflect.Int32:
+            e.uint32(uint32(v.Int()))
+        case reflect.Uint32:
+            e.uint32(uint32(v.Uint()))
+        case reflect.Int64, reflect.Uint64, reflect.Float64:
+            e.align(8)
+            switch v.Kind() {
+                case reflect.Int64:  e.buf = binary.LittleEndian.AppendUint64(e.buf, uint64(v.Int()))
+                case reflect.Uint64: e.buf = binary.LittleEndian.AppendUint64(e.buf, v.Uint())
+                default:             e.buf = binary.LittleEndian.AppendUint64(e.buf, math.Float64bits(v.Float()))
+            }
+        case reflect.String:
+            s := v.String()
+            e.uint32(uint32(len(s)))
+            e.buf = append(e.buf, s...)
+            e.buf = append(e.buf, 0)
+        case reflect.Slice, reflect.Map:
+            sig, err := signatureOf(v.Type())
+            if err != nil { return err }
+
+            e.uint32(0) // length placeholder
+            lengthAt := len(e.buf) - 4
+            e.align(alignment(sig[1:]))
+            start := len(e.buf)
+
+            if v.Kind() == reflect.Slice {
+                for i := 0; i < v.Len(); i++ {
+                    if err := e.value(v.Index(i)); err != nil { return err }
+                }
+            } else {
+                // sort keys, so that encoding is deterministic
+                keys := v.MapKeys()
+                sort.Slice(keys, func(i, j int) bool {
+                    return fmt.Sprint(keys[i].Interface()) < fmt.Sprint(keys[j].Interface())
+                })
+                for _, k := range keys {
+                    e.align(8)
+                    if err := e.value(k); err != nil { return err }
+                    if err := e.value(v.MapIndex(k)); err != nil { return err }
+                }
+            }
+
+            length := len(e.buf) - start
+            if length > (64 * 1024 * 1024) { return errors.New("dbus: array too long") }
+            binary.LittleEndian.PutUint32(e.buf[lengthAt:], uint32(length))
+        case reflect.Struct:
+            e.align(8)
+            for i := 0; i < v.NumField(); i++ {
+                if !v.Type().Field(i).IsExported() { continue }
+                if err := e.value(v.Field(i)); err != nil { return err }
+            }
+        case reflect.Interface, reflect.Pointer:
+            if v.IsNil() { return errors.New("dbus: nil value") }
+            return e.value(v.Elem())
+        default:
+            return fmt.Errorf("dbus: unsupported type %s", v.Type())
+    }
+    return nil
+}
+
+type decoder struct {
+    buf   []byte
+    pos   int
+    order binary.ByteOrder
+}
+
+var errShort = errors.New("dbus: message too short")
+
+func (d *decoder) align(n int) error {
+    for d.pos % n != 0 {
+        if d.pos >= len(d.buf) { return errShort }
+        d.pos++
+    }
+    return nil
+}
+
+func (d *decoder) bytes(n int) ([]byte, error) {
+    if (n < 0) || (d.pos + n > len(d.buf)) { return nil, errShort }
+    b := d.buf[d.pos:d.pos+n]
+    d.pos += n
+    return b, nil
+}
+
+func (d *decoder) uint32() (uint32, error) {
+    if err := d.align(4); err != nil { return 0, err }
+    b, err := d.bytes(4)
+    if err != nil { return 0, err }
+    return d.order.Uint32(b), nil
+}
+
+func (d *decoder) uint64() (uint64, error) {
+    if err := d.align(8); err != nil { return 0, err }
+    b, err := d.bytes(8)
+    if err != nil { return 0, err }
+    return d.order.Uint64(b), nil
+}
+
+func (d *decoder) string() (string, error) {
+    n, err := d.uint32()
+    if err != nil { return "", err }
+    b, err := d.bytes(int(n) + 1)
+    if err != nil { return "", err }
+    return string(b[:n]), nil
+}
+
+func (d *decoder) signature() (string, error) {
+    b, err := d.bytes(1)
+    if err != nil { return "", err }
+    s, err := d.bytes(int(b[0]) + 1)
+    if err != nil { return "", err }
+    return string(s[:b[0]]), nil
+}
+
+// values decodes every value in a signature.
+func (d *decoder) values(sig string) ([]any, error) {
+    var results []any
+    for sig != "" {
+        t, rest, err := next(sig)
+        if err != nil { return nil, err }
+        v, err := d.value(t)
+        if err != nil { return nil, err }
+        results = append(results, v)
+        sig = rest
+    }
+    return results, nil
+}
+
+// value decodes a single complete type.
+func (d *decoder) value(sig string) (any, error) {
+    switch sig[0] {
+        case 'y':
+            b, err := d.bytes(1)
+            if err != nil { return nil, err }
+            return b[0], nil
+        case 'b':
+            x, err := d.uint32()
+            return x != 0, err
+        case 'n', 'q':
+            if err := d.align(2); err != nil { return nil, err }
+            b, err := d.bytes(2)
+            if err != nil { return nil, err }
+            if sig[0] == 'n' { return int16(d.order.Uint16(b)), nil }
+            return d.order.Uint16(b), nil
+        case 'i':
+            x, err := d.uint32()
+            return int32(x), err
+        case 'u', 'h':
+            return d.uint32()
+        case 'x':
+            x, err := d.uint64()
+            return int64(x), err
+        case 't':
+            return d.uint64()
+        case 'd':
+            x, err := d.uint64()
+            return math.Float64frombits(x), err
+        case 's':
+            return d.string()
+        case 'o':
+            s, err := d.string()
+            return ObjectPath(s), err
+        case 'g':
+            s, err := d.signature()
+            return Signature(s), err
+        case 'v':
+            s, err := d.signature()
+            if err != nil { return nil, err }
+            t, rest, err := next(s)
+            if err != nil { return nil, err }
+            if rest != "" { return nil, fmt.Errorf("dbus: invalid variant signature %q", s) }
+            v, err := d.value(t)
+            return Variant{Value: v}, err
+        case 'a':
+            n, err := d.uint32()
+            if err != nil { return nil, err }
+            elem := sig[1:]
+            if err := d.align(alignment(elem)); err != nil { return nil, err }
+            end := d.pos + int(n)
+            if end > len(d.buf) { return nil, errShort }
+
+            switch elem[0] {
+                case 'y':
+                    b, err := d.bytes(int(n))
+                    return append([]byte(nil), b...), err
+                case '{':
+                    k, rest, err := next(elem[1:len(elem)-1])
+                    if err != nil { return nil, err }
+                    m := make(map[any]any)
+                    for d.pos < end {
+                        if err := d.align(8); err != nil { return nil, err }
+                        key, err := d.value(k)
+                        if err != nil { return nil, err }
+                        val, err := d.value(rest)
+                        if err != nil { return nil, err }
+                        m[key] = val
+                    }
+                    return m, nil
+                default:
+                    xs := []any{}
+                    for d.pos < end {
+                        x, err := d.value(elem)
+                        if err != nil { return nil, err }
+                        xs = append(xs, x)
+                    }
+                    return xs, nil
+            }
+        case '(':
+            if err := d.align(8); err != nil { return nil, err }
+            return d.values(sig[1:len(sig)-1])
+    }
+
+    return nil, fmt.Errorf("dbus: invalid signature %q", sig)
+}
+
+// headerField is a message header field in the form (yv).
+type headerField struct {
+    Code  byte
+    Value Variant
+}
+
+// Marshal encodes a message in the D-Bus wire format.
+func (m *Message) Marshal() ([]byte, error) {
+    body := encoder{}
+    var sig string
+    for _, arg := range m.Body {
+        if arg == nil { return nil, errors.New("dbus: nil value") }
+        s, err := signatureOf(reflect.TypeOf(arg))
+        if err != nil { return nil, err }
+        sig += s
+        if err := body.value(reflect.ValueOf(arg)); err != nil { return nil, err }
+    }
+
+    var fields []headerField
+    field := func(code byte, value any) {
+        fields = append(fields, headerField{code, Variant{value}})
+    }
+    if m.Path        != ""  { field(1, m.Path) }
+    if m.Interface   != ""  { field(2, m.Interface) }
+    if m.Member      != ""  { field(3, m.Member) }
+    if m.ErrorName   != ""  { field(4, m.ErrorName) }
+    if m.ReplySerial != 0   { field(5, m.ReplySerial) }
+    if m.Destination != ""  { field(6, m.Destination) }
+    if m.Sender      != ""  { field(7, m.Sender) }
+    if sig           != ""  { field(8, Signature(sig)) }
+
+    e := encoder{}
+    e.buf = append(e.buf, 'l', byte(m.Type), m.Flags, 1)
+    e.uint32(uint32(len(body.buf)))
+    e.uint32(m.Serial)
+    if err := e.value(reflect.ValueOf(fields)); err != nil { return nil, err }
+    e.align(8)
+    e.buf = append(e.buf, body.buf...)
+
+    if len(e.buf) > maxMessageSize { return nil, errors.New("dbus: message too long") }
+    return e.buf, nil
+}
+
+// ReadMessage reads and decodes a single message in the D-Bus wire format.
+func ReadMessage(r io.Reader) (*Message, error) {
+    fixed := make([]byte, 16)
+    if _, err := io.ReadFull(r, fixed); err != nil { return nil, err }
+
+    var order binary.ByteOrder
+    switch fixed[0] {
+        case 'l': order = binary.LittleEndian
+        case 'B': order = binary.BigEndian
+        default: return nil, fmt.Errorf("dbus: invalid byte order %q", fixed[0])
+    }
+
+    bodyLength := int(order.Uint32(fixed[4:8]))
+    fieldsLength := int(order.Uint32(fixed[12:16]))
+    headerLength := 16 + fieldsLength
+    if headerLength % 8 != 0 { headerLength += 8 - (headerLength % 8) }
+    if (bodyLength > maxMessageSize) || (headerLength + bodyLength > maxMessageSize) {
+        return nil, errors.New("dbus: message too long")
+    }
+
+    buf := make([]byte, headerLength + bodyLength)
+    copy(buf, fixed)
+    if _, err := io.ReadFull(r, buf[16:]); err != nil { return nil, err }
+
+    m := &Message{
+        Type:   MessageType(buf[1]),
+        Flags:  buf[2],
+        Serial: order.Uint32(buf[8:12]),
+    }
+
+    d := decoder{buf: buf[:headerLength], pos: 12, order: order}
+    fields, err := d.value("a(yv)")
+    if err != nil { return nil, fmt.Errorf("dbus: invalid message header: %w", err) }
+
+    var sig Signature
+    for _, f := range fields.([]any) {
+        f := f.([]any)
+        code, value := f[0].(byte), f[1].(Variant).Value
+        var ok bool
+        switch code {
+            case 1: m.Path, ok = value.(ObjectPath)
+            case 2: m.Interface, ok = value.(string)
+            case 3: m.Member, ok = value.(string)
+            case 4: m.ErrorName, ok = value.(string)
+            case 5: m.ReplySerial, ok = value.(uint32)
+            case 6: m.Destination, ok = value.(string)
+            case 7: m.Sender, ok = value.(string)
+            case 8: sig, ok = value.(Signature)
+            default: ok = true // ignore unknown fields
+        }
+        if !ok { return nil, fmt.Errorf("dbus: invalid message header field %d", code) }
+    }
+
+    d = decoder{buf: buf[headerLength:], order: order}
+    m.Body, err = d.values(string(sig))
+    if err != nil { return nil, fmt.Errorf("dbus: invalid message body: %w", err) }
+
+    return m, nil
+}
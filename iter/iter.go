@@ -563,60 +563,6 @@ func Take[X any](
     }
 }
 
-// Tee returns a slice of n iterators that each, individually, produce the
-// same values otherwise produced by the input iterator. This can be thought
-// of at "copying" an iterator. The input iterators should not be used anywhere
-// else once provided to this function.
-//
-// For example, given an iterator abc that produces the letters "a", "b", "c",
-// Tee will return n iterators. Each returned iterator will, independently,
-// produce the letters "a", "b", "c".
-//
-// Where the returned iterators produces their inputs at different speeds
-// (their consumers are "out of step"), this requires growing amounts of
-// auxiliary storage.
-func Tee[X any](
-    n int,
-    g It[X],
-) []It[X] {
-
-    // TODO/PERF this could be more efficient.
-
-    gs := make([]It[X], n)
-    queues := make([][]X, n)
-
-    for i := 0; i < n; i++ {
-        queues[i] = make([]X, 0) // FIFO
-
-        gs[i] = func() It[X] {
-            queue := &queues[i]
-
-            return (func() (X, bool) {
-
-                // empty queue? produce more values
-                if len(*queue) == 0 {
-                    if x, ok := g(); ok {
-                        // send to all queues
-                        for j := 0; j < n; j++ {
-                            queues[j] = append(queues[j], x)
-                        }
-                    } else {
-                        // exhausted
-                        return x, ok
-                    }
-                }
-
-                // pop from front of queue
-                item := (*queue)[0]
-                *queue = (*queue)[1:]
-                return item, true
-            })
-        }()
-    }
-
-    return gs
-}
-
 // ToMap returns a new [builtin.map] (of Go type map[X]Y, not to be confused
 // with the higher order function [Map]). For each Pair produced by the input
 // iterator, Pair.Key is used as a map key and Pair.Value is used as the
@@ -1,13 +1,21 @@
 package iter_test
 
 import (
+    "bufio"
+    "encoding/csv"
+    "errors"
     "fmt"
+    "io"
     "math"
     "sort"
     "strings"
+    "sync"
     "testing"
+    "testing/iotest"
+    "time"
 
     "github.com/stretchr/testify/assert"
+    "github.com/tawesoft/golib/v2/internal/test"
     lazy "github.com/tawesoft/golib/v2/iter"
     "github.com/tawesoft/golib/v2/operator"
     "golang.org/x/exp/maps"
@@ -513,6 +521,89 @@ func TestPairwiseEnd(t *testing.T) {
     }
 }
 
+func TestReadCSV(t *testing.T) {
+    {
+        input := "a,b,c\n1,2,3\n\"x,y\",z,\n"
+        expected := [][]string{{"a", "b", "c"}, {"1", "2", "3"}, {"x,y", "z", ""}}
+        it, errf := lazy.ReadCSV(csv.NewReader(strings.NewReader(input)))
+        assert.Equal(t, expected, lazy.ToSlice(it))
+        assert.Nil(t, errf())
+    }
+    {
+        input := "a,b,c\n1,2\n"
+        it, errf := lazy.ReadCSV(csv.NewReader(strings.NewReader(input)))
+        assert.Equal(t, [][]string{{"a", "b", "c"}}, lazy.ToSlice(it))
+        assert.ErrorIs(t, errf(), csv.ErrFieldCount)
+    }
+}
+
+func TestReadJSON(t *testing.T) {
+    type person struct {
+        Name string
+        Age int
+    }
+    {
+        input := "{\"Name\": \"Alice\", \"Age\": 23}\r\n{\"Name\": \"Bob\", \"Age\": 29}\n"
+        expected := []person{{"Alice", 23}, {"Bob", 29}}
+        it, errf := lazy.ReadJSON[person](strings.NewReader(input))
+        assert.Equal(t, expected, lazy.ToSlice(it))
+        assert.Nil(t, errf())
+    }
+    {
+        input := "{\"Name\": \"Alice\", \"Age\": 23}\n{\"Name\": \"Bob\", \"Age\": \"?\"}\n"
+        it, errf := lazy.ReadJSON[person](strings.NewReader(input))
+        assert.Equal(t, []person{{"Alice", 23}}, lazy.ToSlice(it))
+        assert.Error(t, errf())
+    }
+}
+
+func TestReadLines(t *testing.T) {
+    type row struct {
+        input string
+        expected []string
+    }
+
+    rows := []row{
+        {"",                 []string{}},
+        {"\n",               []string{""}},
+        {"abc",              []string{"abc"}},
+        {"abc\n",            []string{"abc"}},
+        {"abc\r\ndef\nxyz",  []string{"abc", "def", "xyz"}},
+        {"abc\n\ndef\r\n",   []string{"abc", "", "def"}},
+        {"a\rb\n",           []string{"a\rb"}},
+    }
+
+    for _, r := range rows {
+        it, errf := lazy.ReadLines(strings.NewReader(r.input))
+        assert.Equal(t, r.expected, lazy.ToSlice(it), "input %q", r.input)
+        assert.Nil(t, errf())
+    }
+
+    {
+        errBroken := errors.New("broken reader")
+        input := io.MultiReader(
+            strings.NewReader("abc\ndef"),
+            iotest.ErrReader(errBroken),
+        )
+        it, errf := lazy.ReadLines(input)
+        assert.Equal(t, []string{"abc"}, lazy.ToSlice(it))
+        assert.ErrorIs(t, errf(), errBroken)
+    }
+}
+
+func TestReadTokens(t *testing.T) {
+    {
+        it, errf := lazy.ReadTokens(strings.NewReader("the quick\nbrown  fox"), bufio.ScanWords)
+        assert.Equal(t, []string{"the", "quick", "brown", "fox"}, lazy.ToSlice(it))
+        assert.Nil(t, errf())
+    }
+    {
+        it, errf := lazy.ReadTokens(strings.NewReader("abc\r\ndef"), nil)
+        assert.Equal(t, []string{"abc", "def"}, lazy.ToSlice(it))
+        assert.Nil(t, errf())
+    }
+}
+
 func TestReduce(t *testing.T) {
     mul := operator.Mul[int]
     {
@@ -624,6 +715,60 @@ func TestTee(t *testing.T) {
     }
 }
 
+func TestTee_large(t *testing.T) {
+    // exercises growing and releasing the shared buffer
+    gs := lazy.Tee(2, lazy.Take(1000, lazy.Counter(0, 1)))
+    a := lazy.ToSlice(lazy.Take(500, gs[0]))
+    b := lazy.ToSlice(gs[1])
+    a = lazy.AppendToSlice(a, gs[0])
+    assert.Equal(t, 1000, len(a))
+    assert.Equal(t, a, b)
+    for i := 0; i < 1000; i++ {
+        assert.Equal(t, i, a[i])
+    }
+}
+
+func TestTeeConcurrent(t *testing.T) {
+    const n = 4
+    const count = 1000
+    gs, errf := lazy.TeeConcurrent(n, lazy.TeeOptions{MaxLag: 10, Block: true},
+        lazy.Take(count, lazy.Counter(0, 1)))
+
+    results := make([][]int, n)
+    var wg sync.WaitGroup
+    wg.Add(n)
+    for i := 0; i < n; i++ {
+        go func(i int) {
+            defer wg.Done()
+            results[i] = lazy.ToSlice(gs[i])
+        }(i)
+    }
+
+    test.Completes(t, 5 * time.Second, wg.Wait)
+    assert.Nil(t, errf())
+    for i := 0; i < n; i++ {
+        assert.Equal(t, count, len(results[i]))
+        assert.Equal(t, results[0], results[i])
+    }
+}
+
+func TestTeeWith(t *testing.T) {
+    gs, errf := lazy.TeeWith(2, lazy.TeeOptions{MaxLag: 2},
+        lazy.FromSlice([]rune("abcdef")))
+
+    r, ok := gs[0](); assert.Equal(t, 'a', r); assert.True(t, ok)
+    r, ok = gs[0](); assert.Equal(t, 'b', r); assert.True(t, ok)
+    assert.Nil(t, errf())
+
+    // gs[0] would now be three values ahead of gs[1]
+    r, ok = gs[0](); assert.False(t, ok)
+    assert.ErrorIs(t, errf(), lazy.ErrMaxLag)
+    r, ok = gs[0](); assert.False(t, ok)
+
+    // gs[1] is unaffected
+    assert.Equal(t, "abcdef", lazy.ToString(gs[1]))
+}
+
 func TestToMap(t *testing.T) {
     f := func() lazy.It[lazy.Pair[string, int]] {
         i := 0
@@ -0,0 +1,162 @@
+package iter
+
+import (
+    "bufio"
+    "encoding/csv"
+    "encoding/json"
+    "errors"
+    "io"
+    "strings"
+
+    "github.com/tawesoft/golib/v2/operator"
+)
+
+// The Read* functions in this file return an iterator and an error function.
+// The iterator becomes exhausted at the end of input, or at the first read
+// error. The error function returns that error, or nil if the input ended
+// normally (i.e. with [io.EOF]). It is only meaningful to call the error
+// function once the iterator is exhausted.
+
+// readErr is a small helper that records the first non-EOF error from a
+// reader and provides the error function returned by the Read* functions.
+type readErr struct {
+    err error
+}
+
+func (r *readErr) set(err error) {
+    if (r.err == nil) && (err != nil) && !errors.Is(err, io.EOF) {
+        r.err = err
+    }
+}
+
+func (r *readErr) get() error {
+    return r.err
+}
+
+// ReadLines returns an iterator that produces each line of text read from r,
+// and a function that returns any read error once the iterator is exhausted.
+//
+// Each line is produced without its trailing end-of-line marker, which may be
+// either a single newline ("\n"), or a carriage return followed by a newline
+// ("\r\n"). The last line of input is produced even if it has no end-of-line
+// marker, unless it is empty.
+//
+// Unlike a [bufio.Scanner], there is no limit on the length of a single line.
+// For example, this is suitable for reading very large files without first
+// loading them into memory as a string (see [CutString]).
+func ReadLines(r io.Reader) (It[string], func() error) {
+    br := bufio.NewReader(r)
+    re := &readErr{}
+    done := false
+
+    return func() (string, bool) {
+        if done { return "", false }
+
+        line, err := br.ReadString('\n')
+        if err != nil {
+            done = true
+            re.set(err)
+            if (len(line) == 0) || (re.err != nil) { return "", false }
+        }
+
+        line = strings.TrimSuffix(line, "\n")
+        line = strings.TrimSuffix(line, "\r")
+        return line, true
+    }, re.get
+}
+
+// ReadTokens returns an iterator that produces each token read from r by a
+// [bufio.Scanner] using the provided split function, and a function that
+// returns any read error once the iterator is exhausted. If split is nil, it
+// defaults to [bufio.ScanLines].
+//
+// For example, ReadTokens(r, bufio.ScanWords) produces each space-separated
+// word of the input.
+//
+// Tokens are limited to [bufio.MaxScanTokenSize]. Longer tokens stop the
+// iterator with the error [bufio.ErrTooLong]. For reading lines of any length,
+// see [ReadLines].
+func ReadTokens(r io.Reader, split bufio.SplitFunc) (It[string], func() error) {
+    scanner := bufio.NewScanner(r)
+    if split != nil { scanner.Split(split) }
+    re := &readErr{}
+    done := false
+
+    return func() (string, bool) {
+        if done { return "", false }
+
+        if !scanner.Scan() {
+            done = true
+            re.set(scanner.Err())
+            return "", false
+        }
+
+        return scanner.Text(), true
+    }, re.get
+}
+
+// ReadCSV returns an iterator that produces each record read by a
+// [csv.Reader], and a function that returns any read or parse error once the
+// iterator is exhausted.
+//
+// The caller controls the CSV format by configuring the [csv.Reader] (e.g. the
+// Comma or FieldsPerRecord fields) before calling ReadCSV. If the csv.Reader
+// has ReuseRecord set, then each produced record is only valid until the next
+// record is produced.
+//
+// For example:
+//
+//     records, errf := ReadCSV(csv.NewReader(file))
+//     Walk(func(record []string) { ... }, records)
+//     if err := errf(); err != nil { ... }
+func ReadCSV(r *csv.Reader) (It[[]string], func() error) {
+    re := &readErr{}
+    done := false
+
+    return func() ([]string, bool) {
+        if done { return nil, false }
+
+        record, err := r.Read()
+        if err != nil {
+            done = true
+            re.set(err)
+            return nil, false
+        }
+
+        return record, true
+    }, re.get
+}
+
+// ReadJSON returns an iterator that produces each value of type T decoded
+// from a stream of JSON values read from r, and a function that returns any
+// read or decoding error once the iterator is exhausted.
+//
+// This is suitable for reading newline-delimited JSON (also known as "JSON
+// Lines" or "NDJSON"), where each line of input is a single JSON value, but
+// it equally accepts values separated by any JSON whitespace.
+//
+// For example, given input:
+//
+//     {"name": "Alice", "age": 23}
+//     {"name": "Bob", "age": 29}
+//
+// ReadJSON[Person](input) produces two Person values.
+func ReadJSON[T any](r io.Reader) (It[T], func() error) {
+    decoder := json.NewDecoder(r)
+    re := &readErr{}
+    zero := operator.Zero[T]()
+    done := false
+
+    return func() (T, bool) {
+        if done { return zero, false }
+
+        var t T
+        if err := decoder.Decode(&t); err != nil {
+            done = true
+            re.set(err)
+            return zero, false
+        }
+
+        return t, true
+    }, re.get
+}
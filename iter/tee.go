@@ -0,0 +1,250 @@
+package iter
+
+import (
+    "errors"
+    "sync"
+
+    "github.com/tawesoft/golib/v2/operator"
+)
+
+// ErrMaxLag is the error reported by the error function returned by
+// [TeeWith] and [TeeConcurrent] when an iterator could not produce a value
+// without exceeding [TeeOptions.MaxLag].
+var ErrMaxLag = errors.New("iter: Tee maximum lag exceeded")
+
+// TeeOptions configures the iterators returned by [TeeWith] and
+// [TeeConcurrent].
+type TeeOptions struct {
+    // MaxLag, if positive, is the maximum number of values that any returned
+    // iterator may have produced ahead of the iterator that has produced the
+    // fewest values. This is also the maximum number of values held in
+    // auxiliary storage at any one time. If zero or negative, there is no
+    // limit.
+    MaxLag int
+
+    // Block controls what happens when producing a value would exceed MaxLag.
+    //
+    // If false, the iterator that would exceed MaxLag instead becomes
+    // exhausted (without affecting the other iterators), and the error
+    // function returns [ErrMaxLag].
+    //
+    // If true, the iterator blocks until the other iterators have caught up.
+    // This is only meaningful for [TeeConcurrent], where the other iterators
+    // are consumed by other goroutines. [TeeWith] ignores this field because a
+    // single goroutine could never unblock itself.
+    Block bool
+}
+
+// Tee returns a slice of n iterators that each, individually, produce the
+// same values otherwise produced by the input iterator. This can be thought
+// of at "copying" an iterator. The input iterators should not be used anywhere
+// else once provided to this function.
+//
+// For example, given an iterator abc that produces the letters "a", "b", "c",
+// Tee will return n iterators. Each returned iterator will, independently,
+// produce the letters "a", "b", "c".
+//
+// Values are stored once, in a buffer shared by every returned iterator, until
+// every returned iterator has produced them. Where the returned iterators
+// produce their values at different speeds (their consumers are "out of
+// step"), this requires growing amounts of auxiliary storage. See [TeeWith]
+// to limit this, and [TeeConcurrent] for iterators that may be consumed by
+// different goroutines.
+func Tee[X any](
+    n int,
+    it It[X],
+) []It[X] {
+    gs, _ := TeeWith(n, TeeOptions{}, it)
+    return gs
+}
+
+// TeeWith is like [Tee], but accepts options that may bound the auxiliary
+// storage. It returns the slice of iterators and a function that returns
+// [ErrMaxLag] if any iterator has become exhausted early because it reached
+// the maximum lag, or nil otherwise.
+//
+// The returned iterators are not safe for concurrent use. See
+// [TeeConcurrent].
+func TeeWith[X any](
+    n int,
+    opts TeeOptions,
+    it It[X],
+) ([]It[X], func() error) {
+    t := newTeeBuffer(n, opts.MaxLag, it)
+    gs := make([]It[X], n)
+
+    for i := 0; i < n; i++ {
+        i := i
+        gs[i] = func() (X, bool) {
+            x, ok, full := t.next(i)
+            if full {
+                t.detach(i)
+                t.err = ErrMaxLag
+            }
+            return x, ok
+        }
+    }
+
+    return gs, t.getErr
+}
+
+// TeeConcurrent is like [TeeWith], but each returned iterator may be consumed
+// by a different goroutine. The input iterator is only ever called by one
+// goroutine at a time.
+//
+// If [TeeOptions.Block] is true, then an iterator that would exceed
+// [TeeOptions.MaxLag] waits for the slowest iterator to produce more values.
+// This blocks forever if the slowest iterator is never consumed again, so
+// every returned iterator should be consumed until exhausted.
+func TeeConcurrent[X any](
+    n int,
+    opts TeeOptions,
+    it It[X],
+) ([]It[X], func() error) {
+    t := &teeConcurrent[X]{t: newTeeBuffer(n, opts.MaxLag, it)}
+    t.cond = sync.NewCond(&t.mu)
+    gs := make([]It[X], n)
+
+    for i := 0; i < n; i++ {
+        i := i
+        gs[i] = func() (X, bool) {
+            return t.next(i, opts.Block)
+        }
+    }
+
+    return gs, t.getErr
+}
+
+// teeBuffer is a ring buffer shared by each iterator returned by Tee. The
+// value with sequence number s (counting values produced by the input
+// iterator from zero) is stored at buf[s % len(buf)].
+type teeBuffer[X any] struct {
+    source    It[X]
+    exhausted bool
+    buf       []X
+    head      int   // sequence number of the oldest value held in buf
+    tail      int   // sequence number of the next value from source
+    pos       []int // per-iterator sequence number of next value, or -1
+    maxLag    int
+    err       error
+}
+
+func newTeeBuffer[X any](n int, maxLag int, source It[X]) *teeBuffer[X] {
+    return &teeBuffer[X]{
+        source: source,
+        pos:    make([]int, n),
+        maxLag: maxLag,
+    }
+}
+
+func (t *teeBuffer[X]) getErr() error {
+    return t.err
+}
+
+// next returns the next value for the i'th iterator. If the value could not
+// be produced without exceeding the maximum lag, returns full == true.
+func (t *teeBuffer[X]) next(i int) (x X, ok bool, full bool) {
+    p := t.pos[i]
+    if p < 0 { return x, false, false }
+
+    if p == t.tail {
+        if t.exhausted { return x, false, false }
+
+        limited := (t.maxLag > 0)
+        if (t.tail - t.head == len(t.buf)) || (limited && (t.tail - t.head >= t.maxLag)) {
+            t.release()
+        }
+        if limited && (t.tail - t.head >= t.maxLag) {
+            return x, false, true
+        }
+        if t.tail - t.head == len(t.buf) {
+            t.grow()
+        }
+
+        v, ok := t.source()
+        if !ok {
+            t.exhausted = true
+            t.source = nil
+            return x, false, false
+        }
+
+        t.buf[t.tail % len(t.buf)] = v
+        t.tail++
+    }
+
+    t.pos[i]++
+    return t.buf[p % len(t.buf)], true, false
+}
+
+// detach stops the i'th iterator so that it no longer holds values in the
+// buffer.
+func (t *teeBuffer[X]) detach(i int) {
+    t.pos[i] = -1
+}
+
+// release discards every value that has been produced by every iterator.
+func (t *teeBuffer[X]) release() {
+    min := t.tail
+    for _, p := range t.pos {
+        if (p >= 0) && (p < min) { min = p }
+    }
+
+    zero := operator.Zero[X]()
+    for s := t.head; s < min; s++ {
+        t.buf[s % len(t.buf)] = zero // allow garbage collection
+    }
+
+    t.head = min
+}
+
+// grow increases the capacity of the buffer.
+func (t *teeBuffer[X]) grow() {
+    size := 2 * len(t.buf)
+    if size < 8 { size = 8 }
+    if (t.maxLag > 0) && (size > t.maxLag) { size = t.maxLag }
+
+    buf := make([]X, size)
+    for s := t.head; s < t.tail; s++ {
+        buf[s % size] = t.buf[s % len(t.buf)]
+    }
+    t.buf = buf
+}
+
+type teeConcurrent[X any] struct {
+    mu      sync.Mutex
+    cond    *sync.Cond
+    waiting int
+    t       *teeBuffer[X]
+}
+
+func (t *teeConcurrent[X]) getErr() error {
+    t.mu.Lock()
+    defer t.mu.Unlock()
+    return t.t.err
+}
+
+func (t *teeConcurrent[X]) next(i int, block bool) (X, bool) {
+    t.mu.Lock()
+    defer t.mu.Unlock()
+
+    for {
+        x, ok, full := t.t.next(i)
+        if !full {
+            // any iterator, including this one, advancing or becoming
+            // exhausted may unblock a waiting iterator.
+            if t.waiting > 0 { t.cond.Broadcast() }
+            return x, ok
+        }
+
+        if !block {
+            t.t.detach(i)
+            t.t.err = ErrMaxLag
+            if t.waiting > 0 { t.cond.Broadcast() }
+            return x, false
+        }
+
+        t.waiting++
+        t.cond.Wait()
+        t.waiting--
+    }
+}
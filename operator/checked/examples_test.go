@@ -40,3 +40,25 @@ func ExampleLimits() {
     // checked.Sub(min, max, 10, 9): 1, ok?=true
     // limit.Sub(10, 25): 0, ok?=false
 }
+
+func ExampleConvert() {
+    {
+        result, ok := checked.Convert[int64, uint16](70000)
+        fmt.Printf("checked.Convert[int64, uint16](70000): %d, ok?=%t\n", result, ok)
+    }
+
+    {
+        result := checked.SaturatingConvert[int64, uint16](70000)
+        fmt.Printf("checked.SaturatingConvert[int64, uint16](70000): %d\n", result)
+    }
+
+    {
+        result, ok := checked.FloatToInt[int64](12.5)
+        fmt.Printf("checked.FloatToInt[int64](12.5): %d, ok?=%t\n", result, ok)
+    }
+
+    // Output:
+    // checked.Convert[int64, uint16](70000): 0, ok?=false
+    // checked.SaturatingConvert[int64, uint16](70000): 65535
+    // checked.FloatToInt[int64](12.5): 0, ok?=false
+}
@@ -0,0 +1,80 @@
+package checked
+
+import (
+    "math"
+    "unsafe"
+
+    "golang.org/x/exp/constraints"
+)
+
+// Finite returns true iff x is neither NaN nor positive or negative infinity.
+func Finite[F constraints.Float](x F) bool {
+    return !math.IsNaN(float64(x)) && !math.IsInf(float64(x), 0)
+}
+
+func finite[F constraints.Float](x F) (F, bool) {
+    if !Finite(x) { return 0, false }
+    return x, true
+}
+
+// AddFloat returns (a + b, true) iff the result is finite, otherwise returns
+// (0, false). The result is not finite if it overflows to infinity, or if
+// either input is not finite.
+func AddFloat[F constraints.Float](a F, b F) (F, bool) {
+    return finite(a + b)
+}
+
+// SubFloat returns (a - b, true) iff the result is finite, otherwise returns
+// (0, false), in the same manner as [AddFloat].
+func SubFloat[F constraints.Float](a F, b F) (F, bool) {
+    return finite(a - b)
+}
+
+// MulFloat returns (a * b, true) iff the result is finite, otherwise returns
+// (0, false), in the same manner as [AddFloat].
+func MulFloat[F constraints.Float](a F, b F) (F, bool) {
+    return finite(a * b)
+}
+
+// DivFloat returns (a / b, true) iff the result is finite, otherwise returns
+// (0, false), in the same manner as [AddFloat]. In particular, division by
+// zero is not finite.
+func DivFloat[F constraints.Float](a F, b F) (F, bool) {
+    if b == 0 { return 0, false }
+    return finite(a / b)
+}
+
+// FloatToInt returns (I(x), true) iff x is finite, has no fractional part,
+// and can be represented exactly by the integer type I, otherwise returns
+// (0, false).
+//
+// For example, FloatToInt[int64](1.5), FloatToInt[int64](math.NaN()), and
+// FloatToInt[uint8](256.0) all return (0, false).
+func FloatToInt[I constraints.Integer, F constraints.Float](x F) (I, bool) {
+    f := float64(x)
+    if !Finite(f) || (math.Trunc(f) != f) { return 0, false }
+
+    var zero I
+    bits := int(8 * unsafe.Sizeof(zero))
+    var lo, hi float64 // lo <= f < hi
+    if zero - 1 > 0 { // unsigned
+        lo, hi = 0, math.Ldexp(1, bits)
+    } else {
+        lo, hi = -math.Ldexp(1, bits - 1), math.Ldexp(1, bits - 1)
+    }
+    if (f < lo) || (f >= hi) { return 0, false }
+
+    return I(f), true
+}
+
+// IntToFloat returns (F(i), true) iff the integer i can be represented
+// exactly by the floating point type F, otherwise returns (0, false).
+//
+// For example, a float64 represents every integer with a magnitude up to
+// 2^53 exactly, but IntToFloat[float64](int64(1 << 53 + 1)) returns
+// (0, false).
+func IntToFloat[F constraints.Float, I constraints.Integer](i I) (F, bool) {
+    f := F(i)
+    if j, ok := FloatToInt[I](f); !ok || (j != i) { return 0, false }
+    return f, true
+}
@@ -0,0 +1,41 @@
+package checked_test
+
+import (
+    "math"
+    "testing"
+
+    "github.com/stretchr/testify/assert"
+    "github.com/tawesoft/golib/v2/operator/checked"
+    "github.com/tawesoft/golib/v2/tuple"
+)
+
+func TestFloat(t *testing.T) {
+    inf := math.Inf(1)
+
+    assert.Equal(t, tuple.ToT2(3.0, true),  tuple.ToT2(checked.AddFloat(1.0, 2.0)))
+    assert.Equal(t, tuple.ToT2(0.0, false), tuple.ToT2(checked.AddFloat(math.MaxFloat64, math.MaxFloat64)))
+    assert.Equal(t, tuple.ToT2(0.0, false), tuple.ToT2(checked.SubFloat(inf, inf)))
+    assert.Equal(t, tuple.ToT2(float32(0), false), tuple.ToT2(checked.MulFloat[float32](math.MaxFloat32, 2)))
+    assert.Equal(t, tuple.ToT2(0.0, false), tuple.ToT2(checked.DivFloat(1.0, 0.0)))
+    assert.Equal(t, tuple.ToT2(0.5, true),  tuple.ToT2(checked.DivFloat(1.0, 2.0)))
+    assert.False(t, checked.Finite(math.NaN()))
+}
+
+func TestFloatToInt(t *testing.T) {
+    assert.Equal(t, tuple.ToT2(int64(3), true),  tuple.ToT2(checked.FloatToInt[int64](3.0)))
+    assert.Equal(t, tuple.ToT2(int64(0), false), tuple.ToT2(checked.FloatToInt[int64](1.5)))
+    assert.Equal(t, tuple.ToT2(int64(0), false), tuple.ToT2(checked.FloatToInt[int64](math.NaN())))
+    assert.Equal(t, tuple.ToT2(int64(0), false), tuple.ToT2(checked.FloatToInt[int64](math.Inf(-1))))
+    assert.Equal(t, tuple.ToT2(int64(0), false), tuple.ToT2(checked.FloatToInt[int64](math.Ldexp(1, 63))))
+    assert.Equal(t, tuple.ToT2(int64(math.MinInt64), true), tuple.ToT2(checked.FloatToInt[int64](-math.Ldexp(1, 63))))
+    assert.Equal(t, tuple.ToT2(uint8(255), true), tuple.ToT2(checked.FloatToInt[uint8](255.0)))
+    assert.Equal(t, tuple.ToT2(uint8(0),  false), tuple.ToT2(checked.FloatToInt[uint8](256.0)))
+    assert.Equal(t, tuple.ToT2(uint8(0),  false), tuple.ToT2(checked.FloatToInt[uint8](-1.0)))
+}
+
+func TestIntToFloat(t *testing.T) {
+    assert.Equal(t, tuple.ToT2(float64(1 << 53), true),  tuple.ToT2(checked.IntToFloat[float64](int64(1 << 53))))
+    assert.Equal(t, tuple.ToT2(float64(0),       false), tuple.ToT2(checked.IntToFloat[float64](int64(1 << 53 + 1))))
+    assert.Equal(t, tuple.ToT2(float64(0),       false), tuple.ToT2(checked.IntToFloat[float64](int64(math.MaxInt64))))
+    assert.Equal(t, tuple.ToT2(float32(0),       false), tuple.ToT2(checked.IntToFloat[float32](int32(1 << 24 + 1))))
+}
@@ -1,5 +1,13 @@
 // Package checked (operator/checked) implements operations on integers
 // that are robust in the event of integer overflow.
+//
+// Each checked operation returns a result and a boolean that is false if the
+// result does not lie between a given min and max. Each "saturating"
+// operation instead returns a result clamped to min or max.
+//
+// The package also implements checked conversions between integer types, and
+// between integers and floating point numbers, and checked floating point
+// operations that detect the production of NaN or infinite values.
 package checked
 
 import (
@@ -61,3 +69,97 @@ func Abs[N constraints.Integer](min N, max N, i N) (N, bool) {
 func Inv[N constraints.Integer](min N, max N, i N) (N, bool) {
     return Sub(min, max, 0, i)
 }
+
+// Div returns (a / b, true) iff b is not zero and the result lies between min
+// and max inclusive, otherwise returns (0, false). As with the Go division
+// operator, the result is truncated towards zero. This calculation is robust
+// in the event of integer overflow.
+//
+// The input arguments must satisfy the inequalities: `min <= a <= max` and
+// `min <= b <= max`.
+func Div[N constraints.Integer](min N, max N, a N, b N) (N, bool) {
+    if b == 0 { return 0, false }
+    if (b < 0) && (b + 1 == 0) { return Inv(min, max, a) } // b == -1
+    x := a / b
+    if (x < min) || (x > max) { return 0, false }
+    return x, true
+}
+
+// Mod returns (a % b, true) iff b is not zero and the result lies between min
+// and max inclusive, otherwise returns (0, false). As with the Go remainder
+// operator, the result has the same sign as a.
+//
+// The input arguments must satisfy the inequalities: `min <= a <= max` and
+// `min <= b <= max`.
+func Mod[N constraints.Integer](min N, max N, a N, b N) (N, bool) {
+    if b == 0 { return 0, false }
+    x := a % b
+    if (x < min) || (x > max) { return 0, false }
+    return x, true
+}
+
+// Pow returns (base raised to the power of exp, true) iff the result lies
+// between min and max inclusive, otherwise returns (0, false). This
+// calculation is robust in the event of integer overflow.
+//
+// Any intermediate power of base must also lie between min and max, which is
+// always the case when min and max are the limits of a type (see [Limits]).
+//
+// The input arguments must satisfy the inequality: `min <= base <= max`.
+func Pow[N constraints.Integer](min N, max N, base N, exp uint) (N, bool) {
+    if exp == 0 {
+        if (1 < min) || (1 > max) { return 0, false }
+        return 1, true
+    }
+
+    var result N
+    first, ok := true, true
+    for exp > 0 {
+        if exp & 1 == 1 {
+            if first {
+                result, first = base, false
+            } else if result, ok = Mul(min, max, result, base); !ok {
+                return 0, false
+            }
+        }
+        exp >>= 1
+        if exp > 0 {
+            if base, ok = Mul(min, max, base, base); !ok { return 0, false }
+        }
+    }
+    return result, true
+}
+
+// Shl returns (a << n, true) iff no set bits are lost and the result lies
+// between min and max inclusive, otherwise returns (0, false). For signed
+// integers, this also means the sign does not change.
+//
+// The input arguments must satisfy the inequality: `min <= a <= max`.
+func Shl[N constraints.Integer](min N, max N, a N, n uint) (N, bool) {
+    x := a << n
+    if (x >> n) != a { return 0, false }
+    if (x < min) || (x > max) { return 0, false }
+    return x, true
+}
+
+// Shr returns (a >> n, true) iff the result lies between min and max
+// inclusive, otherwise returns (0, false). As with the Go shift operator, the
+// shift is arithmetic for signed integers.
+//
+// The input arguments must satisfy the inequality: `min <= a <= max`.
+func Shr[N constraints.Integer](min N, max N, a N, n uint) (N, bool) {
+    x := a >> n
+    if (x < min) || (x > max) { return 0, false }
+    return x, true
+}
+
+// Convert returns (To(x), true) iff x can be represented exactly by the
+// integer type To, otherwise returns (0, false).
+//
+// For example, Convert[int64, uint16](-1) and Convert[int64, uint16](65536)
+// both return (0, false).
+func Convert[From constraints.Integer, To constraints.Integer](x From) (To, bool) {
+    y := To(x)
+    if (From(y) != x) || ((x < 0) != (y < 0)) { return 0, false }
+    return y, true
+}
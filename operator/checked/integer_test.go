@@ -26,6 +26,50 @@ func TestMul(t *testing.T) {
     assert.Equal(t, tuple.ToT2(int8(0),  false), tuple.ToT2(checked.Int8.Mul(64, 3)))
 }
 
+func TestDiv(t *testing.T) {
+    assert.Equal(t, tuple.ToT2(-3,       true),  tuple.ToT2(checked.Int.Div(-7, 2)))
+    assert.Equal(t, tuple.ToT2(0,        false), tuple.ToT2(checked.Int.Div(7, 0)))
+    assert.Equal(t, tuple.ToT2(int8(0),  false), tuple.ToT2(checked.Int8.Div(-128, -1)))
+    assert.Equal(t, tuple.ToT2(int8(127), true), tuple.ToT2(checked.Int8.Div(-127, -1)))
+    assert.Equal(t, tuple.ToT2(uint8(5), true),  tuple.ToT2(checked.Uint8.Div(255, 51)))
+}
+
+func TestMod(t *testing.T) {
+    assert.Equal(t, tuple.ToT2(-1,      true),  tuple.ToT2(checked.Int.Mod(-7, 2)))
+    assert.Equal(t, tuple.ToT2(0,       false), tuple.ToT2(checked.Int.Mod(7, 0)))
+    assert.Equal(t, tuple.ToT2(int8(0), true),  tuple.ToT2(checked.Int8.Mod(-128, -1)))
+}
+
+func TestPow(t *testing.T) {
+    assert.Equal(t, tuple.ToT2(1,                true),  tuple.ToT2(checked.Int.Pow(7, 0)))
+    assert.Equal(t, tuple.ToT2(-27,              true),  tuple.ToT2(checked.Int.Pow(-3, 3)))
+    assert.Equal(t, tuple.ToT2(int8(-128),       true),  tuple.ToT2(checked.Int8.Pow(-2, 7)))
+    assert.Equal(t, tuple.ToT2(int8(0),          false), tuple.ToT2(checked.Int8.Pow(2, 7)))
+    assert.Equal(t, tuple.ToT2(int64(math.MinInt64), true), tuple.ToT2(checked.Int64.Pow(-2, 63)))
+    assert.Equal(t, tuple.ToT2(uint64(0),        false), tuple.ToT2(checked.Uint64.Pow(2, 64)))
+    assert.Equal(t, tuple.ToT2(uint64(1 << 63),  true),  tuple.ToT2(checked.Uint64.Pow(2, 63)))
+    assert.Equal(t, tuple.ToT2(0,                false), tuple.ToT2(checked.Pow(2, 9, 3, 0)))
+}
+
+func TestShift(t *testing.T) {
+    assert.Equal(t, tuple.ToT2(int8(64),   true),  tuple.ToT2(checked.Int8.Shl(1, 6)))
+    assert.Equal(t, tuple.ToT2(int8(0),    false), tuple.ToT2(checked.Int8.Shl(1, 7)))
+    assert.Equal(t, tuple.ToT2(int8(-128), true),  tuple.ToT2(checked.Int8.Shl(-1, 7)))
+    assert.Equal(t, tuple.ToT2(uint8(0),   false), tuple.ToT2(checked.Uint8.Shl(3, 7)))
+    assert.Equal(t, tuple.ToT2(uint8(0),   false), tuple.ToT2(checked.Uint8.Shl(1, 100)))
+    assert.Equal(t, tuple.ToT2(int8(-1),   true),  tuple.ToT2(checked.Int8.Shr(-128, 7)))
+    assert.Equal(t, tuple.ToT2(0,          false), tuple.ToT2(checked.Shr(1, 10, 8, 4)))
+}
+
+func TestConvert(t *testing.T) {
+    assert.Equal(t, tuple.ToT2(uint16(0),     false), tuple.ToT2(checked.Convert[int64, uint16](-1)))
+    assert.Equal(t, tuple.ToT2(uint16(0),     false), tuple.ToT2(checked.Convert[int64, uint16](65536)))
+    assert.Equal(t, tuple.ToT2(uint16(65535), true),  tuple.ToT2(checked.Convert[int64, uint16](65535)))
+    assert.Equal(t, tuple.ToT2(int64(0),      false), tuple.ToT2(checked.Convert[uint64, int64](math.MaxUint64)))
+    assert.Equal(t, tuple.ToT2(int8(0),       false), tuple.ToT2(checked.Convert[uint8, int8](128)))
+    assert.Equal(t, tuple.ToT2(int8(-128),    true),  tuple.ToT2(checked.Convert[int64, int8](-128)))
+}
+
 func FuzzAdd_Int32(f *testing.F) {
     type row struct {
         a int32
@@ -79,3 +79,81 @@ func (l Limits[I]) Abs(i I) (I, bool) {
 func (l Limits[I]) Inv(i I) (I, bool) {
     return Inv(l.Min, l.Max, i)
 }
+
+// Div calls [checked.Div] with min and max filled in with the associated
+// [Limits] values.
+func (l Limits[I]) Div(a I, b I) (I, bool) {
+    return Div(l.Min, l.Max, a, b)
+}
+
+// Mod calls [checked.Mod] with min and max filled in with the associated
+// [Limits] values.
+func (l Limits[I]) Mod(a I, b I) (I, bool) {
+    return Mod(l.Min, l.Max, a, b)
+}
+
+// Pow calls [checked.Pow] with min and max filled in with the associated
+// [Limits] values.
+func (l Limits[I]) Pow(base I, exp uint) (I, bool) {
+    return Pow(l.Min, l.Max, base, exp)
+}
+
+// Shl calls [checked.Shl] with min and max filled in with the associated
+// [Limits] values.
+func (l Limits[I]) Shl(a I, n uint) (I, bool) {
+    return Shl(l.Min, l.Max, a, n)
+}
+
+// Shr calls [checked.Shr] with min and max filled in with the associated
+// [Limits] values.
+func (l Limits[I]) Shr(a I, n uint) (I, bool) {
+    return Shr(l.Min, l.Max, a, n)
+}
+
+// SaturatingAdd calls [checked.SaturatingAdd] with min and max filled in
+// with the associated [Limits] values.
+func (l Limits[I]) SaturatingAdd(a I, b I) I {
+    return SaturatingAdd(l.Min, l.Max, a, b)
+}
+
+// SaturatingSub calls [checked.SaturatingSub] with min and max filled in
+// with the associated [Limits] values.
+func (l Limits[I]) SaturatingSub(a I, b I) I {
+    return SaturatingSub(l.Min, l.Max, a, b)
+}
+
+// SaturatingMul calls [checked.SaturatingMul] with min and max filled in
+// with the associated [Limits] values.
+func (l Limits[I]) SaturatingMul(a I, b I) I {
+    return SaturatingMul(l.Min, l.Max, a, b)
+}
+
+// SaturatingDiv calls [checked.SaturatingDiv] with min and max filled in
+// with the associated [Limits] values.
+func (l Limits[I]) SaturatingDiv(a I, b I) I {
+    return SaturatingDiv(l.Min, l.Max, a, b)
+}
+
+// SaturatingAbs calls [checked.SaturatingAbs] with min and max filled in
+// with the associated [Limits] values.
+func (l Limits[I]) SaturatingAbs(i I) I {
+    return SaturatingAbs(l.Min, l.Max, i)
+}
+
+// SaturatingInv calls [checked.SaturatingInv] with min and max filled in
+// with the associated [Limits] values.
+func (l Limits[I]) SaturatingInv(i I) I {
+    return SaturatingInv(l.Min, l.Max, i)
+}
+
+// SaturatingPow calls [checked.SaturatingPow] with min and max filled in
+// with the associated [Limits] values.
+func (l Limits[I]) SaturatingPow(base I, exp uint) I {
+    return SaturatingPow(l.Min, l.Max, base, exp)
+}
+
+// SaturatingShl calls [checked.SaturatingShl] with min and max filled in
+// with the associated [Limits] values.
+func (l Limits[I]) SaturatingShl(a I, n uint) I {
+    return SaturatingShl(l.Min, l.Max, a, n)
+}
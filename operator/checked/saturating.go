@@ -0,0 +1,123 @@
+package checked
+
+import (
+    "unsafe"
+
+    "golang.org/x/exp/constraints"
+)
+
+// limitsOf returns the Limits of any integer type, including types defined
+// in terms of an integer type, which [GetLimits] does not support.
+func limitsOf[I constraints.Integer]() Limits[I] {
+    var zero I
+    if zero - 1 > 0 { // unsigned
+        return Limits[I]{0, ^zero}
+    }
+    bits := 8 * unsafe.Sizeof(zero)
+    max := I((uint64(1) << (bits - 1)) - 1)
+    return Limits[I]{-max - 1, max}
+}
+
+// SaturatingAdd returns a + b, or, if the result does not lie between min and
+// max inclusive, returns whichever of min or max is closest to the result.
+//
+// The input arguments must satisfy the inequalities: `min <= a <= max` and
+// `min <= b <= max`.
+func SaturatingAdd[N constraints.Integer](min N, max N, a N, b N) N {
+    if x, ok := Add(min, max, a, b); ok { return x }
+    if b > 0 { return max }
+    return min
+}
+
+// SaturatingSub returns a - b, or, if the result does not lie between min and
+// max inclusive, returns whichever of min or max is closest to the result.
+//
+// The input arguments must satisfy the inequalities: `min <= a <= max` and
+// `min <= b <= max`.
+func SaturatingSub[N constraints.Integer](min N, max N, a N, b N) N {
+    if x, ok := Sub(min, max, a, b); ok { return x }
+    if b < 0 { return max }
+    return min
+}
+
+// SaturatingMul returns a * b, or, if the result does not lie between min and
+// max inclusive, returns whichever of min or max is closest to the result.
+//
+// The input arguments must satisfy the inequalities: `min <= a <= max` and
+// `min <= b <= max`.
+func SaturatingMul[N constraints.Integer](min N, max N, a N, b N) N {
+    if x, ok := Mul(min, max, a, b); ok { return x }
+    if (a < 0) != (b < 0) { return min }
+    return max
+}
+
+// SaturatingDiv returns a / b, or, if the result does not lie between min and
+// max inclusive, returns whichever of min or max is closest to the result. As
+// with the Go division operator, it panics if b is zero.
+//
+// The input arguments must satisfy the inequalities: `min <= a <= max` and
+// `min <= b <= max`.
+func SaturatingDiv[N constraints.Integer](min N, max N, a N, b N) N {
+    if b == 0 { panic("checked.SaturatingDiv: division by zero") }
+    if x, ok := Div(min, max, a, b); ok { return x }
+    if (a < 0) != (b < 0) { return min }
+    return max
+}
+
+// SaturatingAbs returns the absolute value of i, or max if the result is
+// greater than max.
+//
+// The input arguments must satisfy the inequality `min <= i <= max`.
+func SaturatingAbs[N constraints.Integer](min N, max N, i N) N {
+    if x, ok := Abs(min, max, i); ok { return x }
+    return max
+}
+
+// SaturatingInv returns -i, or, if the result does not lie between min and
+// max inclusive, returns whichever of min or max is closest to the result.
+//
+// The input arguments must satisfy the inequality `min <= i <= max`.
+func SaturatingInv[N constraints.Integer](min N, max N, i N) N {
+    if x, ok := Inv(min, max, i); ok { return x }
+    if i < 0 { return max }
+    return min
+}
+
+// SaturatingPow returns base raised to the power of exp, or, if the result
+// does not lie between min and max inclusive, returns whichever of min or max
+// is closest to the result.
+//
+// The input arguments must satisfy the inequality: `min <= base <= max`.
+func SaturatingPow[N constraints.Integer](min N, max N, base N, exp uint) N {
+    if x, ok := Pow(min, max, base, exp); ok { return x }
+    if exp == 0 {
+        if 1 < min { return min }
+        return max
+    }
+    if (base < 0) && (exp & 1 == 1) { return min }
+    return max
+}
+
+// SaturatingShl returns a << n, or, if any set bits are lost or the result
+// does not lie between min and max inclusive, returns min if a is negative,
+// or max otherwise.
+//
+// The input arguments must satisfy the inequality: `min <= a <= max`.
+func SaturatingShl[N constraints.Integer](min N, max N, a N, n uint) N {
+    if x, ok := Shl(min, max, a, n); ok { return x }
+    if a < 0 { return min }
+    return max
+}
+
+// SaturatingConvert returns To(x), or, if x cannot be represented by the
+// integer type To, returns the minimum or maximum value of type To, whichever
+// is closest to x.
+//
+// For example, SaturatingConvert[int64, uint16](-1) returns 0, and
+// SaturatingConvert[int64, uint16](65536) returns 65535.
+func SaturatingConvert[From constraints.Integer, To constraints.Integer](x From) To {
+    if y, ok := Convert[From, To](x); ok { return y }
+    l := limitsOf[To]()
+    if x < 0 { return l.Min }
+    return l.Max
+}
@@ -0,0 +1,40 @@
+package checked_test
+
+import (
+    "math"
+    "testing"
+
+    "github.com/stretchr/testify/assert"
+    "github.com/tawesoft/golib/v2/operator/checked"
+)
+
+func TestSaturating(t *testing.T) {
+    assert.Equal(t, int8(127),  checked.Int8.SaturatingAdd(100, 100))
+    assert.Equal(t, int8(-128), checked.Int8.SaturatingAdd(-100, -100))
+    assert.Equal(t, int8(7),    checked.Int8.SaturatingAdd(3, 4))
+    assert.Equal(t, uint8(0),   checked.Uint8.SaturatingSub(3, 4))
+    assert.Equal(t, int8(127),  checked.Int8.SaturatingSub(100, -100))
+    assert.Equal(t, int8(-128), checked.Int8.SaturatingMul(-100, 100))
+    assert.Equal(t, int8(127),  checked.Int8.SaturatingMul(-100, -100))
+    assert.Equal(t, int8(127),  checked.Int8.SaturatingDiv(-128, -1))
+    assert.Equal(t, int8(127),  checked.Int8.SaturatingAbs(-128))
+    assert.Equal(t, int8(127),  checked.Int8.SaturatingInv(-128))
+    assert.Equal(t, uint8(0),   checked.Uint8.SaturatingInv(5))
+    assert.Equal(t, int8(-128), checked.Int8.SaturatingPow(-3, 5))
+    assert.Equal(t, int8(127),  checked.Int8.SaturatingPow(-3, 6))
+    assert.Equal(t, int8(-128), checked.Int8.SaturatingShl(-3, 6))
+    assert.Equal(t, int8(127),  checked.Int8.SaturatingShl(3, 6))
+    assert.Equal(t, 10,         checked.SaturatingAdd(0, 10, 7, 7))
+
+    assert.Panics(t, func() { checked.Int.SaturatingDiv(1, 0) })
+}
+
+func TestSaturatingConvert(t *testing.T) {
+    type Cents int64
+
+    assert.Equal(t, uint16(0),          checked.SaturatingConvert[int64, uint16](-1))
+    assert.Equal(t, uint16(65535),      checked.SaturatingConvert[int64, uint16](65536))
+    assert.Equal(t, uint16(123),        checked.SaturatingConvert[int64, uint16](123))
+    assert.Equal(t, int8(-128),         checked.SaturatingConvert[int, int8](-1000))
+    assert.Equal(t, Cents(math.MaxInt64), checked.SaturatingConvert[uint64, Cents](math.MaxUint64))
+}
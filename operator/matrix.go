@@ -0,0 +1,340 @@
+package operator
+
+// Mat2 is a 2x2 matrix of real numbers, indexed by row then column, such
+// that m[row][col] is the element at that row and column.
+type Mat2[R real] [2][2]R
+
+// Mat3 is a 3x3 matrix of real numbers, indexed by row then column.
+type Mat3[R real] [3][3]R
+
+// Mat4 is a 4x4 matrix of real numbers, indexed by row then column.
+type Mat4[R real] [4][4]R
+
+// Mat2Identity returns the 2x2 identity matrix.
+func Mat2Identity[R real]() Mat2[R] {
+    var m Mat2[R]
+    for i := 0; i < 2; i++ {
+        m[i][i] = 1
+    }
+    return m
+}
+
+// Add returns the matrix m + n.
+func (m Mat2[R]) Add(n Mat2[R]) Mat2[R] {
+    for i := 0; i < 2; i++ {
+        for j := 0; j < 2; j++ {
+            m[i][j] += n[i][j]
+        }
+    }
+    return m
+}
+
+// Sub returns the matrix m - n.
+func (m Mat2[R]) Sub(n Mat2[R]) Mat2[R] {
+    for i := 0; i < 2; i++ {
+        for j := 0; j < 2; j++ {
+            m[i][j] -= n[i][j]
+        }
+    }
+    return m
+}
+
+// Scale returns the matrix m with every element multiplied by the scalar s.
+func (m Mat2[R]) Scale(s R) Mat2[R] {
+    for i := 0; i < 2; i++ {
+        for j := 0; j < 2; j++ {
+            m[i][j] *= s
+        }
+    }
+    return m
+}
+
+// Mul returns the matrix product m × n.
+func (m Mat2[R]) Mul(n Mat2[R]) Mat2[R] {
+    var result Mat2[R]
+    for i := 0; i < 2; i++ {
+        for j := 0; j < 2; j++ {
+            var sum R
+            for k := 0; k < 2; k++ {
+                sum += m[i][k] * n[k][j]
+            }
+            result[i][j] = sum
+        }
+    }
+    return result
+}
+
+// MulVec returns the product m × v, treating v as a column vector.
+func (m Mat2[R]) MulVec(v Vec2[R]) Vec2[R] {
+    var result Vec2[R]
+    for i := 0; i < 2; i++ {
+        result[i] = Vec2[R](m[i]).Dot(v)
+    }
+    return result
+}
+
+// Transpose returns the transpose of m, where each row becomes a column.
+func (m Mat2[R]) Transpose() Mat2[R] {
+    var result Mat2[R]
+    for i := 0; i < 2; i++ {
+        for j := 0; j < 2; j++ {
+            result[j][i] = m[i][j]
+        }
+    }
+    return result
+}
+
+// Mat3Identity returns the 3x3 identity matrix.
+func Mat3Identity[R real]() Mat3[R] {
+    var m Mat3[R]
+    for i := 0; i < 3; i++ {
+        m[i][i] = 1
+    }
+    return m
+}
+
+// Add returns the matrix m + n.
+func (m Mat3[R]) Add(n Mat3[R]) Mat3[R] {
+    for i := 0; i < 3; i++ {
+        for j := 0; j < 3; j++ {
+            m[i][j] += n[i][j]
+        }
+    }
+    return m
+}
+
+// Sub returns the matrix m - n.
+func (m Mat3[R]) Sub(n Mat3[R]) Mat3[R] {
+    for i := 0; i < 3; i++ {
+        for j := 0; j < 3; j++ {
+            m[i][j] -= n[i][j]
+        }
+    }
+    return m
+}
+
+// Scale returns the matrix m with every element multiplied by the scalar s.
+func (m Mat3[R]) Scale(s R) Mat3[R] {
+    for i := 0; i < 3; i++ {
+        for j := 0; j < 3; j++ {
+            m[i][j] *= s
+        }
+    }
+    return m
+}
+
+// Mul returns the matrix product m × n.
+func (m Mat3[R]) Mul(n Mat3[R]) Mat3[R] {
+    var result Mat3[R]
+    for i := 0; i < 3; i++ {
+        for j := 0; j < 3; j++ {
+            var sum R
+            for k := 0; k < 3; k++ {
+                sum += m[i][k] * n[k][j]
+            }
+            result[i][j] = sum
+        }
+    }
+    return result
+}
+
+// MulVec returns the product m × v, treating v as a column vector.
+func (m Mat3[R]) MulVec(v Vec3[R]) Vec3[R] {
+    var result Vec3[R]
+    for i := 0; i < 3; i++ {
+        result[i] = Vec3[R](m[i]).Dot(v)
+    }
+    return result
+}
+
+// Transpose returns the transpose of m, where each row becomes a column.
+func (m Mat3[R]) Transpose() Mat3[R] {
+    var result Mat3[R]
+    for i := 0; i < 3; i++ {
+        for j := 0; j < 3; j++ {
+            result[j][i] = m[i][j]
+        }
+    }
+    return result
+}
+
+// Mat4Identity returns the 4x4 identity matrix.
+func Mat4Identity[R real]() Mat4[R] {
+    var m Mat4[R]
+    for i := 0; i < 4; i++ {
+        m[i][i] = 1
+    }
+    return m
+}
+
+// Add returns the matrix m + n.
+func (m Mat4[R]) Add(n Mat4[R]) Mat4[R] {
+    for i := 0; i < 4; i++ {
+        for j := 0; j < 4; j++ {
+            m[i][j] += n[i][j]
+        }
+    }
+    return m
+}
+
+// Sub returns the matrix m - n.
+func (m Mat4[R]) Sub(n Mat4[R]) Mat4[R] {
+    for i := 0; i < 4; i++ {
+        for j := 0; j < 4; j++ {
+            m[i][j] -= n[i][j]
+        }
+    }
+    return m
+}
+
+// Scale returns the matrix m with every element multiplied by the scalar s.
+func (m Mat4[R]) Scale(s R) Mat4[R] {
+    for i := 0; i < 4; i++ {
+        for j := 0; j < 4; j++ {
+            m[i][j] *= s
+        }
+    }
+    return m
+}
+
+// Mul returns the matrix product m × n.
+func (m Mat4[R]) Mul(n Mat4[R]) Mat4[R] {
+    var result Mat4[R]
+    for i := 0; i < 4; i++ {
+        for j := 0; j < 4; j++ {
+            var sum R
+            for k := 0; k < 4; k++ {
+                sum += m[i][k] * n[k][j]
+            }
+            result[i][j] = sum
+        }
+    }
+    return result
+}
+
+// MulVec returns the product m × v, treating v as a column vector.
+func (m Mat4[R]) MulVec(v Vec4[R]) Vec4[R] {
+    var result Vec4[R]
+    for i := 0; i < 4; i++ {
+        result[i] = Vec4[R](m[i]).Dot(v)
+    }
+    return result
+}
+
+// Transpose returns the transpose of m, where each row becomes a column.
+func (m Mat4[R]) Transpose() Mat4[R] {
+    var result Mat4[R]
+    for i := 0; i < 4; i++ {
+        for j := 0; j < 4; j++ {
+            result[j][i] = m[i][j]
+        }
+    }
+    return result
+}
+
+// Determinant returns the determinant of m.
+func (m Mat2[R]) Determinant() R {
+    return m[0][0] * m[1][1] - m[0][1] * m[1][0]
+}
+
+// Inverse returns the inverse of m, and true, or the zero matrix and false if
+// m is not invertible (i.e. the determinant is zero).
+//
+// The inverse is calculated by division, so is only exact for a matrix of
+// floating point numbers, subject to rounding error, or a matrix of integers
+// with a determinant of 1 or -1.
+func (m Mat2[R]) Inverse() (Mat2[R], bool) {
+    det := m.Determinant()
+    if det == 0 { return Mat2[R]{}, false }
+    return Mat2[R]{
+        { m[1][1] / det, -m[0][1] / det},
+        {-m[1][0] / det,  m[0][0] / det},
+    }, true
+}
+
+// Determinant returns the determinant of m.
+func (m Mat3[R]) Determinant() R {
+    return m[0][0] * (m[1][1] * m[2][2] - m[1][2] * m[2][1]) -
+           m[0][1] * (m[1][0] * m[2][2] - m[1][2] * m[2][0]) +
+           m[0][2] * (m[1][0] * m[2][1] - m[1][1] * m[2][0])
+}
+
+// Inverse is like [Mat2.Inverse], but for a 3x3 matrix.
+func (m Mat3[R]) Inverse() (Mat3[R], bool) {
+    det := m.Determinant()
+    if det == 0 { return Mat3[R]{}, false }
+
+    // adjugate (transposed matrix of cofactors) divided by determinant
+    return Mat3[R]{
+        {
+            (m[1][1] * m[2][2] - m[1][2] * m[2][1]) / det,
+            (m[0][2] * m[2][1] - m[0][1] * m[2][2]) / det,
+            (m[0][1] * m[1][2] - m[0][2] * m[1][1]) / det,
+        },
+        {
+            (m[1][2] * m[2][0] - m[1][0] * m[2][2]) / det,
+            (m[0][0] * m[2][2] - m[0][2] * m[2][0]) / det,
+            (m[0][2] * m[1][0] - m[0][0] * m[1][2]) / det,
+        },
+        {
+            (m[1][0] * m[2][1] - m[1][1] * m[2][0]) / det,
+            (m[0][1] * m[2][0] - m[0][0] * m[2][1]) / det,
+            (m[0][0] * m[1][1] - m[0][1] * m[1][0]) / det,
+        },
+    }, true
+}
+
+// minors4 returns the 2x2 determinants of the top two rows (s) and the bottom
+// two rows (c) of m, used to calculate its determinant and inverse by the
+// Laplace expansion theorem.
+func minors4[R real](m Mat4[R]) (s [6]R, c [6]R) {
+    s[0] = m[0][0] * m[1][1] - m[1][0] * m[0][1]
+    s[1] = m[0][0] * m[1][2] - m[1][0] * m[0][2]
+    s[2] = m[0][0] * m[1][3] - m[1][0] * m[0][3]
+    s[3] = m[0][1] * m[1][2] - m[1][1] * m[0][2]
+    s[4] = m[0][1] * m[1][3] - m[1][1] * m[0][3]
+    s[5] = m[0][2] * m[1][3] - m[1][2] * m[0][3]
+
+    c[5] = m[2][2] * m[3][3] - m[3][2] * m[2][3]
+    c[4] = m[2][1] * m[3][3] - m[3][1] * m[2][3]
+    c[3] = m[2][1] * m[3][2] - m[3][1] * m[2][2]
+    c[2] = m[2][0] * m[3][3] - m[3][0] * m[2][3]
+    c[1] = m[2][0] * m[3][2] - m[3][0] * m[2][2]
+    c[0] = m[2][0] * m[3][1] - m[3][0] * m[2][1]
+    return s, c
+}
+
+// Determinant returns the determinant of m.
+func (m Mat4[R]) Determinant() R {
+    s, c := minors4(m)
+    return s[0] * c[5] - s[1] * c[4] + s[2] * c[3] + s[3] * c[2] - s[4] * c[1] + s[5] * c[0]
+}
+
+// Inverse is like [Mat2.Inverse], but for a 4x4 matrix.
+func (m Mat4[R]) Inverse() (Mat4[R], bool) {
+    s, c := minors4(m)
+    det := s[0] * c[5] - s[1] * c[4] + s[2] * c[3] + s[3] * c[2] - s[4] * c[1] + s[5] * c[0]
+    if det == 0 { return Mat4[R]{}, false }
+
+    var r Mat4[R]
+    r[0][0] = ( m[1][1] * c[5] - m[1][2] * c[4] + m[1][3] * c[3]) / det
+    r[0][1] = (-m[0][1] * c[5] + m[0][2] * c[4] - m[0][3] * c[3]) / det
+    r[0][2] = ( m[3][1] * s[5] - m[3][2] * s[4] + m[3][3] * s[3]) / det
+    r[0][3] = (-m[2][1] * s[5] + m[2][2] * s[4] - m[2][3] * s[3]) / det
+
+    r[1][0] = (-m[1][0] * c[5] + m[1][2] * c[2] - m[1][3] * c[1]) / det
+    r[1][1] = ( m[0][0] * c[5] - m[0][2] * c[2] + m[0][3] * c[1]) / det
+    r[1][2] = (-m[3][0] * s[5] + m[3][2] * s[2] - m[3][3] * s[1]) / det
+    r[1][3] = ( m[2][0] * s[5] - m[2][2] * s[2] + m[2][3] * s[1]) / det
+
+    r[2][0] = ( m[1][0] * c[4] - m[1][1] * c[2] + m[1][3] * c[0]) / det
+    r[2][1] = (-m[0][0] * c[4] + m[0][1] * c[2] - m[0][3] * c[0]) / det
+    r[2][2] = ( m[3][0] * s[4] - m[3][1] * s[2] + m[3][3] * s[0]) / det
+    r[2][3] = (-m[2][0] * s[4] + m[2][1] * s[2] - m[2][3] * s[0]) / det
+
+    r[3][0] = (-m[1][0] * c[3] + m[1][1] * c[1] - m[1][2] * c[0]) / det
+    r[3][1] = ( m[0][0] * c[3] - m[0][1] * c[1] + m[0][2] * c[0]) / det
+    r[3][2] = (-m[3][0] * s[3] + m[3][1] * s[1] - m[3][2] * s[0]) / det
+    r[3][3] = ( m[2][0] * s[3] - m[2][1] * s[1] + m[2][2] * s[0]) / det
+    return r, true
+}
@@ -0,0 +1,83 @@
+package operator_test
+
+import (
+    "math"
+    "testing"
+
+    "github.com/stretchr/testify/assert"
+    "github.com/tawesoft/golib/v2/operator"
+)
+
+func TestVec(t *testing.T) {
+    a := operator.Vec3[int]{1, 2, 3}
+    b := operator.Vec3[int]{4, 5, 6}
+
+    assert.Equal(t, operator.Vec3[int]{5, 7, 9}, a.Add(b))
+    assert.Equal(t, operator.Vec3[int]{-3, -3, -3}, a.Sub(b))
+    assert.Equal(t, operator.Vec3[int]{2, 4, 6}, a.Scale(2))
+    assert.Equal(t, 32, a.Dot(b))
+    assert.Equal(t, operator.Vec3[int]{-3, 6, -3}, a.Cross(b))
+    assert.Equal(t, operator.Vec3[int]{0, 0, 1}, operator.Vec3[int]{1, 0, 0}.Cross(operator.Vec3[int]{0, 1, 0}))
+
+    assert.Equal(t, 1, operator.Vec2[int]{1, 0}.Cross(operator.Vec2[int]{0, 1}))
+    assert.Equal(t, 70, operator.Vec4[int]{1, 2, 3, 4}.Dot(operator.Vec4[int]{5, 6, 7, 8}))
+}
+
+func TestMat(t *testing.T) {
+    m := operator.Mat3[int]{
+        {1, 2, 3},
+        {4, 5, 6},
+        {7, 8, 10},
+    }
+
+    assert.Equal(t, -3, m.Determinant())
+    assert.Equal(t, operator.Mat3[int]{{1, 4, 7}, {2, 5, 8}, {3, 6, 10}}, m.Transpose())
+    assert.Equal(t, m, m.Mul(operator.Mat3Identity[int]()))
+    assert.Equal(t, operator.Vec3[int]{14, 32, 53}, m.MulVec(operator.Vec3[int]{1, 2, 3}))
+    assert.Equal(t, operator.Mat3[int]{{30, 36, 45}, {66, 81, 102}, {109, 134, 169}}, m.Mul(m))
+    assert.Equal(t, operator.Mat3[int]{{2, 4, 6}, {8, 10, 12}, {14, 16, 20}}, m.Add(m))
+    assert.Equal(t, m.Scale(3), m.Add(m).Add(m))
+    assert.Equal(t, operator.Mat3[int]{}, m.Sub(m))
+
+    assert.Equal(t, -2, operator.Mat2[int]{{1, 2}, {3, 4}}.Determinant())
+    assert.Equal(t, 24, operator.Mat4[int]{{2, 0, 0, 0}, {0, 3, 0, 0}, {0, 0, 4, 0}, {0, 0, 0, 1}}.Determinant())
+    assert.Equal(t, -376, operator.Mat4[int]{{1, 3, 5, 9}, {1, 3, 1, 7}, {4, 3, 9, 7}, {5, 2, 0, 9}}.Determinant())
+
+    _, ok := operator.Mat3[float64]{{1, 2, 3}, {2, 4, 6}, {0, 1, 0}}.Inverse()
+    assert.False(t, ok)
+}
+
+func assertIdentity(t *testing.T, m [][]float64) {
+    t.Helper()
+    for i := range m {
+        for j := range m[i] {
+            expected := 0.0
+            if i == j { expected = 1.0 }
+            if math.Abs(m[i][j] - expected) > 1e-9 {
+                t.Errorf("not identity at [%d][%d]: %v", i, j, m)
+                return
+            }
+        }
+    }
+}
+
+func TestMat_Inverse(t *testing.T) {
+    m2 := operator.Mat2[float64]{{4, 7}, {2, 6}}
+    i2, ok := m2.Inverse()
+    assert.True(t, ok)
+    p2 := m2.Mul(i2)
+    assertIdentity(t, [][]float64{p2[0][:], p2[1][:]})
+
+    m3 := operator.Mat3[float64]{{1, 2, 3}, {0, 1, 4}, {5, 6, 0}}
+    i3, ok := m3.Inverse()
+    assert.True(t, ok)
+    assert.Equal(t, operator.Mat3[float64]{{-24, 18, 5}, {20, -15, -4}, {-5, 4, 1}}, i3)
+
+    m4 := operator.Mat4[float64]{{1, 3, 5, 9}, {1, 3, 1, 7}, {4, 3, 9, 7}, {5, 2, 0, 9}}
+    i4, ok := m4.Inverse()
+    assert.True(t, ok)
+    p4 := m4.Mul(i4)
+    assertIdentity(t, [][]float64{p4[0][:], p4[1][:], p4[2][:], p4[3][:]})
+    p4 = i4.Mul(m4)
+    assertIdentity(t, [][]float64{p4[0][:], p4[1][:], p4[2][:], p4[3][:]})
+}
@@ -0,0 +1,87 @@
+package operator
+
+// Vec2 is a vector of two real numbers.
+type Vec2[R real] [2]R
+
+// Vec3 is a vector of three real numbers.
+type Vec3[R real] [3]R
+
+// Vec4 is a vector of four real numbers.
+type Vec4[R real] [4]R
+
+// Add returns the vector v + w.
+func (v Vec2[R]) Add(w Vec2[R]) Vec2[R] {
+    return Vec2[R]{v[0] + w[0], v[1] + w[1]}
+}
+
+// Sub returns the vector v - w.
+func (v Vec2[R]) Sub(w Vec2[R]) Vec2[R] {
+    return Vec2[R]{v[0] - w[0], v[1] - w[1]}
+}
+
+// Scale returns the vector v multiplied by the scalar s.
+func (v Vec2[R]) Scale(s R) Vec2[R] {
+    return Vec2[R]{v[0] * s, v[1] * s}
+}
+
+// Dot returns the dot product of v and w.
+func (v Vec2[R]) Dot(w Vec2[R]) R {
+    return v[0] * w[0] + v[1] * w[1]
+}
+
+// Cross returns the two-dimensional cross product of v and w i.e. the z
+// component of the cross product of the vectors extended to three dimensions
+// with a z component of zero. Its sign gives the direction of rotation from
+// v to w.
+func (v Vec2[R]) Cross(w Vec2[R]) R {
+    return v[0] * w[1] - v[1] * w[0]
+}
+
+// Add returns the vector v + w.
+func (v Vec3[R]) Add(w Vec3[R]) Vec3[R] {
+    return Vec3[R]{v[0] + w[0], v[1] + w[1], v[2] + w[2]}
+}
+
+// Sub returns the vector v - w.
+func (v Vec3[R]) Sub(w Vec3[R]) Vec3[R] {
+    return Vec3[R]{v[0] - w[0], v[1] - w[1], v[2] - w[2]}
+}
+
+// Scale returns the vector v multiplied by the scalar s.
+func (v Vec3[R]) Scale(s R) Vec3[R] {
+    return Vec3[R]{v[0] * s, v[1] * s, v[2] * s}
+}
+
+// Dot returns the dot product of v and w.
+func (v Vec3[R]) Dot(w Vec3[R]) R {
+    return v[0] * w[0] + v[1] * w[1] + v[2] * w[2]
+}
+
+// Cross returns the cross product of v and w.
+func (v Vec3[R]) Cross(w Vec3[R]) Vec3[R] {
+    return Vec3[R]{
+        v[1] * w[2] - v[2] * w[1],
+        v[2] * w[0] - v[0] * w[2],
+        v[0] * w[1] - v[1] * w[0],
+    }
+}
+
+// Add returns the vector v + w.
+func (v Vec4[R]) Add(w Vec4[R]) Vec4[R] {
+    return Vec4[R]{v[0] + w[0], v[1] + w[1], v[2] + w[2], v[3] + w[3]}
+}
+
+// Sub returns the vector v - w.
+func (v Vec4[R]) Sub(w Vec4[R]) Vec4[R] {
+    return Vec4[R]{v[0] - w[0], v[1] - w[1], v[2] - w[2], v[3] - w[3]}
+}
+
+// Scale returns the vector v multiplied by the scalar s.
+func (v Vec4[R]) Scale(s R) Vec4[R] {
+    return Vec4[R]{v[0] * s, v[1] * s, v[2] * s, v[3] * s}
+}
+
+// Dot returns the dot product of v and w.
+func (v Vec4[R]) Dot(w Vec4[R]) R {
+    return v[0] * w[0] + v[1] * w[1] + v[2] * w[2] + v[3] * w[3]
+}
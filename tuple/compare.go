@@ -0,0 +1,222 @@
+package tuple
+
+import (
+    "github.com/tawesoft/golib/v2/operator"
+    "golang.org/x/exp/constraints"
+)
+
+// Compare2 compares two tuples lexicographically, first by field A, then,
+// if equal, by field B. It returns -1 if x < y, 0 if x == y, or +1 if x > y.
+func Compare2[A constraints.Ordered, B constraints.Ordered](x T2[A, B], y T2[A, B]) int {
+    if c := operator.Cmp(x.A, y.A); c != 0 { return c }
+    return operator.Cmp(x.B, y.B)
+}
+
+// Less2 returns true iff x < y, compared lexicographically as in
+// [Compare2]. For example, to sort a slice of tuples:
+//
+//     sort.Slice(xs, func(i, j int) bool { return tuple.Less2(xs[i], xs[j]) })
+func Less2[A constraints.Ordered, B constraints.Ordered](x T2[A, B], y T2[A, B]) bool {
+    return Compare2(x, y) < 0
+}
+
+// Compare3 is like [Compare2], but for a [T3].
+func Compare3[
+    A constraints.Ordered,
+    B constraints.Ordered,
+    C constraints.Ordered,
+](
+    x T3[A, B, C],
+    y T3[A, B, C],
+) int {
+    if c := operator.Cmp(x.A, y.A); c != 0 { return c }
+    if c := operator.Cmp(x.B, y.B); c != 0 { return c }
+    return operator.Cmp(x.C, y.C)
+}
+
+// Less3 is like [Less2], but for a [T3].
+func Less3[
+    A constraints.Ordered,
+    B constraints.Ordered,
+    C constraints.Ordered,
+](
+    x T3[A, B, C],
+    y T3[A, B, C],
+) bool {
+    return Compare3(x, y) < 0
+}
+
+// Compare4 is like [Compare2], but for a [T4].
+func Compare4[
+    A constraints.Ordered,
+    B constraints.Ordered,
+    C constraints.Ordered,
+    D constraints.Ordered,
+](
+    x T4[A, B, C, D],
+    y T4[A, B, C, D],
+) int {
+    if c := operator.Cmp(x.A, y.A); c != 0 { return c }
+    if c := operator.Cmp(x.B, y.B); c != 0 { return c }
+    if c := operator.Cmp(x.C, y.C); c != 0 { return c }
+    return operator.Cmp(x.D, y.D)
+}
+
+// Less4 is like [Less2], but for a [T4].
+func Less4[
+    A constraints.Ordered,
+    B constraints.Ordered,
+    C constraints.Ordered,
+    D constraints.Ordered,
+](
+    x T4[A, B, C, D],
+    y T4[A, B, C, D],
+) bool {
+    return Compare4(x, y) < 0
+}
+
+// Compare5 is like [Compare2], but for a [T5].
+func Compare5[
+    A constraints.Ordered,
+    B constraints.Ordered,
+    C constraints.Ordered,
+    D constraints.Ordered,
+    E constraints.Ordered,
+](
+    x T5[A, B, C, D, E],
+    y T5[A, B, C, D, E],
+) int {
+    if c := operator.Cmp(x.A, y.A); c != 0 { return c }
+    if c := operator.Cmp(x.B, y.B); c != 0 { return c }
+    if c := operator.Cmp(x.C, y.C); c != 0 { return c }
+    if c := operator.Cmp(x.D, y.D); c != 0 { return c }
+    return operator.Cmp(x.E, y.E)
+}
+
+// Less5 is like [Less2], but for a [T5].
+func Less5[
+    A constraints.Ordered,
+    B constraints.Ordered,
+    C constraints.Ordered,
+    D constraints.Ordered,
+    E constraints.Ordered,
+](
+    x T5[A, B, C, D, E],
+    y T5[A, B, C, D, E],
+) bool {
+    return Compare5(x, y) < 0
+}
+
+// Compare6 is like [Compare2], but for a [T6].
+func Compare6[
+    A constraints.Ordered,
+    B constraints.Ordered,
+    C constraints.Ordered,
+    D constraints.Ordered,
+    E constraints.Ordered,
+    F constraints.Ordered,
+](
+    x T6[A, B, C, D, E, F],
+    y T6[A, B, C, D, E, F],
+) int {
+    if c := operator.Cmp(x.A, y.A); c != 0 { return c }
+    if c := operator.Cmp(x.B, y.B); c != 0 { return c }
+    if c := operator.Cmp(x.C, y.C); c != 0 { return c }
+    if c := operator.Cmp(x.D, y.D); c != 0 { return c }
+    if c := operator.Cmp(x.E, y.E); c != 0 { return c }
+    return operator.Cmp(x.F, y.F)
+}
+
+// Less6 is like [Less2], but for a [T6].
+func Less6[
+    A constraints.Ordered,
+    B constraints.Ordered,
+    C constraints.Ordered,
+    D constraints.Ordered,
+    E constraints.Ordered,
+    F constraints.Ordered,
+](
+    x T6[A, B, C, D, E, F],
+    y T6[A, B, C, D, E, F],
+) bool {
+    return Compare6(x, y) < 0
+}
+
+// Compare7 is like [Compare2], but for a [T7].
+func Compare7[
+    A constraints.Ordered,
+    B constraints.Ordered,
+    C constraints.Ordered,
+    D constraints.Ordered,
+    E constraints.Ordered,
+    F constraints.Ordered,
+    G constraints.Ordered,
+](
+    x T7[A, B, C, D, E, F, G],
+    y T7[A, B, C, D, E, F, G],
+) int {
+    if c := operator.Cmp(x.A, y.A); c != 0 { return c }
+    if c := operator.Cmp(x.B, y.B); c != 0 { return c }
+    if c := operator.Cmp(x.C, y.C); c != 0 { return c }
+    if c := operator.Cmp(x.D, y.D); c != 0 { return c }
+    if c := operator.Cmp(x.E, y.E); c != 0 { return c }
+    if c := operator.Cmp(x.F, y.F); c != 0 { return c }
+    return operator.Cmp(x.G, y.G)
+}
+
+// Less7 is like [Less2], but for a [T7].
+func Less7[
+    A constraints.Ordered,
+    B constraints.Ordered,
+    C constraints.Ordered,
+    D constraints.Ordered,
+    E constraints.Ordered,
+    F constraints.Ordered,
+    G constraints.Ordered,
+](
+    x T7[A, B, C, D, E, F, G],
+    y T7[A, B, C, D, E, F, G],
+) bool {
+    return Compare7(x, y) < 0
+}
+
+// Compare8 is like [Compare2], but for a [T8].
+func Compare8[
+    A constraints.Ordered,
+    B constraints.Ordered,
+    C constraints.Ordered,
+    D constraints.Ordered,
+    E constraints.Ordered,
+    F constraints.Ordered,
+    G constraints.Ordered,
+    H constraints.Ordered,
+](
+    x T8[A, B, C, D, E, F, G, H],
+    y T8[A, B, C, D, E, F, G, H],
+) int {
+    if c := operator.Cmp(x.A, y.A); c != 0 { return c }
+    if c := operator.Cmp(x.B, y.B); c != 0 { return c }
+    if c := operator.Cmp(x.C, y.C); c != 0 { return c }
+    if c := operator.Cmp(x.D, y.D); c != 0 { return c }
+    if c := operator.Cmp(x.E, y.E); c != 0 { return c }
+    if c := operator.Cmp(x.F, y.F); c != 0 { return c }
+    if c := operator.Cmp(x.G, y.G); c != 0 { return c }
+    return operator.Cmp(x.H, y.H)
+}
+
+// Less8 is like [Less2], but for a [T8].
+func Less8[
+    A constraints.Ordered,
+    B constraints.Ordered,
+    C constraints.Ordered,
+    D constraints.Ordered,
+    E constraints.Ordered,
+    F constraints.Ordered,
+    G constraints.Ordered,
+    H constraints.Ordered,
+](
+    x T8[A, B, C, D, E, F, G, H],
+    y T8[A, B, C, D, E, F, G, H],
+) bool {
+    return Compare8(x, y) < 0
+}
@@ -0,0 +1,97 @@
+package tuple
+
+import (
+    "encoding/json"
+    "fmt"
+)
+
+// unmarshal decodes a JSON array into the values pointed to by dests, which
+// must have the same length as the array. A JSON null is a no-op.
+func unmarshal(name string, data []byte, dests ... any) error {
+    var raw []json.RawMessage
+    if err := json.Unmarshal(data, &raw); err != nil { return err }
+    if raw == nil { return nil } // null
+    if len(raw) != len(dests) {
+        return fmt.Errorf("tuple: cannot unmarshal JSON array of length %d into a %s", len(raw), name)
+    }
+
+    for i, dest := range dests {
+        if err := json.Unmarshal(raw[i], dest); err != nil { return err }
+    }
+    return nil
+}
+
+// MarshalJSON implements the [json.Marshaler] interface. A tuple is encoded
+// as a JSON array of its fields, in order. For example, ToT2(1, "one")
+// encodes as [1, "one"].
+func (t T2[A, B]) MarshalJSON() ([]byte, error) {
+    return json.Marshal([]any{t.A, t.B})
+}
+
+// UnmarshalJSON implements the [json.Unmarshaler] interface, decoding a JSON
+// array with exactly one element for each field, in order. If decoding fails,
+// the tuple may be partially modified. By convention, a JSON null leaves the
+// tuple unchanged, without error.
+func (t *T2[A, B]) UnmarshalJSON(data []byte) error {
+    return unmarshal("T2", data, &t.A, &t.B)
+}
+
+// MarshalJSON is like [T2.MarshalJSON], but for a T3.
+func (t T3[A, B, C]) MarshalJSON() ([]byte, error) {
+    return json.Marshal([]any{t.A, t.B, t.C})
+}
+
+// UnmarshalJSON is like [T2.UnmarshalJSON], but for a T3.
+func (t *T3[A, B, C]) UnmarshalJSON(data []byte) error {
+    return unmarshal("T3", data, &t.A, &t.B, &t.C)
+}
+
+// MarshalJSON is like [T2.MarshalJSON], but for a T4.
+func (t T4[A, B, C, D]) MarshalJSON() ([]byte, error) {
+    return json.Marshal([]any{t.A, t.B, t.C, t.D})
+}
+
+// UnmarshalJSON is like [T2.UnmarshalJSON], but for a T4.
+func (t *T4[A, B, C, D]) UnmarshalJSON(data []byte) error {
+    return unmarshal("T4", data, &t.A, &t.B, &t.C, &t.D)
+}
+
+// MarshalJSON is like [T2.MarshalJSON], but for a T5.
+func (t T5[A, B, C, D, E]) MarshalJSON() ([]byte, error) {
+    return json.Marshal([]any{t.A, t.B, t.C, t.D, t.E})
+}
+
+// UnmarshalJSON is like [T2.UnmarshalJSON], but for a T5.
+func (t *T5[A, B, C, D, E]) UnmarshalJSON(data []byte) error {
+    return unmarshal("T5", data, &t.A, &t.B, &t.C, &t.D, &t.E)
+}
+
+// MarshalJSON is like [T2.MarshalJSON], but for a T6.
+func (t T6[A, B, C, D, E, F]) MarshalJSON() ([]byte, error) {
+    return json.Marshal([]any{t.A, t.B, t.C, t.D, t.E, t.F})
+}
+
+// UnmarshalJSON is like [T2.UnmarshalJSON], but for a T6.
+func (t *T6[A, B, C, D, E, F]) UnmarshalJSON(data []byte) error {
+    return unmarshal("T6", data, &t.A, &t.B, &t.C, &t.D, &t.E, &t.F)
+}
+
+// MarshalJSON is like [T2.MarshalJSON], but for a T7.
+func (t T7[A, B, C, D, E, F, G]) MarshalJSON() ([]byte, error) {
+    return json.Marshal([]any{t.A, t.B, t.C, t.D, t.E, t.F, t.G})
+}
+
+// UnmarshalJSON is like [T2.UnmarshalJSON], but for a T7.
+func (t *T7[A, B, C, D, E, F, G]) UnmarshalJSON(data []byte) error {
+    return unmarshal("T7", data, &t.A, &t.B, &t.C, &t.D, &t.E, &t.F, &t.G)
+}
+
+// MarshalJSON is like [T2.MarshalJSON], but for a T8.
+func (t T8[A, B, C, D, E, F, G, H]) MarshalJSON() ([]byte, error) {
+    return json.Marshal([]any{t.A, t.B, t.C, t.D, t.E, t.F, t.G, t.H})
+}
+
+// UnmarshalJSON is like [T2.UnmarshalJSON], but for a T8.
+func (t *T8[A, B, C, D, E, F, G, H]) UnmarshalJSON(data []byte) error {
+    return unmarshal("T8", data, &t.A, &t.B, &t.C, &t.D, &t.E, &t.F, &t.G, &t.H)
+}
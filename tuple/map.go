@@ -0,0 +1,135 @@
+package tuple
+
+// Map2 returns a function that maps a [T2] to a new [T2], by applying
+// function fa to field A, and function fb to field B.
+func Map2[A any, B any, R any, S any](
+    fa func(A) R,
+    fb func(B) S,
+) func(T2[A, B]) T2[R, S] {
+    return func(t T2[A, B]) T2[R, S] {
+        return T2[R, S]{
+            A: fa(t.A),
+            B: fb(t.B),
+        }
+    }
+}
+
+// Map3 is like [Map2], but for a [T3].
+func Map3[A any, B any, C any, R any, S any, T any](
+    fa func(A) R,
+    fb func(B) S,
+    fc func(C) T,
+) func(T3[A, B, C]) T3[R, S, T] {
+    return func(t T3[A, B, C]) T3[R, S, T] {
+        return T3[R, S, T]{
+            A: fa(t.A),
+            B: fb(t.B),
+            C: fc(t.C),
+        }
+    }
+}
+
+// Map4 is like [Map2], but for a [T4].
+func Map4[A any, B any, C any, D any, R any, S any, T any, U any](
+    fa func(A) R,
+    fb func(B) S,
+    fc func(C) T,
+    fd func(D) U,
+) func(T4[A, B, C, D]) T4[R, S, T, U] {
+    return func(t T4[A, B, C, D]) T4[R, S, T, U] {
+        return T4[R, S, T, U]{
+            A: fa(t.A),
+            B: fb(t.B),
+            C: fc(t.C),
+            D: fd(t.D),
+        }
+    }
+}
+
+// Map5 is like [Map2], but for a [T5].
+func Map5[A any, B any, C any, D any, E any, R any, S any, T any, U any, V any](
+    fa func(A) R,
+    fb func(B) S,
+    fc func(C) T,
+    fd func(D) U,
+    fe func(E) V,
+) func(T5[A, B, C, D, E]) T5[R, S, T, U, V] {
+    return func(t T5[A, B, C, D, E]) T5[R, S, T, U, V] {
+        return T5[R, S, T, U, V]{
+            A: fa(t.A),
+            B: fb(t.B),
+            C: fc(t.C),
+            D: fd(t.D),
+            E: fe(t.E),
+        }
+    }
+}
+
+// Map6 is like [Map2], but for a [T6].
+func Map6[A any, B any, C any, D any, E any, F any, R any, S any, T any, U any, V any, W any](
+    fa func(A) R,
+    fb func(B) S,
+    fc func(C) T,
+    fd func(D) U,
+    fe func(E) V,
+    ff func(F) W,
+) func(T6[A, B, C, D, E, F]) T6[R, S, T, U, V, W] {
+    return func(t T6[A, B, C, D, E, F]) T6[R, S, T, U, V, W] {
+        return T6[R, S, T, U, V, W]{
+            A: fa(t.A),
+            B: fb(t.B),
+            C: fc(t.C),
+            D: fd(t.D),
+            E: fe(t.E),
+            F: ff(t.F),
+        }
+    }
+}
+
+// Map7 is like [Map2], but for a [T7].
+func Map7[A any, B any, C any, D any, E any, F any, G any, R any, S any, T any, U any, V any, W any, X any](
+    fa func(A) R,
+    fb func(B) S,
+    fc func(C) T,
+    fd func(D) U,
+    fe func(E) V,
+    ff func(F) W,
+    fg func(G) X,
+) func(T7[A, B, C, D, E, F, G]) T7[R, S, T, U, V, W, X] {
+    return func(t T7[A, B, C, D, E, F, G]) T7[R, S, T, U, V, W, X] {
+        return T7[R, S, T, U, V, W, X]{
+            A: fa(t.A),
+            B: fb(t.B),
+            C: fc(t.C),
+            D: fd(t.D),
+            E: fe(t.E),
+            F: ff(t.F),
+            G: fg(t.G),
+        }
+    }
+}
+
+// Map8 is like [Map2], but for a [T8].
+func Map8[A any, B any, C any, D any, E any, F any, G any, H any, R any, S any, T any, U any, V any, W any, X any, Y any](
+    fa func(A) R,
+    fb func(B) S,
+    fc func(C) T,
+    fd func(D) U,
+    fe func(E) V,
+    ff func(F) W,
+    fg func(G) X,
+    fh func(H) Y,
+) func(T8[A, B, C, D, E, F, G, H]) T8[R, S, T, U, V, W, X, Y] {
+    return func(t T8[A, B, C, D, E, F, G, H]) T8[R, S, T, U, V, W, X, Y] {
+        return T8[R, S, T, U, V, W, X, Y]{
+            A: fa(t.A),
+            B: fb(t.B),
+            C: fc(t.C),
+            D: fd(t.D),
+            E: fe(t.E),
+            F: ff(t.F),
+            G: fg(t.G),
+            H: fh(t.H),
+        }
+    }
+}
@@ -0,0 +1,96 @@
+package tuple_test
+
+import (
+    "encoding/json"
+    "sort"
+    "strconv"
+    "testing"
+
+    "github.com/stretchr/testify/assert"
+    "github.com/tawesoft/golib/v2/iter"
+    "github.com/tawesoft/golib/v2/tuple"
+)
+
+func TestJSON(t *testing.T) {
+    t2 := tuple.ToT2(1, "one")
+    data, err := json.Marshal(t2)
+    assert.NoError(t, err)
+    assert.Equal(t, `[1,"one"]`, string(data))
+
+    var u2 tuple.T2[int, string]
+    assert.NoError(t, json.Unmarshal(data, &u2))
+    assert.Equal(t, t2, u2)
+
+    t8 := tuple.ToT8(1, "b", true, 4.5, []int{5}, map[string]int{"f": 6}, 'g', uint8(8))
+    data, err = json.Marshal(t8)
+    assert.NoError(t, err)
+    assert.Equal(t, `[1,"b",true,4.5,[5],{"f":6},103,8]`, string(data))
+
+    var u8 tuple.T8[int, string, bool, float64, []int, map[string]int, rune, uint8]
+    assert.NoError(t, json.Unmarshal(data, &u8))
+    assert.Equal(t, t8, u8)
+
+    assert.Error(t, json.Unmarshal([]byte(`[1]`), &u2))
+    assert.Error(t, json.Unmarshal([]byte(`[1, "one", 3]`), &u2))
+    assert.Error(t, json.Unmarshal([]byte(`["1", "one"]`), &u2))
+    assert.Error(t, json.Unmarshal([]byte(`{"A": 1, "B": "one"}`), &u2))
+
+    // null is a no-op
+    assert.NoError(t, json.Unmarshal([]byte(`null`), &u2))
+    assert.Equal(t, t2, u2)
+
+    var record struct {
+        Pair tuple.T2[int, string] `json:"pair"`
+    }
+    record.Pair = t2
+    assert.NoError(t, json.Unmarshal([]byte(`{"pair": null}`), &record))
+    assert.Equal(t, t2, record.Pair)
+}
+
+func TestZip(t *testing.T) {
+    zipped := tuple.Zip3([]int{1, 2, 3}, []string{"a", "b"}, []bool{true, false, true})
+    assert.Equal(t, []tuple.T3[int, string, bool]{
+        tuple.ToT3(1, "a", true),
+        tuple.ToT3(2, "b", false),
+    }, zipped)
+
+    as, bs, cs := tuple.Unzip3(zipped)
+    assert.Equal(t, []int{1, 2}, as)
+    assert.Equal(t, []string{"a", "b"}, bs)
+    assert.Equal(t, []bool{true, false}, cs)
+
+    it := tuple.ZipIter2(iter.Counter(0, 1), iter.FromSlice([]string{"x", "y"}))
+    assert.Equal(t, []tuple.T2[int, string]{
+        tuple.ToT2(0, "x"),
+        tuple.ToT2(1, "y"),
+    }, iter.ToSlice(it))
+}
+
+func TestMap(t *testing.T) {
+    f := tuple.Map2(strconv.Itoa, func(s string) int { return len(s) })
+    assert.Equal(t, tuple.ToT2("5", 3), f(tuple.ToT2(5, "abc")))
+}
+
+func TestCompare(t *testing.T) {
+    xs := []tuple.T3[string, int, float64]{
+        tuple.ToT3("b", 1, 0.5),
+        tuple.ToT3("a", 2, 0.5),
+        tuple.ToT3("a", 1, 0.75),
+        tuple.ToT3("a", 1, 0.25),
+    }
+    sort.Slice(xs, func(i, j int) bool { return tuple.Less3(xs[i], xs[j]) })
+
+    assert.Equal(t, []tuple.T3[string, int, float64]{
+        tuple.ToT3("a", 1, 0.25),
+        tuple.ToT3("a", 1, 0.75),
+        tuple.ToT3("a", 2, 0.5),
+        tuple.ToT3("b", 1, 0.5),
+    }, xs)
+
+    assert.Equal(t, 0, tuple.Compare2(tuple.ToT2(1, 2), tuple.ToT2(1, 2)))
+    assert.Equal(t, 1, tuple.Compare2(tuple.ToT2(1, 3), tuple.ToT2(1, 2)))
+    assert.Equal(t, -1, tuple.Compare8(
+        tuple.ToT8(1, 1, 1, 1, 1, 1, 1, 1),
+        tuple.ToT8(1, 1, 1, 1, 1, 1, 1, 2),
+    ))
+}
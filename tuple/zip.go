@@ -0,0 +1,127 @@
+package tuple
+
+import (
+    "github.com/tawesoft/golib/v2/iter"
+)
+
+// Zip2 returns a new slice of tuples, where each tuple contains the values
+// at the same index in each input slice. The length of the result is the
+// length of the shortest input slice.
+func Zip2[A any, B any](as []A, bs []B) []T2[A, B] {
+    n := len(as)
+    if len(bs) < n { n = len(bs) }
+    result := make([]T2[A, B], 0, n)
+    for i := 0; i < n; i++ {
+        result = append(result, T2[A, B]{A: as[i], B: bs[i]})
+    }
+    return result
+}
+
+// ZipIter2 returns an iterator that produces tuples, where each tuple
+// contains the next value produced by each input iterator. The returned
+// iterator is exhausted when any input iterator is exhausted.
+func ZipIter2[A any, B any](as iter.It[A], bs iter.It[B]) iter.It[T2[A, B]] {
+    return func() (T2[A, B], bool) {
+        var zero T2[A, B]
+        a, ok := as()
+        if !ok { return zero, false }
+        b, ok := bs()
+        if !ok { return zero, false }
+        return T2[A, B]{A: a, B: b}, true
+    }
+}
+
+// Unzip2 is the inverse of [Zip2]. It returns a new slice for each field of
+// the input tuples, containing the value of that field for each tuple.
+func Unzip2[A any, B any](ts []T2[A, B]) ([]A, []B) {
+    as := make([]A, 0, len(ts))
+    bs := make([]B, 0, len(ts))
+    for _, t := range ts {
+        as = append(as, t.A)
+        bs = append(bs, t.B)
+    }
+    return as, bs
+}
+
+// Zip3 is like [Zip2], but for three slices and a [T3].
+func Zip3[A any, B any, C any](as []A, bs []B, cs []C) []T3[A, B, C] {
+    n := len(as)
+    if len(bs) < n { n = len(bs) }
+    if len(cs) < n { n = len(cs) }
+    result := make([]T3[A, B, C], 0, n)
+    for i := 0; i < n; i++ {
+        result = append(result, T3[A, B, C]{A: as[i], B: bs[i], C: cs[i]})
+    }
+    return result
+}
+
+// ZipIter3 is like [ZipIter2], but for three iterators and a [T3].
+func ZipIter3[A any, B any, C any](as iter.It[A], bs iter.It[B], cs iter.It[C]) iter.It[T3[A, B, C]] {
+    return func() (T3[A, B, C], bool) {
+        var zero T3[A, B, C]
+        a, ok := as()
+        if !ok { return zero, false }
+        b, ok := bs()
+        if !ok { return zero, false }
+        c, ok := cs()
+        if !ok { return zero, false }
+        return T3[A, B, C]{A: a, B: b, C: c}, true
+    }
+}
+
+// Unzip3 is like [Unzip2], but for a [T3].
+func Unzip3[A any, B any, C any](ts []T3[A, B, C]) ([]A, []B, []C) {
+    as := make([]A, 0, len(ts))
+    bs := make([]B, 0, len(ts))
+    cs := make([]C, 0, len(ts))
+    for _, t := range ts {
+        as = append(as, t.A)
+        bs = append(bs, t.B)
+        cs = append(cs, t.C)
+    }
+    return as, bs, cs
+}
+
+// Zip4 is like [Zip2], but for four slices and a [T4].
+func Zip4[A any, B any, C any, D any](as []A, bs []B, cs []C, ds []D) []T4[A, B, C, D] {
+    n := len(as)
+    if len(bs) < n { n = len(bs) }
+    if len(cs) < n { n = len(cs) }
+    if len(ds) < n { n = len(ds) }
+    result := make([]T4[A, B, C, D], 0, n)
+    for i := 0; i < n; i++ {
+        result = append(result, T4[A, B, C, D]{A: as[i], B: bs[i], C: cs[i], D: ds[i]})
+    }
+    return result
+}
+
+// ZipIter4 is like [ZipIter2], but for four iterators and a [T4].
+func ZipIter4[A any, B any, C any, D any](as iter.It[A], bs iter.It[B], cs iter.It[C], ds iter.It[D]) iter.It[T4[A, B, C, D]] {
+    return func() (T4[A, B, C, D], bool) {
+        var zero T4[A, B, C, D]
+        a, ok := as()
+        if !ok { return zero, false }
+        b, ok := bs()
+        if !ok { return zero, false }
+        c, ok := cs()
+        if !ok { return zero, false }
+        d, ok := ds()
+        if !ok { return zero, false }
+        return T4[A, B, C, D]{A: a, B: b, C: c, D: d}, true
+    }
+}
+
+// Unzip4 is like [Unzip2], but for a [T4].
+func Unzip4[A any, B any, C any, D any](ts []T4[A, B, C, D]) ([]A, []B, []C, []D) {
+    as := make([]A, 0, len(ts))
+    bs := make([]B, 0, len(ts))
+    cs := make([]C, 0, len(ts))
+    ds := make([]D, 0, len(ts))
+    for _, t := range ts {
+        as = append(as, t.A)
+        bs = append(bs, t.B)
+        cs = append(cs, t.C)
+        ds = append(ds, t.D)
+    }
+    return as, bs, cs, ds
+}
@@ -0,0 +1,217 @@
+package view
+
+import (
+    "sort"
+    "strings"
+
+    "github.com/tawesoft/golib/v2/iter"
+    "golang.org/x/exp/constraints"
+)
+
+type sorted[K comparable, V any] struct {
+    View[K, V]
+    less func(a iter.Pair[K, V], b iter.Pair[K, V]) bool
+}
+
+// Sorted returns a new View of another View, where the Iter method produces
+// elements in the order defined by the less function. The sort is stable.
+//
+// Each call to Iter collects and sorts every element of the underlying View,
+// so changes to the underlying View after calling Iter are not reflected in
+// that iterator.
+//
+// See [KeyLess] and [ValueLess] for less functions that order elements by
+// key or by value.
+func Sorted[K comparable, V any](
+    v View[K, V],
+    less func(a iter.Pair[K, V], b iter.Pair[K, V]) bool,
+) View[K, V] {
+    return sorted[K, V]{View: v, less: less}
+}
+
+func (s sorted[K, V]) Iter() iter.It[iter.Pair[K, V]] {
+    pairs := iter.ToSlice(s.View.Iter())
+    sort.SliceStable(pairs, func(i, j int) bool {
+        return s.less(pairs[i], pairs[j])
+    })
+    return iter.FromSlice(pairs)
+}
+
+// KeyLess is a less function for [Sorted] that orders elements by key.
+func KeyLess[K constraints.Ordered, V any](a iter.Pair[K, V], b iter.Pair[K, V]) bool {
+    return a.Key < b.Key
+}
+
+// ValueLess is a less function for [Sorted] that orders elements by value.
+func ValueLess[K comparable, V constraints.Ordered](a iter.Pair[K, V], b iter.Pair[K, V]) bool {
+    return a.Value < b.Value
+}
+
+type union[K comparable, V any] struct {
+    views []View[K, V]
+}
+
+// Union returns a new View that combines several Views, in order of
+// precedence, such that a key in an earlier View hides the same key in any
+// later View. This is useful, for example, for layered configuration, such
+// as Union(environment, file, defaults).
+//
+// The Get method returns the value from the first View that has the key.
+// The Set and Delete methods change the first View only, so that deleting a
+// key may reveal the same key in a later View (for example, deleting an
+// environment override reveals the default). The Iter method produces each
+// key once, with the value from the first View that has the key.
+//
+// A Union of no Views is empty, and its Set and Delete methods do nothing.
+func Union[K comparable, V any](views ... View[K, V]) View[K, V] {
+    return union[K, V]{views: views}
+}
+
+func (u union[K, V]) Get(k K) (V, bool) {
+    for _, v := range u.views {
+        if x, ok := v.Get(k); ok { return x, true }
+    }
+    var zero V
+    return zero, false
+}
+
+func (u union[K, V]) Set(k K, x V) {
+    if len(u.views) == 0 { return }
+    u.views[0].Set(k, x)
+}
+
+func (u union[K, V]) Delete(k K) {
+    if len(u.views) == 0 { return }
+    u.views[0].Delete(k)
+}
+
+func (u union[K, V]) Iter() iter.It[iter.Pair[K, V]] {
+    seen := make(map[K]struct{})
+    its := make([]iter.It[iter.Pair[K, V]], 0, len(u.views))
+    for _, v := range u.views {
+        its = append(its, v.Iter())
+    }
+
+    return iter.Filter(func(kv iter.Pair[K, V]) bool {
+        if _, ok := seen[kv.Key]; ok { return false }
+        seen[kv.Key] = struct{}{}
+        return true
+    }, iter.Cat(its...))
+}
+
+// Nested describes a way to create a View from a Go map collection of
+// nested map collections, such as the result of decoding a JSON object into
+// a map[string]any, where each key in the View is a path.
+//
+// For example, with a Separator of ".", the key "server.port" refers to the
+// key "port" in a nested map at the key "server".
+type Nested struct {
+    // Separator separates the components of a path. If empty, defaults to
+    // ".".
+    Separator string
+}
+
+// Bind returns a new View over a Go map collection of nested map
+// collections.
+//
+// The Get method returns the value at a path, which may itself be a nested
+// map. The Set method creates any missing nested maps along the path, and
+// replaces any value along the path that is not a nested map. The Delete
+// method deletes the value at a path, but does not delete any nested map
+// that becomes empty as a result. The Iter method produces the path and
+// value of every value that is not itself a nested map.
+func (n Nested) Bind(c map[string]any) View[string, any] {
+    sep := n.Separator
+    if sep == "" { sep = "." }
+
+    // parent returns the map containing the last component of a path, and
+    // that component, optionally creating the map if it doesn't exist.
+    parent := func(path string, create bool) (map[string]any, string) {
+        parts := strings.Split(path, sep)
+        m := c
+        for _, p := range parts[:len(parts)-1] {
+            next, ok := m[p].(map[string]any)
+            if !ok {
+                if !create { return nil, "" }
+                next = make(map[string]any)
+                m[p] = next
+            }
+            m = next
+        }
+        return m, parts[len(parts)-1]
+    }
+
+    identity := func(x string) string { return x }
+    identityValue := func(x any) any { return x }
+
+    return Viewer[string, any, string, any]{
+        Filterer: func(string, any) bool { return true },
+        Getter: func(k string) (any, bool) {
+            m, last := parent(k, false)
+            if m == nil { return nil, false }
+            v, ok := m[last]
+            return v, ok
+        },
+        Setter: func(k string, v any) {
+            m, last := parent(k, true)
+            m[last] = v
+        },
+        Deleter: func(k string) {
+            m, last := parent(k, false)
+            if m == nil { return }
+            delete(m, last)
+        },
+        Iterer: func() iter.It[iter.Pair[string, any]] {
+            var pairs []iter.Pair[string, any]
+            var walk func(prefix string, m map[string]any)
+            walk = func(prefix string, m map[string]any) {
+                for k, v := range m {
+                    if nested, ok := v.(map[string]any); ok {
+                        walk(prefix + k + sep, nested)
+                    } else {
+                        pairs = append(pairs, iter.Pair[string, any]{Key: prefix + k, Value: v})
+                    }
+                }
+            }
+            walk("", c)
+            return iter.FromSlice(pairs)
+        },
+        ToKey:     identity,
+        FromKey:   identity,
+        ToValue:   identityValue,
+        FromValue: identityValue,
+    }
+}
+
+// Compose returns a new Viewer that stacks Viewer b on top of Viewer a, such
+// that keys and values are mapped first by a, and then by b, and an element
+// appears only if it passes the filters of both a and b.
+//
+// The returned Viewer accesses the underlying collection of a. Only the
+// Filterer, ToKey, FromKey, ToValue, and FromValue fields of b are used; any
+// other fields of b are ignored, and may be omitted.
+func Compose[K comparable, V any, K2 comparable, V2 any, K3 comparable, V3 any](
+    a Viewer[K, V, K2, V2],
+    b Viewer[K2, V2, K3, V3],
+) Viewer[K, V, K3, V3] {
+    var fromValue func(V3) V
+    if (a.FromValue != nil) && (b.FromValue != nil) {
+        fromValue = func(v V3) V { return a.FromValue(b.FromValue(v)) }
+    }
+
+    return Viewer[K, V, K3, V3]{
+        Filterer: func(k K, v V) bool {
+            if (a.Filterer != nil) && !a.Filterer(k, v) { return false }
+            if b.Filterer == nil { return true }
+            return b.Filterer(a.ToKey(k), a.ToValue(v))
+        },
+        Getter:    a.Getter,
+        Setter:    a.Setter,
+        Deleter:   a.Deleter,
+        Iterer:    a.Iterer,
+        ToKey:     func(k K) K3 { return b.ToKey(a.ToKey(k)) },
+        FromKey:   func(k K3) K { return a.FromKey(b.FromKey(k)) },
+        ToValue:   func(v V) V3 { return b.ToValue(a.ToValue(v)) },
+        FromValue: fromValue,
+    }
+}
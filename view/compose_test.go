@@ -0,0 +1,19 @@
+package view_test
+
+import (
+    "testing"
+
+    "github.com/stretchr/testify/assert"
+    "github.com/tawesoft/golib/v2/view"
+)
+
+func TestUnion_empty(t *testing.T) {
+    u := view.Union[string, int]()
+    u.Set("a", 1)
+    u.Delete("a")
+
+    _, ok := u.Get("a")
+    assert.False(t, ok)
+    _, ok = u.Iter()()
+    assert.False(t, ok)
+}
@@ -5,8 +5,10 @@ import (
     "net/url"
     "path"
     "sort"
+    "strings"
 
     "github.com/acarl005/stripansi"
+    "github.com/tawesoft/golib/v2/iter"
     "github.com/tawesoft/golib/v2/view"
 )
 
@@ -96,3 +98,101 @@ func Example_urlQuery() {
     // I see that you're friends with Jess!
     // Safe filename: index.html
 }
+
+// identity returns a view.Map that doesn't transform keys or values.
+func identity[K comparable, V any]() view.Map[K, V, K, V] {
+    return view.Map[K, V, K, V]{
+        Filterer:  func(K, V) bool { return true },
+        ToKey:     func(k K) K { return k },
+        FromKey:   func(k K) K { return k },
+        ToValue:   func(v V) V { return v },
+        FromValue: func(v V) V { return v },
+    }
+}
+
+func ExampleUnion() {
+    defaults := map[string]string{"host": "localhost", "port": "80"}
+    file     := map[string]string{"port": "8080"}
+    env      := map[string]string{}
+
+    config := view.Sorted(view.Union(
+        identity[string, string]().Bind(env),
+        identity[string, string]().Bind(file),
+        identity[string, string]().Bind(defaults),
+    ), view.KeyLess[string, string])
+
+    config.Set("host", "example.org") // stored in env
+    for it := config.Iter(); ; {
+        kv, ok := it()
+        if !ok { break }
+        fmt.Printf("%s=%s\n", kv.Key, kv.Value)
+    }
+    fmt.Println(env["host"], defaults["host"])
+
+    config.Delete("host") // deleted from env, revealing the default
+    host, _ := config.Get("host")
+    fmt.Println(host)
+
+    // Output:
+    // host=example.org
+    // port=8080
+    // example.org localhost
+    // localhost
+}
+
+func ExampleNested() {
+    doc := map[string]any{
+        "server": map[string]any{"port": 8080},
+    }
+    v := view.Nested{}.Bind(doc)
+
+    v.Set("server.host", "example.org")
+    v.Set("log.level", "debug")
+    port, _ := v.Get("server.port")
+    fmt.Println(port)
+
+    sorted := view.Sorted(v, view.KeyLess[string, any])
+    for it := sorted.Iter(); ; {
+        kv, ok := it()
+        if !ok { break }
+        fmt.Printf("%s=%v\n", kv.Key, kv.Value)
+    }
+
+    // Output:
+    // 8080
+    // log.level=debug
+    // server.host=example.org
+    // server.port=8080
+}
+
+func ExampleCompose() {
+    prices := map[string]int{"apple": 120, "pear": 80, "plum": 30}
+
+    base := view.Viewer[string, int, string, int]{
+        Filterer:  func(string, int) bool { return true },
+        Getter:    func(k string) (int, bool) { v, ok := prices[k]; return v, ok },
+        Setter:    func(k string, v int) { prices[k] = v },
+        Deleter:   func(k string) { delete(prices, k) },
+        Iterer:    func() iter.It[iter.Pair[string, int]] { return iter.FromMap(prices) },
+        ToKey:     func(k string) string { return k },
+        FromKey:   func(k string) string { return k },
+        ToValue:   func(v int) int { return v },
+        FromValue: func(v int) int { return v },
+    }
+
+    // only prices of at least one pound, formatted as pounds
+    pounds := view.Viewer[string, int, string, string]{
+        Filterer:  func(_ string, v int) bool { return v >= 100 },
+        ToKey:     strings.ToUpper,
+        FromKey:   strings.ToLower,
+        ToValue:   func(v int) string { return fmt.Sprintf("£%d.%02d", v / 100, v % 100) },
+    }
+
+    v := view.Compose(base, pounds)
+    fmt.Println(v.Get("APPLE"))
+    fmt.Println(v.Get("PEAR"))
+
+    // Output:
+    // £1.20 true
+    //  false
+}
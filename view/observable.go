@@ -0,0 +1,392 @@
+package view
+
+import (
+    "fmt"
+    "sync"
+
+    "github.com/tawesoft/golib/v2/iter"
+    "github.com/tawesoft/golib/v2/operator"
+)
+
+// ChangeKind describes the kind of a [Change].
+type ChangeKind int
+
+const (
+    Insert ChangeKind = iota // a new key
+    Update                   // a new value for an existing key
+    Delete                   // an existing key removed
+)
+
+func (k ChangeKind) String() string {
+    switch k {
+        case Insert: return "Insert"
+        case Update: return "Update"
+        case Delete: return "Delete"
+        default:     return "ChangeKind(?)"
+    }
+}
+
+// Change describes a change to an element of an [Observable] collection.
+//
+// For an Insert, Old is the zero value. For a Delete, New is the zero value.
+type Change[K comparable, V any] struct {
+    Kind ChangeKind
+    Key  K
+    Old  V
+    New  V
+}
+
+// Observable is a View that notifies subscribers of changes.
+type Observable[K comparable, V any] interface {
+    View[K, V]
+
+    // Subscribe registers a function that is called with each change, or
+    // batch of changes, to the collection. Changes are delivered
+    // synchronously, from the goroutine that made the change, after the
+    // change has been made, and in the order that changes were made. The
+    // returned function unsubscribes f, and may be called more than once.
+    //
+    // Changes are delivered before the method that made the change returns,
+    // so f must not itself change the collection.
+    Subscribe(f func([]Change[K, V])) (unsubscribe func())
+
+    // Batch calls f, and delivers every change made to the collection while
+    // f is running to subscribers together, as a single slice, once f
+    // returns. Batches may be nested, in which case changes are delivered
+    // once the outermost batch returns.
+    //
+    // A batch applies to the whole collection, not only to the goroutine that
+    // called Batch: changes made by other goroutines while f is running are
+    // also delivered once the outermost batch returns, from the goroutine
+    // that called Batch. Where this matters, don't change the collection from
+    // other goroutines during a batch.
+    Batch(f func())
+}
+
+// subscribers implements the Subscribe and Batch methods of an Observable.
+type subscribers[K comparable, V any] struct {
+    order   sync.Mutex // held from each change until it is delivered
+    mu      sync.Mutex
+    next    int
+    fs      map[int]func([]Change[K, V])
+    depth   int
+    pending []Change[K, V]
+}
+
+func (s *subscribers[K, V]) Subscribe(f func([]Change[K, V])) func() {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+
+    if s.fs == nil { s.fs = make(map[int]func([]Change[K, V])) }
+    id := s.next
+    s.next++
+    s.fs[id] = f
+
+    return func() {
+        s.mu.Lock()
+        defer s.mu.Unlock()
+        delete(s.fs, id)
+    }
+}
+
+func (s *subscribers[K, V]) Batch(f func()) {
+    s.mu.Lock()
+    s.depth++
+    s.mu.Unlock()
+
+    defer func() {
+        s.order.Lock()
+        defer s.order.Unlock()
+
+        s.mu.Lock()
+        s.depth--
+        var changes []Change[K, V]
+        if s.depth == 0 {
+            changes = s.pending
+            s.pending = nil
+        }
+        s.mu.Unlock()
+        s.notify(changes)
+    }()
+
+    f()
+}
+
+// change calls f, which changes the collection, and returns a description of
+// the change, if any. Then, delivers the change to subscribers, so that
+// changes are delivered in the same order that they are made.
+func (s *subscribers[K, V]) change(f func() (Change[K, V], bool)) {
+    s.order.Lock()
+    defer s.order.Unlock()
+
+    if c, ok := f(); ok { s.emit(c) }
+}
+
+// emit delivers a change to subscribers, or queues it if a batch is in
+// progress.
+func (s *subscribers[K, V]) emit(c Change[K, V]) {
+    s.mu.Lock()
+    if s.depth > 0 {
+        s.pending = append(s.pending, c)
+        s.mu.Unlock()
+        return
+    }
+    s.mu.Unlock()
+    s.notify([]Change[K, V]{c})
+}
+
+func (s *subscribers[K, V]) notify(changes []Change[K, V]) {
+    if len(changes) == 0 { return }
+
+    // copy, so that subscribers may subscribe or unsubscribe
+    s.mu.Lock()
+    fs := make([]func([]Change[K, V]), 0, len(s.fs))
+    for _, f := range s.fs {
+        fs = append(fs, f)
+    }
+    s.mu.Unlock()
+
+    for _, f := range fs {
+        f(changes)
+    }
+}
+
+// ObservableMap is an [Observable] Go map collection.
+//
+// The methods of an ObservableMap are safe for concurrent use.
+type ObservableMap[K comparable, V any] struct {
+    subscribers[K, V]
+    mu sync.RWMutex
+    c  map[K]V
+}
+
+// NewObservableMap returns a new ObservableMap that takes ownership of the Go
+// map c, which must not be modified directly afterwards. If c is nil, a new
+// empty map is created.
+func NewObservableMap[K comparable, V any](c map[K]V) *ObservableMap[K, V] {
+    if c == nil { c = make(map[K]V) }
+    return &ObservableMap[K, V]{c: c}
+}
+
+func (m *ObservableMap[K, V]) Get(k K) (V, bool) {
+    m.mu.RLock()
+    defer m.mu.RUnlock()
+    v, ok := m.c[k]
+    return v, ok
+}
+
+// Set sets the value for the key k, notifying subscribers of an Insert or an
+// Update.
+func (m *ObservableMap[K, V]) Set(k K, v V) {
+    m.change(func() (Change[K, V], bool) {
+        m.mu.Lock()
+        defer m.mu.Unlock()
+
+        old, exists := m.c[k]
+        m.c[k] = v
+
+        if exists {
+            return Change[K, V]{Kind: Update, Key: k, Old: old, New: v}, true
+        }
+        return Change[K, V]{Kind: Insert, Key: k, New: v}, true
+    })
+}
+
+// Delete deletes the key k, notifying subscribers of a Delete if the key
+// existed.
+func (m *ObservableMap[K, V]) Delete(k K) {
+    m.change(func() (Change[K, V], bool) {
+        m.mu.Lock()
+        defer m.mu.Unlock()
+
+        old, exists := m.c[k]
+        delete(m.c, k)
+        return Change[K, V]{Kind: Delete, Key: k, Old: old}, exists
+    })
+}
+
+// Iter returns an iterator over a snapshot of the map's elements at the time
+// Iter was called.
+func (m *ObservableMap[K, V]) Iter() iter.It[iter.Pair[K, V]] {
+    m.mu.RLock()
+    defer m.mu.RUnlock()
+    return iter.FromSlice(iter.ToSlice(iter.FromMap(m.c)))
+}
+
+// Len returns the number of elements in the map.
+func (m *ObservableMap[K, V]) Len() int {
+    m.mu.RLock()
+    defer m.mu.RUnlock()
+    return len(m.c)
+}
+
+// ObservableSlice is an [Observable] Go slice collection, where each key is
+// an index.
+//
+// The methods of an ObservableSlice are safe for concurrent use.
+type ObservableSlice[V any] struct {
+    subscribers[int, V]
+    mu sync.RWMutex
+    c  []V
+}
+
+// NewObservableSlice returns a new ObservableSlice that takes ownership of
+// the Go slice c, which must not be modified directly afterwards.
+func NewObservableSlice[V any](c []V) *ObservableSlice[V] {
+    return &ObservableSlice[V]{c: c}
+}
+
+func (s *ObservableSlice[V]) Get(i int) (V, bool) {
+    s.mu.RLock()
+    defer s.mu.RUnlock()
+    if (i < 0) || (i >= len(s.c)) {
+        return operator.Zero[V](), false
+    }
+    return s.c[i], true
+}
+
+// Set sets the value at index i, notifying subscribers of an Update, or, if
+// i is equal to the length of the slice, appends the value, notifying
+// subscribers of an Insert. Panics if i is out of range otherwise.
+func (s *ObservableSlice[V]) Set(i int, v V) {
+    s.change(func() (Change[int, V], bool) {
+        s.mu.Lock()
+        defer s.mu.Unlock()
+
+        if (i < 0) || (i > len(s.c)) {
+            panic(fmt.Sprintf("view: ObservableSlice index %d out of range [0:%d]", i, len(s.c)))
+        } else if i == len(s.c) {
+            s.c = append(s.c, v)
+            return Change[int, V]{Kind: Insert, Key: i, New: v}, true
+        }
+
+        old := s.c[i]
+        s.c[i] = v
+        return Change[int, V]{Kind: Update, Key: i, Old: old, New: v}, true
+    })
+}
+
+// Delete removes the value at index i, if it exists, notifying subscribers
+// of a Delete. The index of every later value is reduced by one, without any
+// further notification.
+func (s *ObservableSlice[V]) Delete(i int) {
+    s.change(func() (Change[int, V], bool) {
+        s.mu.Lock()
+        defer s.mu.Unlock()
+
+        if (i < 0) || (i >= len(s.c)) { return Change[int, V]{}, false }
+        old := s.c[i]
+        s.c = append(s.c[0:i], s.c[i+1:]...)
+        return Change[int, V]{Kind: Delete, Key: i, Old: old}, true
+    })
+}
+
+// Iter returns an iterator over a snapshot of the slice's elements at the
+// time Iter was called.
+func (s *ObservableSlice[V]) Iter() iter.It[iter.Pair[int, V]] {
+    s.mu.RLock()
+    defer s.mu.RUnlock()
+    c := append([]V(nil), s.c...)
+    return iter.Enumerate(iter.FromSlice(c))
+}
+
+// Len returns the number of elements in the slice.
+func (s *ObservableSlice[V]) Len() int {
+    s.mu.RLock()
+    defer s.mu.RUnlock()
+    return len(s.c)
+}
+
+type observed[K comparable, V any, ToK comparable, ToV any] struct {
+    Viewer[K, V, ToK, ToV]
+    source Observable[K, V]
+}
+
+func (o observed[K, V, ToK, ToV]) Batch(f func()) {
+    o.source.Batch(f)
+}
+
+// Subscribe registers a function that is called with each change, mapped
+// by the view's key and value mappings. An Update that moves an element into
+// or out of the view's filter is delivered as an Insert or a Delete, and
+// changes to elements that are filtered out are not delivered at all.
+func (o observed[K, V, ToK, ToV]) Subscribe(f func([]Change[ToK, ToV])) func() {
+    return o.source.Subscribe(func(changes []Change[K, V]) {
+        mapped := make([]Change[ToK, ToV], 0, len(changes))
+        for _, c := range changes {
+            if mc, ok := o.mapChange(c); ok {
+                mapped = append(mapped, mc)
+            }
+        }
+        if len(mapped) > 0 { f(mapped) }
+    })
+}
+
+func (o observed[K, V, ToK, ToV]) mapChange(c Change[K, V]) (Change[ToK, ToV], bool) {
+    oldIn := (c.Kind != Insert) && o.Filterer(c.Key, c.Old)
+    newIn := (c.Kind != Delete) && o.Filterer(c.Key, c.New)
+
+    result := Change[ToK, ToV]{Key: o.ToKey(c.Key)}
+    switch {
+        case oldIn && newIn:
+            result.Kind = Update
+        case newIn:
+            result.Kind = Insert
+        case oldIn:
+            result.Kind = Delete
+        default:
+            return Change[ToK, ToV]{}, false
+    }
+
+    if oldIn { result.Old = o.ToValue(c.Old) }
+    if newIn { result.New = o.ToValue(c.New) }
+    return result, true
+}
+
+// BindObservable returns a new Observable View over an Observable
+// collection, such as an [ObservableMap]. Changes to the collection are
+// delivered to subscribers of the returned View, mapped by the Map's key and
+// value mappings, and filtered by its Filterer.
+func (m Map[K, V, ToK, ToV]) BindObservable(o Observable[K, V]) Observable[ToK, ToV] {
+    return observed[K, V, ToK, ToV]{
+        Viewer: Viewer[K, V, ToK, ToV]{
+            Filterer: func(k K, v V) bool {
+                if m.Filterer == nil { return true }
+                return m.Filterer(k, v)
+            },
+            Getter:    o.Get,
+            Setter:    o.Set,
+            Deleter:   o.Delete,
+            Iterer:    o.Iter,
+            ToKey:     m.ToKey,
+            FromKey:   m.FromKey,
+            ToValue:   m.ToValue,
+            FromValue: m.FromValue,
+        },
+        source: o,
+    }
+}
+
+// BindObservable returns a new Observable View over an Observable
+// collection, such as an [ObservableSlice]. Changes to the collection are
+// delivered to subscribers of the returned View, mapped by the Slice's value
+// mapping, and filtered by its Filterer.
+func (s Slice[V, ToV]) BindObservable(o Observable[int, V]) Observable[int, ToV] {
+    return observed[int, V, int, ToV]{
+        Viewer: Viewer[int, V, int, ToV]{
+            Filterer: func(_ int, v V) bool {
+                if s.Filterer == nil { return true }
+                return s.Filterer(v)
+            },
+            Getter:    o.Get,
+            Setter:    o.Set,
+            Deleter:   o.Delete,
+            Iterer:    o.Iter,
+            ToKey:     func (x int) int { return x },
+            FromKey:   func (x int) int { return x },
+            ToValue:   s.ToValue,
+            FromValue: s.FromValue,
+        },
+        source: o,
+    }
+}
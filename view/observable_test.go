@@ -0,0 +1,182 @@
+package view_test
+
+import (
+    "strconv"
+    "sync"
+    "testing"
+
+    "github.com/stretchr/testify/assert"
+    "github.com/tawesoft/golib/v2/view"
+)
+
+func TestObservableMap(t *testing.T) {
+    m := view.NewObservableMap[string, int](nil)
+
+    var got []view.Change[string, int]
+    unsubscribe := m.Subscribe(func(cs []view.Change[string, int]) {
+        got = append(got, cs...)
+    })
+
+    m.Set("a", 1)
+    m.Set("a", 2)
+    m.Delete("a")
+    m.Delete("missing")
+    unsubscribe()
+    m.Set("b", 3)
+
+    assert.Equal(t, []view.Change[string, int]{
+        {Kind: view.Insert, Key: "a", New: 1},
+        {Kind: view.Update, Key: "a", Old: 1, New: 2},
+        {Kind: view.Delete, Key: "a", Old: 2},
+    }, got)
+    assert.Equal(t, 1, m.Len())
+}
+
+func TestObservableMap_Batch(t *testing.T) {
+    m := view.NewObservableMap[string, int](nil)
+
+    var calls [][]view.Change[string, int]
+    m.Subscribe(func(cs []view.Change[string, int]) {
+        calls = append(calls, cs)
+    })
+
+    m.Batch(func() {
+        m.Set("a", 1)
+        m.Batch(func() {
+            m.Set("b", 2)
+        })
+        assert.Len(t, calls, 0)
+    })
+
+    assert.Equal(t, [][]view.Change[string, int]{{
+        {Kind: view.Insert, Key: "a", New: 1},
+        {Kind: view.Insert, Key: "b", New: 2},
+    }}, calls)
+}
+
+func TestObservableSlice(t *testing.T) {
+    s := view.NewObservableSlice([]string{"a", "b"})
+
+    var got []view.Change[int, string]
+    s.Subscribe(func(cs []view.Change[int, string]) {
+        got = append(got, cs...)
+    })
+
+    s.Set(2, "c")
+    s.Set(0, "A")
+    s.Delete(1)
+
+    assert.Equal(t, []view.Change[int, string]{
+        {Kind: view.Insert, Key: 2, New: "c"},
+        {Kind: view.Update, Key: 0, Old: "a", New: "A"},
+        {Kind: view.Delete, Key: 1, Old: "b"},
+    }, got)
+
+    v, ok := s.Get(1)
+    assert.True(t, ok)
+    assert.Equal(t, "c", v)
+}
+
+func TestObservableSlice_outOfRange(t *testing.T) {
+    s := view.NewObservableSlice([]string{"a"})
+
+    assert.Panics(t, func() { s.Set(2, "c") })
+    assert.Panics(t, func() { s.Set(-1, "c") })
+
+    // not left locked by the panic
+    s.Set(1, "b")
+    assert.Equal(t, 2, s.Len())
+}
+
+func TestObservableMap_concurrent(t *testing.T) {
+    m := view.NewObservableMap[int, int](nil)
+
+    // a mirror of the map, kept up to date by its changes
+    mirror := make(map[int]int)
+    m.Subscribe(func(cs []view.Change[int, int]) {
+        for _, c := range cs {
+            switch c.Kind {
+                case view.Insert, view.Update: mirror[c.Key] = c.New
+                case view.Delete:              delete(mirror, c.Key)
+            }
+        }
+    })
+
+    var wg sync.WaitGroup
+    for i := 0; i < 8; i++ {
+        wg.Add(1)
+        go func(i int) {
+            defer wg.Done()
+            for j := 0; j < 100; j++ {
+                m.Set(j % 10, i)
+                if j % 3 == 0 { m.Delete(j % 10) }
+            }
+        }(i)
+    }
+    wg.Wait()
+
+    assert.Equal(t, m.Len(), len(mirror))
+    for k, v := range mirror {
+        got, ok := m.Get(k)
+        assert.True(t, ok)
+        assert.Equal(t, got, v)
+    }
+}
+
+func TestMap_BindObservable(t *testing.T) {
+    m := view.NewObservableMap[int, int](nil)
+    v := view.Map[int, int, string, string]{
+        Filterer:  func(_ int, v int) bool { return v >= 0 },
+        ToKey:     strconv.Itoa,
+        FromKey:   func(k string) int { i, _ := strconv.Atoi(k); return i },
+        ToValue:   strconv.Itoa,
+        FromValue: func(v string) int { i, _ := strconv.Atoi(v); return i },
+    }.BindObservable(m)
+
+    var got []view.Change[string, string]
+    v.Subscribe(func(cs []view.Change[string, string]) {
+        got = append(got, cs...)
+    })
+
+    v.Set("1", "10")  // insert
+    m.Set(1, -1)      // update out of filter
+    m.Set(1, -2)      // update while filtered out
+    m.Set(1, 5)       // update into filter
+    m.Set(1, 6)       // update
+    m.Delete(1)       // delete
+    m.Set(2, -1)      // insert while filtered out
+    m.Delete(2)       // delete while filtered out
+
+    assert.Equal(t, []view.Change[string, string]{
+        {Kind: view.Insert, Key: "1", New: "10"},
+        {Kind: view.Delete, Key: "1", Old: "10"},
+        {Kind: view.Insert, Key: "1", New: "5"},
+        {Kind: view.Update, Key: "1", Old: "5", New: "6"},
+        {Kind: view.Delete, Key: "1", Old: "6"},
+    }, got)
+}
+
+func TestSlice_BindObservable(t *testing.T) {
+    s := view.NewObservableSlice([]int{1, 2})
+    v := view.Slice[int, string]{
+        ToValue: strconv.Itoa,
+    }.BindObservable(s)
+
+    var calls int
+    var got []view.Change[int, string]
+    v.Subscribe(func(cs []view.Change[int, string]) {
+        calls++
+        got = append(got, cs...)
+    })
+
+    v.Batch(func() {
+        s.Set(0, 3)
+        s.Set(2, 4)
+    })
+
+    assert.Equal(t, 1, calls)
+    assert.Equal(t, []view.Change[int, string]{
+        {Kind: view.Update, Key: 0, Old: "1", New: "3"},
+        {Kind: view.Insert, Key: 2, New: "4"},
+    }, got)
+}
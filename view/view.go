@@ -1,5 +1,10 @@
 // Package view provides customisable abstractions over collections. Changes to
 // an underlying collection are reflected in its views, and vice-versa.
+//
+// An [Observable] collection, such as an [ObservableMap] or
+// [ObservableSlice], additionally notifies subscribers of each change, and
+// views bound to it with BindObservable deliver those changes mapped to the
+// view's keys and values.
 package view
 
 import (